@@ -3,6 +3,7 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"html/template"
 	"log"
@@ -14,9 +15,13 @@ import (
 	// Primary adapters (driving adapters)
 	httpHandlers "uni-task-manager/internal/adapters/primary/http"
 	// Secondary adapters (driven adapters)
+	"uni-task-manager/internal/adapters/secondary/notify"
 	"uni-task-manager/internal/adapters/secondary/sqlite"
 	// Domain services
 	"uni-task-manager/internal/domain/services"
+	// Ports
+	"uni-task-manager/internal/ports/input"
+	"uni-task-manager/internal/ports/output"
 
 	"github.com/gorilla/mux"
 	_ "modernc.org/sqlite"
@@ -40,19 +45,36 @@ func run() error {
 		return err
 	}
 
-	// Initialize database connection and schema
+	// Initialize database connection
 	db, err := initializeDatabase()
 	if err != nil {
 		return err
 	}
 	defer db.Close()
 
+	// The migrate command manages the schema explicitly instead of starting the server
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		return runMigrate(db, os.Args[2:])
+	}
+
+	// Bring the schema up to date before serving requests
+	if err := migrateDatabase(db); err != nil {
+		return err
+	}
+
 	// Initialize application components (services, handlers)
 	app, err := initializeApplication(db)
 	if err != nil {
 		return err
 	}
 
+	// Send reminders in the background while serving requests
+	go runReminders(app.reminderService, reminderInterval)
+
+	// Dispatch the events of the outbox and deliver them to webhooks in the background while serving requests
+	go runOutbox(app.outboxService, outboxInterval)
+	go runWebhooks(app.webhookService, webhookInterval)
+
 	// Start HTTP server
 	return startServer(app)
 }
@@ -63,26 +85,52 @@ func setupInfrastructure() error {
 	return os.MkdirAll(dbDir, 0755)
 }
 
-// initializeDatabase sets up the SQLite database connection and schema
+// initializeDatabase sets up the SQLite database connection.
+// Foreign key enforcement is off by default in SQLite, so every connection of the pool enables it.
+// Transactions take the write lock when they begin and wait up to 5 seconds for it, so concurrent
+// units of work queue up instead of failing with SQLITE_BUSY.
 func initializeDatabase() (*sql.DB, error) {
 	dbPath := filepath.Join(".", "data", "uni-tasks.db")
-	db, err := sql.Open("sqlite", dbPath)
+	return sql.Open("sqlite", "file:"+dbPath+"?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_txlock=immediate")
+}
+
+// migrateDatabase applies any pending schema migrations
+func migrateDatabase(db *sql.DB) error {
+	migrator, err := sqlite.NewMigrator(db)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	// Initialize schema
-	if err := createDatabaseSchema(db); err != nil {
-		return nil, err
+	applied, err := migrator.Up(context.Background())
+	for _, migration := range applied {
+		log.Printf("Applied migration %04d_%s", migration.Version, migration.Name)
+	}
+	if err != nil {
+		return err
 	}
 
-	return db, nil
+	log.Println("Database schema is up to date")
+	return nil
 }
 
+const (
+	// reminderInterval is how often the reminder scheduler looks for reminders to send
+	reminderInterval = time.Minute
+
+	// outboxInterval is how often the outbox dispatcher looks for events to dispatch
+	outboxInterval = 2 * time.Second
+
+	// webhookInterval is how often the webhook dispatcher looks for events to deliver
+	webhookInterval = 10 * time.Second
+)
+
 // application holds the initialized components of the application
 type application struct {
-	handler   *httpHandlers.Handler
-	templates *template.Template
+	handler         *httpHandlers.Handler
+	templates       *template.Template
+	reminderService input.ReminderService
+	outboxService   input.OutboxService
+	webhookService  input.WebhookService
 }
 
 // initializeApplication sets up all application components following hexagonal architecture
@@ -90,10 +138,41 @@ func initializeApplication(db *sql.DB) (*application, error) {
 	// Initialize repositories (secondary/driven adapters)
 	taskRepo := sqlite.NewTaskRepository(db)
 	courseRepo := sqlite.NewCourseRepository(db)
+	recurrenceRepo := sqlite.NewRecurrenceRepository(db)
+	userRepo := sqlite.NewUserRepository(db)
+	sessionRepo := sqlite.NewSessionRepository(db)
+	tokenRepo := sqlite.NewAPITokenRepository(db)
+	tagRepo := sqlite.NewTagRepository(db)
+	dependencyRepo := sqlite.NewDependencyRepository(db)
+	timeEntryRepo := sqlite.NewTimeEntryRepository(db)
+	availabilityRepo := sqlite.NewAvailabilityRepository(db)
+	reminderRepo := sqlite.NewReminderRepository(db)
+	webhookRepo := sqlite.NewWebhookRepository(db)
+	outboxRepo := sqlite.NewOutboxRepository(db)
+	historyRepo := sqlite.NewTaskHistoryRepository(db)
+	uow := sqlite.NewUnitOfWork(db)
+
+	// Initialize notification channels (secondary/driven adapters)
+	notifiers, err := initializeNotifiers()
+	if err != nil {
+		return nil, err
+	}
 
-	// Initialize domain services
-	taskService := services.NewTaskService(taskRepo, courseRepo)
-	courseService := services.NewCourseService(courseRepo)
+	// Initialize domain services; task and course events go through the outbox to the webhooks.
+	// Webhooks may only target private networks when WEBHOOK_ALLOW_PRIVATE_NETWORKS is set, for development.
+	webhookClient := notify.NewWebhookClient(os.Getenv("WEBHOOK_ALLOW_PRIVATE_NETWORKS") != "")
+	webhookService := services.NewWebhookService(webhookRepo, webhookClient)
+	outboxService := services.NewOutboxService(outboxRepo, uow, []output.EventSubscriber{webhookService})
+	taskService := services.NewTaskService(taskRepo, courseRepo, recurrenceRepo, tagRepo, dependencyRepo, historyRepo, uow, outboxService)
+	courseService := services.NewCourseService(courseRepo, taskRepo, historyRepo, uow, outboxService)
+	importService := services.NewImportService(taskService, courseService, taskRepo, courseRepo)
+	userService := services.NewUserService(userRepo, sessionRepo, uow)
+	tokenService := services.NewTokenService(tokenRepo)
+	tagService := services.NewTagService(tagRepo, taskRepo, uow)
+	timeService := services.NewTimeService(timeEntryRepo, taskRepo)
+	workloadService := services.NewWorkloadService(taskRepo, timeEntryRepo)
+	plannerService := services.NewPlannerService(availabilityRepo, taskRepo, timeEntryRepo)
+	reminderService := services.NewReminderService(reminderRepo, taskRepo, userRepo, notifiers, nil)
 
 	// Load HTML templates
 	templatesDir := filepath.Join(".", "web", "templates")
@@ -103,36 +182,216 @@ func initializeApplication(db *sql.DB) (*application, error) {
 	}
 
 	// Initialize HTTP handlers (primary/driving adapters)
-	handler := httpHandlers.NewHandler(taskService, courseService, templates)
+	handler := httpHandlers.NewHandler(taskService, courseService, tagService, timeService, workloadService, plannerService, importService, userService, tokenService, webhookService, templates)
 
 	return &application{
-		handler:   handler,
-		templates: templates,
+		handler:         handler,
+		templates:       templates,
+		reminderService: reminderService,
+		outboxService:   outboxService,
+		webhookService:  webhookService,
 	}, nil
 }
 
+// initializeNotifiers sets up the channels reminders are delivered through.
+// Reminders are always appended to data/reminders.log (or REMINDER_LOG_FILE, "-" for standard
+// output); email is sent when SMTP_ADDR is set and webhooks are posted when REMINDER_WEBHOOK_URL is.
+func initializeNotifiers() ([]output.Notifier, error) {
+	var notifiers []output.Notifier
+
+	logFile := os.Getenv("REMINDER_LOG_FILE")
+	if logFile == "" {
+		logFile = filepath.Join(".", "data", "reminders.log")
+	}
+	if logFile == "-" {
+		notifiers = append(notifiers, notify.NewFileNotifier(os.Stdout))
+	} else {
+		fileNotifier, err := notify.OpenFileNotifier(logFile)
+		if err != nil {
+			return nil, err
+		}
+		notifiers = append(notifiers, fileNotifier)
+	}
+
+	if addr := os.Getenv("SMTP_ADDR"); addr != "" {
+		notifiers = append(notifiers, notify.NewEmailNotifier(addr, os.Getenv("SMTP_FROM"), os.Getenv("SMTP_USERNAME"), os.Getenv("SMTP_PASSWORD")))
+	}
+
+	if url := os.Getenv("REMINDER_WEBHOOK_URL"); url != "" {
+		notifiers = append(notifiers, notify.NewWebhookNotifier(url))
+	}
+
+	for _, notifier := range notifiers {
+		log.Printf("Reminders are delivered by %s", notifier.Channel())
+	}
+	return notifiers, nil
+}
+
+// runReminders sends the due reminders right away and then at every interval, for as long as the process runs.
+// Delivery state is stored, so reminders missed while the server was down are sent on startup.
+func runReminders(reminderService input.ReminderService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		run, err := reminderService.SendDueReminders(context.Background(), time.Now().UTC())
+		if err != nil {
+			log.Printf("Error sending reminders: %v", err)
+		} else if run.Sent > 0 || run.Failed > 0 {
+			log.Printf("Sent %d reminder(s), %d failed", run.Sent, run.Failed)
+		}
+		<-ticker.C
+	}
+}
+
+// runOutbox dispatches the events recorded in the outbox right away and then at every interval, for as long
+// as the process runs. Events stay in the outbox until dispatched, so none is lost to a crash or restart.
+func runOutbox(outboxService input.OutboxService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		run, err := outboxService.DispatchPending(context.Background(), time.Now().UTC())
+		if err != nil {
+			log.Printf("Error dispatching events: %v", err)
+		} else if run.Retrying > 0 {
+			log.Printf("Dispatched %d event(s), %d to retry", run.Dispatched, run.Retrying)
+		}
+		<-ticker.C
+	}
+}
+
+// runWebhooks delivers the pending webhook events right away and then at every interval, for as long as
+// the process runs. Deliveries are stored, so events queued while the server was down are delivered on startup.
+func runWebhooks(webhookService input.WebhookService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		run, err := webhookService.DeliverPending(context.Background(), time.Now().UTC())
+		if err != nil {
+			log.Printf("Error delivering webhooks: %v", err)
+		} else if run.Retrying > 0 || run.Failed > 0 {
+			log.Printf("Delivered %d webhook event(s), %d to retry, %d failed", run.Succeeded, run.Retrying, run.Failed)
+		}
+		<-ticker.C
+	}
+}
+
 // startServer configures and starts the HTTP server
 func startServer(app *application) error {
 	// Create router and configure routes
 	r := mux.NewRouter()
 
-	// Web routes for the user interface
-	r.HandleFunc("/", app.handler.Index).Methods("GET")
-	r.HandleFunc("/tasks/new", app.handler.CreateTaskForm).Methods("GET")
-	r.HandleFunc("/tasks", app.handler.CreateTask).Methods("POST")
-	r.HandleFunc("/tasks/{id:[0-9]+}/edit", app.handler.EditTaskForm).Methods("GET")
-	r.HandleFunc("/tasks/{id:[0-9]+}", app.handler.UpdateTask).Methods("POST")
-	r.HandleFunc("/tasks/{id:[0-9]+}/delete", app.handler.DeleteTask).Methods("POST")
-	r.HandleFunc("/courses", app.handler.ListCourses).Methods("GET")
-	r.HandleFunc("/courses/new", app.handler.CreateCourseForm).Methods("GET")
-	r.HandleFunc("/courses", app.handler.CreateCourse).Methods("POST")
-
-	// API routes for programmatic access
-	r.HandleFunc("/api/tasks", app.handler.APIGetTasks).Methods("GET")
-	r.HandleFunc("/api/tasks/{id:[0-9]+}", app.handler.APIGetTask).Methods("GET")
-	r.HandleFunc("/api/tasks", app.handler.APICreateTask).Methods("POST")
-	r.HandleFunc("/api/tasks/{id:[0-9]+}", app.handler.APIUpdateTask).Methods("PUT")
-	r.HandleFunc("/api/tasks/{id:[0-9]+}", app.handler.APIDeleteTask).Methods("DELETE")
+	// Account routes, available without a session
+	r.HandleFunc("/login", app.handler.LoginForm).Methods("GET")
+	r.HandleFunc("/login", app.handler.Login).Methods("POST")
+	r.HandleFunc("/register", app.handler.RegisterForm).Methods("GET")
+	r.HandleFunc("/register", app.handler.Register).Methods("POST")
+	r.HandleFunc("/logout", app.handler.Logout).Methods("POST")
+
+	// API routes for programmatic access, authenticated by API token or session
+	api := r.PathPrefix("/api").Subrouter()
+	api.Use(app.handler.RequireAPIAuth)
+	api.NotFoundHandler = http.HandlerFunc(httpHandlers.APINotFound)
+	api.HandleFunc("/tasks", app.handler.APIGetTasks).Methods("GET")
+	api.HandleFunc("/tasks/{id:[0-9]+}", app.handler.APIGetTask).Methods("GET")
+	api.HandleFunc("/tasks", app.handler.APICreateTask).Methods("POST")
+	api.HandleFunc("/tasks/{id:[0-9]+}", app.handler.APIUpdateTask).Methods("PUT")
+	api.HandleFunc("/tasks/{id:[0-9]+}", app.handler.APIPatchTask).Methods("PATCH")
+	api.HandleFunc("/tasks/{id:[0-9]+}/status", app.handler.APISetTaskStatus).Methods("POST")
+	api.HandleFunc("/tasks/{id:[0-9]+}", app.handler.APIDeleteTask).Methods("DELETE")
+	api.HandleFunc("/tasks/{id:[0-9]+}/subtasks", app.handler.APIGetSubtasks).Methods("GET")
+	api.HandleFunc("/tasks/{id:[0-9]+}/subtasks", app.handler.APICreateSubtask).Methods("POST")
+	api.HandleFunc("/tasks/{id:[0-9]+}/recurrence", app.handler.APISetRecurrence).Methods("PUT")
+	api.HandleFunc("/tasks/{id:[0-9]+}/recurrence", app.handler.APIDeleteRecurrence).Methods("DELETE")
+	api.HandleFunc("/tasks/{id:[0-9]+}/series", app.handler.APIGetSeries).Methods("GET")
+	api.HandleFunc("/tasks/{id:[0-9]+}/tags", app.handler.APIGetTaskTags).Methods("GET")
+	api.HandleFunc("/tasks/{id:[0-9]+}/tags", app.handler.APISetTaskTags).Methods("PUT")
+	api.HandleFunc("/tasks/{id:[0-9]+}/dependencies", app.handler.APIGetDependencies).Methods("GET")
+	api.HandleFunc("/tasks/{id:[0-9]+}/dependencies", app.handler.APIAddDependency).Methods("POST")
+	api.HandleFunc("/tasks/{id:[0-9]+}/dependencies/{dependsOnId:[0-9]+}", app.handler.APIRemoveDependency).Methods("DELETE")
+	api.HandleFunc("/tasks/{id:[0-9]+}/time-entries", app.handler.APIGetTimeEntries).Methods("GET")
+	api.HandleFunc("/tasks/{id:[0-9]+}/time-entries", app.handler.APILogTime).Methods("POST")
+	api.HandleFunc("/tasks/{id:[0-9]+}/time-entries/start", app.handler.APIStartTimer).Methods("POST")
+	api.HandleFunc("/tasks/{id:[0-9]+}/time-entries/stop", app.handler.APIStopTimer).Methods("POST")
+	api.HandleFunc("/tasks/{id:[0-9]+}/time-entries/{entryId:[0-9]+}", app.handler.APIDeleteTimeEntry).Methods("DELETE")
+	api.HandleFunc("/tasks/{id:[0-9]+}/history", app.handler.APIGetTaskHistory).Methods("GET")
+	api.HandleFunc("/tasks/{id:[0-9]+}/history/{entryId:[0-9]+}/restore", app.handler.APIRestoreTaskVersion).Methods("POST")
+	api.HandleFunc("/time-totals", app.handler.APIGetTimeTotals).Methods("GET")
+	api.HandleFunc("/workload", app.handler.APIGetWorkload).Methods("GET")
+	api.HandleFunc("/plan", app.handler.APIGetPlan).Methods("GET")
+	api.HandleFunc("/availability", app.handler.APIGetAvailability).Methods("GET")
+	api.HandleFunc("/availability", app.handler.APIAddAvailability).Methods("POST")
+	api.HandleFunc("/availability/{id:[0-9]+}", app.handler.APIDeleteAvailability).Methods("DELETE")
+	api.HandleFunc("/search", app.handler.APISearchTasks).Methods("GET")
+	api.HandleFunc("/courses", app.handler.APIGetCourses).Methods("GET")
+	api.HandleFunc("/courses/{id:[0-9]+}", app.handler.APIGetCourse).Methods("GET")
+	api.HandleFunc("/courses", app.handler.APICreateCourse).Methods("POST")
+	api.HandleFunc("/courses/{id:[0-9]+}", app.handler.APIUpdateCourse).Methods("PUT")
+	api.HandleFunc("/courses/{id:[0-9]+}", app.handler.APIDeleteCourse).Methods("DELETE")
+	api.HandleFunc("/courses/{id:[0-9]+}/tasks", app.handler.APIGetCourseTasks).Methods("GET")
+	api.HandleFunc("/tags", app.handler.APIGetTags).Methods("GET")
+	api.HandleFunc("/tags", app.handler.APICreateTag).Methods("POST")
+	api.HandleFunc("/tags/{id:[0-9]+}", app.handler.APIUpdateTag).Methods("PUT")
+	api.HandleFunc("/tags/{id:[0-9]+}", app.handler.APIDeleteTag).Methods("DELETE")
+	api.HandleFunc("/import/ics", app.handler.APIImportICS).Methods("POST")
+	api.HandleFunc("/tokens", httpHandlers.SessionOnly(app.handler.APIGetTokens)).Methods("GET")
+	api.HandleFunc("/tokens", httpHandlers.SessionOnly(app.handler.APICreateToken)).Methods("POST")
+	api.HandleFunc("/tokens/{id:[0-9]+}", httpHandlers.SessionOnly(app.handler.APIDeleteToken)).Methods("DELETE")
+	api.HandleFunc("/webhooks", httpHandlers.SessionOnly(app.handler.APIGetWebhooks)).Methods("GET")
+	api.HandleFunc("/webhooks", httpHandlers.SessionOnly(app.handler.APICreateWebhook)).Methods("POST")
+	api.HandleFunc("/webhooks/{id:[0-9]+}", httpHandlers.SessionOnly(app.handler.APIGetWebhook)).Methods("GET")
+	api.HandleFunc("/webhooks/{id:[0-9]+}", httpHandlers.SessionOnly(app.handler.APIUpdateWebhook)).Methods("PUT")
+	api.HandleFunc("/webhooks/{id:[0-9]+}", httpHandlers.SessionOnly(app.handler.APIDeleteWebhook)).Methods("DELETE")
+	api.HandleFunc("/webhooks/{id:[0-9]+}/deliveries", httpHandlers.SessionOnly(app.handler.APIGetWebhookDeliveries)).Methods("GET")
+
+	// Web routes for the user interface, scoped to the logged-in user
+	web := r.NewRoute().Subrouter()
+	web.Use(app.handler.RequireSession)
+	web.HandleFunc("/", app.handler.Index).Methods("GET")
+	web.HandleFunc("/search", app.handler.SearchTasks).Methods("GET")
+	web.HandleFunc("/tasks/new", app.handler.CreateTaskForm).Methods("GET")
+	web.HandleFunc("/tasks/import", app.handler.ImportTasksForm).Methods("GET")
+	web.HandleFunc("/tasks/import", app.handler.ImportTasks).Methods("POST")
+	web.HandleFunc("/tasks", app.handler.CreateTask).Methods("POST")
+	web.HandleFunc("/tasks/{id:[0-9]+}/edit", app.handler.EditTaskForm).Methods("GET")
+	web.HandleFunc("/tasks/{id:[0-9]+}", app.handler.UpdateTask).Methods("POST")
+	web.HandleFunc("/tasks/{id:[0-9]+}/delete", app.handler.DeleteTask).Methods("POST")
+	web.HandleFunc("/tasks/{id:[0-9]+}/status", app.handler.SetTaskStatus).Methods("POST")
+	web.HandleFunc("/tasks/{id:[0-9]+}/subtasks", app.handler.CreateSubtask).Methods("POST")
+	web.HandleFunc("/tasks/{id:[0-9]+}/recurrence", app.handler.UpdateRecurrence).Methods("POST")
+	web.HandleFunc("/tasks/{id:[0-9]+}/dependencies", app.handler.AddDependency).Methods("POST")
+	web.HandleFunc("/tasks/{id:[0-9]+}/dependencies/{dependsOnId:[0-9]+}/delete", app.handler.RemoveDependency).Methods("POST")
+	web.HandleFunc("/tasks/{id:[0-9]+}/timer/start", app.handler.StartTimer).Methods("POST")
+	web.HandleFunc("/tasks/{id:[0-9]+}/timer/stop", app.handler.StopTimer).Methods("POST")
+	web.HandleFunc("/tasks/{id:[0-9]+}/time-entries", app.handler.LogTime).Methods("POST")
+	web.HandleFunc("/tasks/{id:[0-9]+}/time-entries/{entryId:[0-9]+}/delete", app.handler.DeleteTimeEntry).Methods("POST")
+	web.HandleFunc("/tasks/{id:[0-9]+}/history/{entryId:[0-9]+}/restore", app.handler.RestoreTaskVersion).Methods("POST")
+	web.HandleFunc("/courses", app.handler.ListCourses).Methods("GET")
+	web.HandleFunc("/courses/new", app.handler.CreateCourseForm).Methods("GET")
+	web.HandleFunc("/courses", app.handler.CreateCourse).Methods("POST")
+	web.HandleFunc("/courses/{id:[0-9]+}", app.handler.CourseDetail).Methods("GET")
+	web.HandleFunc("/courses/{id:[0-9]+}/edit", app.handler.EditCourseForm).Methods("GET")
+	web.HandleFunc("/courses/{id:[0-9]+}", app.handler.UpdateCourse).Methods("POST")
+	web.HandleFunc("/courses/{id:[0-9]+}/delete", app.handler.DeleteCourse).Methods("POST")
+	web.HandleFunc("/tags", app.handler.ListTags).Methods("GET")
+	web.HandleFunc("/workload", app.handler.Workload).Methods("GET")
+	web.HandleFunc("/plan", app.handler.Plan).Methods("GET")
+	web.HandleFunc("/plan/availability", app.handler.AddAvailability).Methods("POST")
+	web.HandleFunc("/plan/availability/{id:[0-9]+}/delete", app.handler.DeleteAvailability).Methods("POST")
+	web.HandleFunc("/tags", app.handler.CreateTag).Methods("POST")
+	web.HandleFunc("/tags/{id:[0-9]+}", app.handler.RenameTag).Methods("POST")
+	web.HandleFunc("/tags/{id:[0-9]+}/delete", app.handler.DeleteTag).Methods("POST")
+	web.HandleFunc("/tokens", app.handler.ListTokens).Methods("GET")
+	web.HandleFunc("/tokens", app.handler.CreateToken).Methods("POST")
+	web.HandleFunc("/tokens/{id:[0-9]+}/revoke", app.handler.RevokeToken).Methods("POST")
+
+	// Calendar feeds for subscription from calendar applications, which authenticate with ?token=
+	feeds := r.NewRoute().Subrouter()
+	feeds.Use(app.handler.RequireFeedAuth)
+	feeds.HandleFunc("/calendar.ics", app.handler.Calendar).Methods("GET")
+	feeds.HandleFunc("/courses/{id:[0-9]+}/calendar.ics", app.handler.CourseCalendar).Methods("GET")
 
 	// Configure server with timeouts for security and reliability
 	srv := &http.Server{
@@ -145,40 +404,3 @@ func startServer(app *application) error {
 	log.Println("Server started on http://localhost:8080")
 	return srv.ListenAndServe()
 }
-
-// createDatabaseSchema initializes the SQLite database schema
-func createDatabaseSchema(db *sql.DB) error {
-	// Create courses table
-	_, err := db.Exec(`
-	CREATE TABLE IF NOT EXISTS courses (
-		id INTEGER PRIMARY KEY AUTOINCREMENT,
-		name TEXT NOT NULL,
-		professor TEXT NOT NULL,
-		created_at DATETIME NOT NULL,
-		updated_at DATETIME NOT NULL
-	)`)
-	if err != nil {
-		return err
-	}
-
-	// Create tasks table with foreign key to courses
-	_, err = db.Exec(`
-	CREATE TABLE IF NOT EXISTS tasks (
-		id INTEGER PRIMARY KEY AUTOINCREMENT,
-		title TEXT NOT NULL,
-		description TEXT,
-		due_date DATETIME NOT NULL,
-		priority INTEGER NOT NULL CHECK (priority BETWEEN 1 AND 5),
-		status TEXT NOT NULL CHECK (status IN ('pending', 'in_progress', 'completed')),
-		course_id INTEGER,
-		created_at DATETIME NOT NULL,
-		updated_at DATETIME NOT NULL,
-		FOREIGN KEY (course_id) REFERENCES courses(id)
-	)`)
-	if err != nil {
-		return err
-	}
-
-	log.Println("Database tables initialized successfully")
-	return nil
-}
@@ -4,6 +4,7 @@ go 1.23.2
 
 require (
 	github.com/gorilla/mux v1.8.1
+	golang.org/x/crypto v0.33.0
 	modernc.org/sqlite v1.36.1
 )
 
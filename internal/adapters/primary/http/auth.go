@@ -0,0 +1,266 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"uni-task-manager/internal/domain/models"
+	"uni-task-manager/internal/domain/services"
+)
+
+// sessionCookieName is the name of the cookie carrying the browser session token
+const sessionCookieName = "session"
+
+// contextKey is the type of the keys this package stores in request contexts
+type contextKey int
+
+// apiTokenKey is the context key of the API token that authenticated the request
+const apiTokenKey contextKey = iota
+
+// RequireSession is a middleware for the web interface that resolves the session cookie
+// to its user and scopes the request to that user. Anonymous visitors are sent to the login page.
+func (h *Handler) RequireSession(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, ok := h.sessionUserID(r)
+		if !ok {
+			target := "/login"
+			if r.Method == http.MethodGet && r.URL.Path != "/" {
+				target += "?next=" + url.QueryEscape(r.URL.RequestURI())
+			}
+			http.Redirect(w, r, target, http.StatusSeeOther)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(services.ContextWithUserID(r.Context(), userID)))
+	})
+}
+
+// RequireAPIAuth is a middleware for the REST API that authenticates the request with either
+// an API token in the Authorization header or the session cookie, and scopes it to that user.
+// Token requests must hold tasks:read for safe methods and tasks:write for everything else.
+func (h *Handler) RequireAPIAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if header := r.Header.Get("Authorization"); header != "" {
+			secret, ok := bearerToken(header)
+			if !ok {
+				writeError(w, http.StatusUnauthorized, "invalid_token", "Authorization header must use the Bearer scheme")
+				return
+			}
+
+			token, err := h.tokenService.AuthenticateToken(r.Context(), secret)
+			if err != nil {
+				writeDomainError(w, err)
+				return
+			}
+
+			scope := requiredScope(r.Method)
+			if !token.HasScope(scope) {
+				writeError(w, http.StatusForbidden, "insufficient_scope", "API token lacks the "+string(scope)+" scope")
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), apiTokenKey, token)
+			next.ServeHTTP(w, r.WithContext(services.ContextWithUserID(ctx, token.UserID)))
+			return
+		}
+
+		userID, ok := h.sessionUserID(r)
+		if !ok {
+			writeError(w, http.StatusUnauthorized, "unauthenticated", "Authentication required")
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(services.ContextWithUserID(r.Context(), userID)))
+	})
+}
+
+// RequireFeedAuth is a middleware for the calendar feeds, which calendar applications fetch
+// without a browser session. It accepts a token query parameter holding an API token with
+// the tasks:read scope, and falls back to the session cookie.
+func (h *Handler) RequireFeedAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if secret := r.URL.Query().Get("token"); secret != "" {
+			token, err := h.tokenService.AuthenticateToken(r.Context(), secret)
+			if errors.Is(err, services.ErrInvalidToken) {
+				http.Error(w, "Invalid or expired API token", http.StatusUnauthorized)
+				return
+			}
+			if err != nil {
+				http.Error(w, "Error verifying API token", http.StatusInternalServerError)
+				return
+			}
+			if !token.HasScope(models.ScopeTasksRead) {
+				http.Error(w, "API token lacks the tasks:read scope", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(services.ContextWithUserID(r.Context(), token.UserID)))
+			return
+		}
+
+		userID, ok := h.sessionUserID(r)
+		if !ok {
+			http.Error(w, "Authentication required", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(services.ContextWithUserID(r.Context(), userID)))
+	})
+}
+
+// SessionOnly restricts an API route to requests authenticated by the session cookie.
+// It keeps API tokens from minting or revoking other tokens.
+func SessionOnly(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.Context().Value(apiTokenKey).(*models.APIToken); ok {
+			writeError(w, http.StatusForbidden, "forbidden", "API tokens cannot be managed with an API token")
+			return
+		}
+		next(w, r)
+	}
+}
+
+// sessionUserID returns the ID of the user owning the request's session cookie, if it is valid.
+func (h *Handler) sessionUserID(r *http.Request) (int64, bool) {
+	cookie, err := r.Cookie(sessionCookieName)
+	if err != nil {
+		return 0, false
+	}
+	user, err := h.userService.Authenticate(r.Context(), cookie.Value)
+	if err != nil {
+		return 0, false
+	}
+	return user.ID, true
+}
+
+// bearerToken extracts the secret of an "Authorization: Bearer <token>" header value.
+func bearerToken(header string) (string, bool) {
+	scheme, secret, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	secret = strings.TrimSpace(secret)
+	return secret, secret != ""
+}
+
+// requiredScope returns the token scope needed to call the API with the given method.
+func requiredScope(method string) models.TokenScope {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return models.ScopeTasksRead
+	default:
+		return models.ScopeTasksWrite
+	}
+}
+
+// LoginForm handles requests to display the login page.
+func (h *Handler) LoginForm(w http.ResponseWriter, r *http.Request) {
+	h.renderAuthForm(w, "login.html", r.URL.Query().Get("next"), "", "")
+}
+
+// Login handles form submissions to log in, opening a session on success.
+func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Error parsing form", http.StatusBadRequest)
+		return
+	}
+
+	email := r.FormValue("email")
+	next := r.FormValue("next")
+
+	session, err := h.userService.Login(r.Context(), email, r.FormValue("password"))
+	if errors.Is(err, services.ErrInvalidCredentials) {
+		w.WriteHeader(http.StatusUnauthorized)
+		h.renderAuthForm(w, "login.html", next, email, err.Error())
+		return
+	}
+	if err != nil {
+		http.Error(w, "Error logging in", http.StatusInternalServerError)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookieName,
+		Value:    session.Token,
+		Path:     "/",
+		Expires:  session.ExpiresAt,
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.Redirect(w, r, safeRedirect(next), http.StatusSeeOther)
+}
+
+// RegisterForm handles requests to display the registration page.
+func (h *Handler) RegisterForm(w http.ResponseWriter, r *http.Request) {
+	h.renderAuthForm(w, "register.html", "", "", "")
+}
+
+// Register handles form submissions to create an account, logging the new user in.
+func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Error parsing form", http.StatusBadRequest)
+		return
+	}
+
+	email := r.FormValue("email")
+	password := r.FormValue("password")
+
+	_, err := h.userService.Register(r.Context(), email, r.FormValue("name"), password)
+	if errors.Is(err, services.ErrInvalidEmail) || errors.Is(err, services.ErrWeakPassword) || errors.Is(err, services.ErrEmailTaken) {
+		w.WriteHeader(http.StatusBadRequest)
+		h.renderAuthForm(w, "register.html", "", email, err.Error())
+		return
+	}
+	if err != nil {
+		http.Error(w, "Error creating account", http.StatusInternalServerError)
+		return
+	}
+
+	h.Login(w, r)
+}
+
+// Logout handles requests to end the current session.
+func (h *Handler) Logout(w http.ResponseWriter, r *http.Request) {
+	if cookie, err := r.Cookie(sessionCookieName); err == nil {
+		if err := h.userService.Logout(r.Context(), cookie.Value); err != nil {
+			http.Error(w, "Error logging out", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookieName,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.Redirect(w, r, "/login", http.StatusSeeOther)
+}
+
+// renderAuthForm renders the login or registration page with an optional error message.
+func (h *Handler) renderAuthForm(w http.ResponseWriter, name, next, email, message string) {
+	data := struct {
+		Next  string
+		Email string
+		Error string
+	}{
+		Next:  next,
+		Email: email,
+		Error: message,
+	}
+
+	if err := h.templates.ExecuteTemplate(w, name, data); err != nil {
+		http.Error(w, "Error rendering template", http.StatusInternalServerError)
+	}
+}
+
+// safeRedirect returns the local path to continue to after login, guarding against open redirects.
+func safeRedirect(next string) string {
+	if !strings.HasPrefix(next, "/") || strings.HasPrefix(next, "//") || strings.HasPrefix(next, "/\\") {
+		return "/"
+	}
+	return next
+}
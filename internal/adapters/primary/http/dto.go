@@ -0,0 +1,519 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"uni-task-manager/internal/domain/models"
+)
+
+// The types of this file define the JSON contract of the REST API. They are kept separate
+// from the domain models so the wire format stays stable when the models evolve.
+
+// taskRequest is the JSON body accepted when creating or replacing a task.
+type taskRequest struct {
+	Title       string            `json:"title"`
+	Description string            `json:"description"`
+	DueDate     *time.Time        `json:"due_date"`
+	Priority    int               `json:"priority"`
+	Status      models.TaskStatus `json:"status"`
+	Estimated   float64           `json:"estimated_hours"`
+	CourseID    *int64            `json:"course_id"`
+}
+
+// toModel converts the request into a domain task.
+func (req taskRequest) toModel() *models.Task {
+	task := &models.Task{
+		Title:          req.Title,
+		Description:    req.Description,
+		Priority:       req.Priority,
+		Status:         req.Status,
+		EstimatedHours: req.Estimated,
+	}
+	if req.DueDate != nil {
+		task.DueDate = *req.DueDate
+	}
+	if req.CourseID != nil {
+		task.CourseID = *req.CourseID
+	}
+	return task
+}
+
+// taskPatchRequest is a JSON Merge Patch (RFC 7386) of a task. Members left out of the
+// document are kept as stored, members set to null are cleared. Only description,
+// estimated_hours and course_id can be cleared; the service rejects null for the others.
+type taskPatchRequest struct {
+	Title       json.RawMessage `json:"title"`
+	Description json.RawMessage `json:"description"`
+	DueDate     json.RawMessage `json:"due_date"`
+	Priority    json.RawMessage `json:"priority"`
+	Status      json.RawMessage `json:"status"`
+	Estimated   json.RawMessage `json:"estimated_hours"`
+	CourseID    json.RawMessage `json:"course_id"`
+}
+
+// toModel converts the merge patch to a task patch, reporting the members of the wrong type.
+func (req taskPatchRequest) toModel() (models.TaskPatch, []fieldError) {
+	var patch models.TaskPatch
+	var fields []fieldError
+
+	// Unmarshaling null leaves the zero value in place, which is what clears a field, or what
+	// the service reports as a validation failure for a required one
+	decode := func(name string, raw json.RawMessage, target interface{}) {
+		err := json.Unmarshal(raw, target)
+		if err == nil {
+			return
+		}
+		message := err.Error()
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			message = fmt.Sprintf("expected %s, got %s", typeErr.Type, typeErr.Value)
+		}
+		fields = append(fields, fieldError{Field: name, Code: "invalid_type", Message: message})
+	}
+
+	if req.Title != nil {
+		patch.Title = new(string)
+		decode("title", req.Title, patch.Title)
+	}
+	if req.Description != nil {
+		patch.Description = new(string)
+		decode("description", req.Description, patch.Description)
+	}
+	if req.DueDate != nil {
+		patch.DueDate = new(time.Time)
+		decode("due_date", req.DueDate, patch.DueDate)
+	}
+	if req.Priority != nil {
+		patch.Priority = new(int)
+		decode("priority", req.Priority, patch.Priority)
+	}
+	if req.Status != nil {
+		patch.Status = new(models.TaskStatus)
+		decode("status", req.Status, patch.Status)
+	}
+	if req.Estimated != nil {
+		patch.EstimatedHours = new(float64)
+		decode("estimated_hours", req.Estimated, patch.EstimatedHours)
+	}
+	if req.CourseID != nil {
+		patch.CourseID = new(int64)
+		decode("course_id", req.CourseID, patch.CourseID)
+	}
+
+	return patch, fields
+}
+
+// taskStatusRequest is the JSON body of a task status transition.
+type taskStatusRequest struct {
+	Status models.TaskStatus `json:"status"`
+}
+
+// taskResponse is the JSON representation of a task.
+// Blocked, Overdue and DaysLeft are computed at the time of the response.
+type taskResponse struct {
+	ID           int64             `json:"id"`
+	Title        string            `json:"title"`
+	Description  string            `json:"description"`
+	DueDate      *time.Time        `json:"due_date"`
+	Priority     int               `json:"priority"`
+	Status       models.TaskStatus `json:"status"`
+	Blocked      bool              `json:"blocked"`
+	Estimated    float64           `json:"estimated_hours"`
+	Course       *courseSummary    `json:"course"`
+	Tags         []string          `json:"tags"`
+	ParentID     *int64            `json:"parent_id"`
+	RecurrenceID *int64            `json:"recurrence_id"`
+	ExternalUID  string            `json:"external_uid,omitempty"`
+	Overdue      bool              `json:"overdue"`
+	DaysLeft     *int              `json:"days_left"`
+	Version      int64             `json:"version"`
+	CreatedAt    time.Time         `json:"created_at"`
+	UpdatedAt    time.Time         `json:"updated_at"`
+}
+
+// courseSummary is the short form of a course embedded in task responses.
+type courseSummary struct {
+	ID        int64  `json:"id"`
+	Name      string `json:"name"`
+	Professor string `json:"professor"`
+}
+
+// taskRelations holds the records embedded in task responses: course summaries keyed by
+// course ID, tag names and the blocked state keyed by task ID.
+type taskRelations struct {
+	courses map[int64]courseSummary
+	tags    map[int64][]string
+	blocked map[int64]bool
+}
+
+// newTaskResponse converts a task to its JSON representation.
+// The course summary, tags and blocked state are looked up in relations.
+func newTaskResponse(task models.Task, relations taskRelations, now time.Time) taskResponse {
+	response := taskResponse{
+		ID:           task.ID,
+		Title:        task.Title,
+		Description:  task.Description,
+		Priority:     task.Priority,
+		Status:       task.Status,
+		Blocked:      relations.blocked[task.ID],
+		Estimated:    task.EstimatedHours,
+		ParentID:     optionalID(task.ParentID),
+		RecurrenceID: optionalID(task.RecurrenceID),
+		ExternalUID:  task.ExternalUID,
+		Version:      task.Version,
+		CreatedAt:    task.CreatedAt.Truncate(time.Second),
+		UpdatedAt:    task.UpdatedAt.Truncate(time.Second),
+	}
+
+	if course, ok := relations.courses[task.CourseID]; ok {
+		response.Course = &course
+	}
+
+	response.Tags = relations.tags[task.ID]
+	if response.Tags == nil {
+		response.Tags = []string{}
+	}
+
+	if !task.DueDate.IsZero() {
+		due := task.DueDate
+		response.DueDate = &due
+		response.Overdue = task.Status != models.TaskStatusCompleted && due.Before(now)
+		daysLeft := daysBetween(now, due)
+		response.DaysLeft = &daysLeft
+	}
+
+	return response
+}
+
+// newTaskResponses converts a list of tasks to their JSON representation.
+// The result is never nil so an empty listing encodes as [].
+func newTaskResponses(tasks []models.Task, relations taskRelations) []taskResponse {
+	now := time.Now()
+	responses := make([]taskResponse, 0, len(tasks))
+	for _, task := range tasks {
+		responses = append(responses, newTaskResponse(task, relations, now))
+	}
+	return responses
+}
+
+// daysBetween counts the calendar days from one moment to another in the local time zone,
+// so a task due later today has 0 days left and one due yesterday has -1.
+func daysBetween(from, to time.Time) int {
+	from = from.Local()
+	to = to.Local()
+	start := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	end := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(end.Sub(start).Hours() / 24)
+}
+
+// optionalID returns nil for the zero ID, so unset references encode as null.
+func optionalID(id int64) *int64 {
+	if id == 0 {
+		return nil
+	}
+	return &id
+}
+
+// searchResultResponse is the JSON representation of a full-text search match.
+// The snippet is HTML: its text is escaped and the matching terms are wrapped in <mark> elements.
+type searchResultResponse struct {
+	Task    taskResponse `json:"task"`
+	Snippet string       `json:"snippet"`
+	Score   float64      `json:"score"`
+}
+
+// newSearchResultResponses converts search results to their JSON representation.
+func newSearchResultResponses(results []models.TaskSearchResult, relations taskRelations) []searchResultResponse {
+	now := time.Now()
+	response := make([]searchResultResponse, 0, len(results))
+	for _, result := range results {
+		var snippet strings.Builder
+		for _, part := range result.Snippet {
+			if part.Match {
+				snippet.WriteString("<mark>" + html.EscapeString(part.Text) + "</mark>")
+			} else {
+				snippet.WriteString(html.EscapeString(part.Text))
+			}
+		}
+		response = append(response, searchResultResponse{
+			Task:    newTaskResponse(result.Task, relations, now),
+			Snippet: snippet.String(),
+			Score:   result.Score,
+		})
+	}
+	return response
+}
+
+// progressResponse is the JSON representation of the completion of a task's subtasks.
+type progressResponse struct {
+	Total     int `json:"total"`
+	Completed int `json:"completed"`
+	Percent   int `json:"percent"`
+}
+
+// newProgressResponse converts task progress to its JSON representation.
+func newProgressResponse(progress *models.TaskProgress) progressResponse {
+	return progressResponse{
+		Total:     progress.Total,
+		Completed: progress.Completed,
+		Percent:   progress.Percent,
+	}
+}
+
+// courseRequest is the JSON body accepted when creating or replacing a course.
+type courseRequest struct {
+	Name      string `json:"name"`
+	Professor string `json:"professor"`
+}
+
+// toModel converts the request into a domain course.
+func (req courseRequest) toModel() *models.Course {
+	return &models.Course{
+		Name:      req.Name,
+		Professor: req.Professor,
+	}
+}
+
+// courseResponse is the JSON representation of a course.
+type courseResponse struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Professor string    `json:"professor"`
+	Version   int64     `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// newCourseResponse converts a course to its JSON representation.
+func newCourseResponse(course models.Course) courseResponse {
+	return courseResponse{
+		ID:        course.ID,
+		Name:      course.Name,
+		Professor: course.Professor,
+		Version:   course.Version,
+		CreatedAt: course.CreatedAt.Truncate(time.Second),
+		UpdatedAt: course.UpdatedAt.Truncate(time.Second),
+	}
+}
+
+// newCourseSummaries indexes the short form of courses by their ID.
+func newCourseSummaries(courses []models.Course) map[int64]courseSummary {
+	summaries := make(map[int64]courseSummary, len(courses))
+	for _, course := range courses {
+		summaries[course.ID] = courseSummary{ID: course.ID, Name: course.Name, Professor: course.Professor}
+	}
+	return summaries
+}
+
+// newTagNames indexes the names of the tags of every task by task ID.
+func newTagNames(tags map[int64][]models.Tag) map[int64][]string {
+	names := make(map[int64][]string, len(tags))
+	for taskID, taskTags := range tags {
+		for _, tag := range taskTags {
+			names[taskID] = append(names[taskID], tag.Name)
+		}
+	}
+	return names
+}
+
+// taskTagsRequest is the JSON body replacing the tags of a task, by name.
+type taskTagsRequest struct {
+	Tags []string `json:"tags"`
+}
+
+// dependencyRequest is the JSON body making a task depend on another task.
+type dependencyRequest struct {
+	DependsOnID int64 `json:"depends_on_id"`
+}
+
+// dependenciesResponse is the JSON representation of the dependencies of a task.
+type dependenciesResponse struct {
+	Blocked       bool           `json:"blocked"`
+	Prerequisites []taskResponse `json:"prerequisites"`
+	Dependents    []taskResponse `json:"dependents"`
+}
+
+// newDependenciesResponse converts the dependencies of a task to their JSON representation.
+func newDependenciesResponse(dependencies *models.TaskDependencies, relations taskRelations) dependenciesResponse {
+	return dependenciesResponse{
+		Blocked:       dependencies.Blocked,
+		Prerequisites: newTaskResponses(dependencies.Prerequisites, relations),
+		Dependents:    newTaskResponses(dependencies.Dependents, relations),
+	}
+}
+
+// recurrenceRequest is the JSON body accepted when setting the recurrence rule of a task.
+// Weekdays are lowercase English day names; exception dates are YYYY-MM-DD days.
+type recurrenceRequest struct {
+	Frequency models.RecurrenceFrequency `json:"frequency"`
+	Interval  int                        `json:"interval"`
+	ByWeekday []string                   `json:"by_weekday"`
+	Until     *time.Time                 `json:"until"`
+	Count     int                        `json:"count"`
+	ExDates   []string                   `json:"exdates"`
+}
+
+// toModel converts the request into a domain recurrence rule.
+func (req recurrenceRequest) toModel() (*models.Recurrence, []fieldError) {
+	rule := &models.Recurrence{
+		Frequency: req.Frequency,
+		Interval:  req.Interval,
+		Count:     req.Count,
+	}
+	if req.Until != nil {
+		rule.Until = *req.Until
+	}
+
+	var fields []fieldError
+	for _, name := range req.ByWeekday {
+		day, ok := weekdayNames[strings.ToLower(name)]
+		if !ok {
+			fields = append(fields, fieldError{Field: "by_weekday", Code: "invalid_weekday", Message: fmt.Sprintf("unknown weekday %q", name)})
+			continue
+		}
+		rule.ByWeekday = append(rule.ByWeekday, day)
+	}
+	for _, value := range req.ExDates {
+		date, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			fields = append(fields, fieldError{Field: "exdates", Code: "invalid_date", Message: fmt.Sprintf("invalid date %q, expected YYYY-MM-DD", value)})
+			continue
+		}
+		rule.ExDates = append(rule.ExDates, date)
+	}
+
+	return rule, fields
+}
+
+// recurrenceResponse is the JSON representation of a recurrence rule.
+type recurrenceResponse struct {
+	ID        int64                      `json:"id"`
+	Frequency models.RecurrenceFrequency `json:"frequency"`
+	Interval  int                        `json:"interval"`
+	ByWeekday []string                   `json:"by_weekday"`
+	Until     *time.Time                 `json:"until"`
+	Count     int                        `json:"count"`
+	ExDates   []string                   `json:"exdates"`
+	CreatedAt time.Time                  `json:"created_at"`
+	UpdatedAt time.Time                  `json:"updated_at"`
+}
+
+// newRecurrenceResponse converts a recurrence rule to its JSON representation.
+func newRecurrenceResponse(rule *models.Recurrence) recurrenceResponse {
+	response := recurrenceResponse{
+		ID:        rule.ID,
+		Frequency: rule.Frequency,
+		Interval:  rule.Interval,
+		ByWeekday: make([]string, 0, len(rule.ByWeekday)),
+		Count:     rule.Count,
+		ExDates:   make([]string, 0, len(rule.ExDates)),
+		CreatedAt: rule.CreatedAt.Truncate(time.Second),
+		UpdatedAt: rule.UpdatedAt.Truncate(time.Second),
+	}
+	for _, day := range rule.ByWeekday {
+		response.ByWeekday = append(response.ByWeekday, strings.ToLower(day.String()))
+	}
+	if !rule.Until.IsZero() {
+		until := rule.Until
+		response.Until = &until
+	}
+	for _, date := range rule.ExDates {
+		response.ExDates = append(response.ExDates, date.Format("2006-01-02"))
+	}
+	return response
+}
+
+// weekdayNames maps the lowercase English day names to weekdays
+var weekdayNames = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+// importResultResponse is the JSON summary of a calendar import.
+type importResultResponse struct {
+	Created        int                  `json:"created"`
+	Updated        int                  `json:"updated"`
+	Unchanged      int                  `json:"unchanged"`
+	CoursesCreated int                  `json:"courses_created"`
+	Skipped        []importSkipResponse `json:"skipped"`
+}
+
+// importSkipResponse describes a calendar entry that was not imported.
+type importSkipResponse struct {
+	UID     string `json:"uid"`
+	Summary string `json:"summary"`
+	Reason  string `json:"reason"`
+}
+
+// newImportResultResponse converts an import result to its JSON representation.
+func newImportResultResponse(result *models.ImportResult) importResultResponse {
+	response := importResultResponse{
+		Created:        result.Created,
+		Updated:        result.Updated,
+		Unchanged:      result.Unchanged,
+		CoursesCreated: result.CoursesCreated,
+		Skipped:        make([]importSkipResponse, 0, len(result.Skipped)),
+	}
+	for _, skip := range result.Skipped {
+		response.Skipped = append(response.Skipped, importSkipResponse{UID: skip.UID, Summary: skip.Summary, Reason: skip.Reason})
+	}
+	return response
+}
+
+// decodeJSON strictly decodes a JSON request body into v: unknown fields, mistyped values and
+// trailing data are rejected. On failure it writes the error response and returns false.
+func decodeJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	err := decoder.Decode(v)
+	if err == nil {
+		if _, trailing := decoder.Token(); trailing != io.EOF {
+			err = errors.New("request body must contain a single JSON value")
+		}
+	}
+	if err == nil {
+		return true
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		writeError(w, http.StatusBadRequest, codeInvalidBody, "Invalid request body", fieldError{
+			Field:   typeErr.Field,
+			Code:    "invalid_type",
+			Message: fmt.Sprintf("expected %s, got %s", typeErr.Type, typeErr.Value),
+		})
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		field := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+		writeError(w, http.StatusBadRequest, codeInvalidBody, "Invalid request body", fieldError{
+			Field:   field,
+			Code:    "unknown_field",
+			Message: "unknown field",
+		})
+	case errors.Is(err, io.EOF):
+		writeError(w, http.StatusBadRequest, codeInvalidBody, "Request body is empty")
+	default:
+		writeError(w, http.StatusBadRequest, codeInvalidBody, "Invalid request body: "+err.Error())
+	}
+	return false
+}
+
+// writeJSON responds with the given status and v encoded as JSON.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
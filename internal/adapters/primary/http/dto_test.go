@@ -0,0 +1,71 @@
+package http
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"uni-task-manager/internal/domain/models"
+)
+
+func TestTaskPatchRequestToModel(t *testing.T) {
+	title := "Essay"
+	empty := ""
+	dueDate := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+	var noDueDate time.Time
+	priority, noPriority := 4, 0
+	noStatus := models.TaskStatus("")
+	noHours := 0.0
+	courseID, noCourse := int64(2), int64(0)
+
+	tests := []struct {
+		name       string
+		body       string
+		want       models.TaskPatch
+		wantFields []string
+	}{
+		{
+			name: "absent members are left out",
+			body: `{}`,
+		},
+		{
+			name: "null members become zero values",
+			body: `{"title":null,"description":null,"due_date":null,"priority":null,"status":null,"estimated_hours":null,"course_id":null}`,
+			want: models.TaskPatch{Title: &empty, Description: &empty, DueDate: &noDueDate, Priority: &noPriority,
+				Status: &noStatus, EstimatedHours: &noHours, CourseID: &noCourse},
+		},
+		{
+			name: "values are decoded",
+			body: `{"title":"Essay","due_date":"2030-01-01T09:00:00Z","priority":4,"course_id":2}`,
+			want: models.TaskPatch{Title: &title, DueDate: &dueDate, Priority: &priority, CourseID: &courseID},
+		},
+		{
+			name:       "wrong types are reported",
+			body:       `{"title":5,"priority":"high"}`,
+			wantFields: []string{"title", "priority"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var request taskPatchRequest
+			if err := json.Unmarshal([]byte(tt.body), &request); err != nil {
+				t.Fatal(err)
+			}
+			patch, fields := request.toModel()
+
+			if len(fields) != len(tt.wantFields) {
+				t.Fatalf("toModel() fields = %v, want %v", fields, tt.wantFields)
+			}
+			for i, field := range fields {
+				if field.Field != tt.wantFields[i] || field.Code != "invalid_type" {
+					t.Errorf("field error %d = %+v, want an invalid_type error for %s", i, field, tt.wantFields[i])
+				}
+			}
+			if len(fields) == 0 && !reflect.DeepEqual(patch, tt.want) {
+				t.Errorf("toModel() = %+v, want %+v", patch, tt.want)
+			}
+		})
+	}
+}
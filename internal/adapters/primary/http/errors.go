@@ -0,0 +1,181 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"uni-task-manager/internal/domain/services"
+)
+
+// Error codes of failures detected by the HTTP layer itself, before reaching a service
+const (
+	codeInvalidID    = "invalid_id"
+	codeInvalidBody  = "invalid_body"
+	codeInvalidQuery = "invalid_query"
+	codeNotFound     = "not_found"
+	codeInternal     = "internal_error"
+
+	// codeValidationFailed is reported when several fields are rejected at once
+	codeValidationFailed = "validation_failed"
+)
+
+// errorResponse is the JSON envelope of every API error:
+//
+//	{"error": {"code": "invalid_priority", "message": "...", "fields": [{"field": "priority", "message": "..."}]}}
+type errorResponse struct {
+	Error apiError `json:"error"`
+}
+
+// apiError describes a failed API request. Code is a stable machine-readable identifier,
+// Message a human-readable explanation and Fields lists the rejected input fields, if any.
+type apiError struct {
+	Code    string       `json:"code"`
+	Message string       `json:"message"`
+	Fields  []fieldError `json:"fields,omitempty"`
+}
+
+// fieldError reports why the value of a single input field was rejected.
+type fieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// domainError maps a sentinel error of the services package to its HTTP representation.
+type domainError struct {
+	err    error
+	status int
+	code   string
+	field  string
+}
+
+// domainErrors lists the sentinel errors the API knows how to report, with their status,
+// error code and, for validation failures, the input field they concern.
+var domainErrors = []domainError{
+	// 400 Bad Request: the request itself is malformed
+	{services.ErrInvalidTaskQuery, http.StatusBadRequest, codeInvalidQuery, ""},
+	{services.ErrInvalidImportOptions, http.StatusBadRequest, "invalid_import_options", ""},
+	{services.ErrInvalidDeletePolicy, http.StatusBadRequest, codeInvalidQuery, "policy"},
+	{services.ErrInvalidSearch, http.StatusBadRequest, codeInvalidQuery, "q"},
+	{services.ErrInvalidWorkloadRange, http.StatusBadRequest, codeInvalidQuery, "to"},
+	{services.ErrInvalidCapacity, http.StatusBadRequest, codeInvalidQuery, "capacity"},
+	{services.ErrInvalidPlanQuery, http.StatusBadRequest, codeInvalidQuery, ""},
+
+	// 401 Unauthorized: the caller isn't authenticated
+	{services.ErrUnauthenticated, http.StatusUnauthorized, "unauthenticated", ""},
+	{services.ErrInvalidCredentials, http.StatusUnauthorized, "invalid_credentials", ""},
+	{services.ErrInvalidToken, http.StatusUnauthorized, "invalid_token", ""},
+
+	// 404 Not Found: the resource doesn't exist or belongs to someone else
+	{services.ErrTaskNotFound, http.StatusNotFound, "task_not_found", ""},
+	{services.ErrCourseNotFound, http.StatusNotFound, "course_not_found", "course_id"},
+	{services.ErrTokenNotFound, http.StatusNotFound, "token_not_found", ""},
+	{services.ErrTagNotFound, http.StatusNotFound, "tag_not_found", ""},
+	{services.ErrDependencyNotFound, http.StatusNotFound, "dependency_not_found", ""},
+	{services.ErrAvailabilityNotFound, http.StatusNotFound, "availability_not_found", ""},
+	{services.ErrTimeEntryNotFound, http.StatusNotFound, "time_entry_not_found", ""},
+	{services.ErrHistoryEntryNotFound, http.StatusNotFound, "history_entry_not_found", ""},
+	{services.ErrWebhookNotFound, http.StatusNotFound, "webhook_not_found", ""},
+
+	// 409 Conflict: the request clashes with the current state of a resource
+	{services.ErrNestedSubtask, http.StatusConflict, "nested_subtask", "parent_id"},
+	{services.ErrTaskNotRecurring, http.StatusConflict, "task_not_recurring", ""},
+	{services.ErrEmailTaken, http.StatusConflict, "email_taken", "email"},
+	{services.ErrCourseHasTasks, http.StatusConflict, "course_has_tasks", ""},
+	{services.ErrTagExists, http.StatusConflict, "tag_exists", "name"},
+	{services.ErrDependencyCycle, http.StatusConflict, "dependency_cycle", "depends_on_id"},
+	{services.ErrTaskBlocked, http.StatusConflict, "task_blocked", "status"},
+	{services.ErrTimerRunning, http.StatusConflict, "timer_running", ""},
+	{services.ErrTimerNotRunning, http.StatusConflict, "timer_not_running", ""},
+	{services.ErrAvailabilityOverlap, http.StatusConflict, "availability_overlap", ""},
+
+	// 412 Precondition Failed: the If-Match version is no longer the current one
+	{services.ErrVersionConflict, http.StatusPreconditionFailed, "version_conflict", ""},
+
+	// 422 Unprocessable Entity: well-formed input that breaks a business rule
+	{services.ErrInvalidTaskPriority, http.StatusUnprocessableEntity, "invalid_priority", "priority"},
+	{services.ErrInvalidDueDate, http.StatusUnprocessableEntity, "invalid_due_date", "due_date"},
+	{services.ErrInvalidTaskStatus, http.StatusUnprocessableEntity, "invalid_status", "status"},
+	{services.ErrEmptyTitle, http.StatusUnprocessableEntity, "empty_title", "title"},
+	{services.ErrDueDateRequired, http.StatusUnprocessableEntity, "due_date_required", "due_date"},
+	{services.ErrInvalidEstimate, http.StatusUnprocessableEntity, "invalid_estimate", "estimated_hours"},
+	{services.ErrEmptyName, http.StatusUnprocessableEntity, "empty_name", "name"},
+	{services.ErrInvalidTagName, http.StatusUnprocessableEntity, "invalid_tag_name", "name"},
+	{services.ErrInvalidAvailability, http.StatusUnprocessableEntity, "invalid_availability", ""},
+	{services.ErrInvalidTimeEntry, http.StatusUnprocessableEntity, "invalid_time_entry", ""},
+	{services.ErrInvalidRecurrence, http.StatusUnprocessableEntity, "invalid_recurrence", ""},
+	{services.ErrInvalidWebhookURL, http.StatusUnprocessableEntity, "invalid_webhook_url", "url"},
+	{services.ErrInvalidWebhookEvents, http.StatusUnprocessableEntity, "invalid_webhook_events", "events"},
+	{services.ErrInvalidTokenRequest, http.StatusUnprocessableEntity, "invalid_token_request", ""},
+	{services.ErrInvalidEmail, http.StatusUnprocessableEntity, "invalid_email", "email"},
+	{services.ErrWeakPassword, http.StatusUnprocessableEntity, "weak_password", "password"},
+}
+
+// writeError responds with the JSON error envelope.
+func writeError(w http.ResponseWriter, status int, code, message string, fields ...fieldError) {
+	if status == http.StatusUnauthorized {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="uni-task-manager"`)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(errorResponse{Error: apiError{Code: code, Message: message, Fields: fields}})
+}
+
+// writeDomainError responds with the JSON error envelope matching an error returned by a service.
+// Errors joined with errors.Join are reported together, one field error each. Unknown errors
+// are logged and reported as a 500 without leaking their details.
+func writeDomainError(w http.ResponseWriter, err error) {
+	mapped, ok := classifyError(err)
+	if !ok {
+		log.Printf("internal error: %v", err)
+		writeError(w, http.StatusInternalServerError, codeInternal, "Internal server error")
+		return
+	}
+
+	var fields []fieldError
+	for _, e := range splitErrors(err) {
+		if m, ok := classifyError(e); ok && m.field != "" {
+			fields = append(fields, fieldError{Field: m.field, Code: m.code, Message: e.Error()})
+		}
+	}
+
+	code, message := mapped.code, err.Error()
+	if len(fields) > 1 {
+		code, message = codeValidationFailed, "The request contains invalid fields"
+	}
+	writeError(w, mapped.status, code, message, fields...)
+}
+
+// errorStatus returns the HTTP status matching an error returned by a service,
+// for the web interface handlers which answer with plain text.
+func errorStatus(err error) int {
+	if mapped, ok := classifyError(err); ok {
+		return mapped.status
+	}
+	return http.StatusInternalServerError
+}
+
+// classifyError finds the mapping of the first known sentinel error wrapped by err.
+func classifyError(err error) (domainError, bool) {
+	for _, mapped := range domainErrors {
+		if errors.Is(err, mapped.err) {
+			return mapped, true
+		}
+	}
+	return domainError{}, false
+}
+
+// splitErrors returns the errors joined by errors.Join, or err itself.
+func splitErrors(err error) []error {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		return joined.Unwrap()
+	}
+	return []error{err}
+}
+
+// APINotFound responds to requests for unknown API routes with the JSON error envelope.
+func APINotFound(w http.ResponseWriter, r *http.Request) {
+	writeError(w, http.StatusNotFound, codeNotFound, "No API endpoint matches "+r.URL.Path)
+}
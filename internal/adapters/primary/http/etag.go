@@ -0,0 +1,44 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"uni-task-manager/internal/domain/services"
+)
+
+// etag formats the version of a task or course as a strong entity tag.
+func etag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// ifMatchVersion reads the version the client expects from the If-Match header.
+// It returns zero when the header is absent or "*", so the write is unconditional.
+// Only a single strong tag produced by etag can be matched; anything else can never
+// equal the current version and is reported with ok set to false.
+func ifMatchVersion(r *http.Request) (version int64, ok bool) {
+	header := strings.TrimSpace(r.Header.Get("If-Match"))
+	if header == "" || header == "*" {
+		return 0, true
+	}
+
+	if len(header) < 2 || header[0] != '"' || header[len(header)-1] != '"' {
+		return 0, false
+	}
+	version, err := strconv.ParseInt(header[1:len(header)-1], 10, 64)
+	if err != nil || version <= 0 {
+		return 0, false
+	}
+	return version, true
+}
+
+// requireIfMatch reads the If-Match version and answers 412 Precondition Failed when it
+// cannot match any version. It returns false once the error response is written.
+func requireIfMatch(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	version, ok := ifMatchVersion(r)
+	if !ok {
+		writeDomainError(w, services.ErrVersionConflict)
+	}
+	return version, ok
+}
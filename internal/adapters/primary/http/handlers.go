@@ -3,13 +3,17 @@
 package http
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"html/template"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"uni-task-manager/internal/domain/models"
+	"uni-task-manager/internal/domain/services"
 	"uni-task-manager/internal/ports/input"
 
 	"github.com/gorilla/mux"
@@ -18,17 +22,33 @@ import (
 // Handler encapsulates the dependencies required for HTTP request handling.
 // It serves as a primary adapter in the hexagonal architecture.
 type Handler struct {
-	taskService   input.TaskService
-	courseService input.CourseService
-	templates     *template.Template
+	taskService     input.TaskService
+	courseService   input.CourseService
+	tagService      input.TagService
+	timeService     input.TimeService
+	workloadService input.WorkloadService
+	plannerService  input.PlannerService
+	importService   input.ImportService
+	userService     input.UserService
+	tokenService    input.TokenService
+	webhookService  input.WebhookService
+	templates       *template.Template
 }
 
 // NewHandler creates a new instance of Handler with the required dependencies.
-func NewHandler(taskService input.TaskService, courseService input.CourseService, templates *template.Template) *Handler {
+func NewHandler(taskService input.TaskService, courseService input.CourseService, tagService input.TagService, timeService input.TimeService, workloadService input.WorkloadService, plannerService input.PlannerService, importService input.ImportService, userService input.UserService, tokenService input.TokenService, webhookService input.WebhookService, templates *template.Template) *Handler {
 	return &Handler{
-		taskService:   taskService,
-		courseService: courseService,
-		templates:     templates,
+		taskService:     taskService,
+		courseService:   courseService,
+		tagService:      tagService,
+		timeService:     timeService,
+		workloadService: workloadService,
+		plannerService:  plannerService,
+		importService:   importService,
+		userService:     userService,
+		tokenService:    tokenService,
+		webhookService:  webhookService,
+		templates:       templates,
 	}
 }
 
@@ -49,36 +69,155 @@ func (h *Handler) Index(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	progress, err := h.taskService.GetAllTaskProgress(ctx)
+	if err != nil {
+		http.Error(w, "Error fetching task progress", http.StatusInternalServerError)
+		return
+	}
+
 	courseMap := make(map[int64]string)
 	for _, course := range courses {
 		courseMap[course.ID] = course.Name
 	}
 
+	tags, err := h.tagService.GetAllTags(ctx)
+	if err != nil {
+		http.Error(w, "Error fetching tags", http.StatusInternalServerError)
+		return
+	}
+
+	taskTags, err := h.tagService.GetAllTaskTags(ctx)
+	if err != nil {
+		http.Error(w, "Error fetching task tags", http.StatusInternalServerError)
+		return
+	}
+
+	// Subtasks are listed on their parent's edit page, not on the home page.
+	// The tag parameter narrows the list down to the tasks carrying that tag.
+	tagFilter := r.URL.Query().Get("tag")
+	topLevel := make([]models.Task, 0, len(tasks))
+	for _, task := range tasks {
+		if task.ParentID == 0 && (tagFilter == "" || hasTag(taskTags[task.ID], tagFilter)) {
+			topLevel = append(topLevel, task)
+		}
+	}
+
+	blocked, err := h.taskService.GetBlockedTasks(ctx, taskIDs(topLevel))
+	if err != nil {
+		http.Error(w, "Error fetching blocked tasks", http.StatusInternalServerError)
+		return
+	}
+
 	data := struct {
 		Tasks     []models.Task
 		Courses   []models.Course
 		CourseMap map[int64]string
+		Progress  map[int64]models.TaskProgress
+		Blocked   map[int64]bool
+		Tags      []models.Tag
+		TaskTags  map[int64][]models.Tag
+		TagFilter string
 	}{
-		Tasks:     tasks,
+		Tasks:     topLevel,
 		Courses:   courses,
 		CourseMap: courseMap,
+		Progress:  progress,
+		Blocked:   blocked,
+		Tags:      tags,
+		TaskTags:  taskTags,
+		TagFilter: tagFilter,
 	}
 
 	h.templates.ExecuteTemplate(w, "index.html", data)
 }
 
+// SearchTasks displays the tasks matching the full-text query given in the q parameter,
+// with the matching terms highlighted. Without a query only the search form is shown.
+func (h *Handler) SearchTasks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+
+	var results []models.TaskSearchResult
+	if query != "" {
+		var err error
+		results, err = h.taskService.SearchTasks(ctx, query, services.MaxSearchLimit)
+		if err != nil && !errors.Is(err, services.ErrInvalidSearch) {
+			http.Error(w, "Error searching tasks", errorStatus(err))
+			return
+		}
+	}
+
+	courses, err := h.courseService.GetAllCourses(ctx)
+	if err != nil {
+		http.Error(w, "Error fetching courses", http.StatusInternalServerError)
+		return
+	}
+
+	courseMap := make(map[int64]string)
+	for _, course := range courses {
+		courseMap[course.ID] = course.Name
+	}
+
+	data := struct {
+		Query     string
+		Results   []models.TaskSearchResult
+		CourseMap map[int64]string
+	}{
+		Query:     query,
+		Results:   results,
+		CourseMap: courseMap,
+	}
+
+	h.templates.ExecuteTemplate(w, "search.html", data)
+}
+
 // CreateTaskForm displays the form for creating a new task.
 func (h *Handler) CreateTaskForm(w http.ResponseWriter, r *http.Request) {
-	courses, err := h.courseService.GetAllCourses(r.Context())
+	h.renderCreateTask(w, r, http.StatusOK, "")
+}
+
+// renderCreateTask renders the task creation form, optionally with an error and the values
+// submitted with the request, so a rejected submission can be corrected and sent again.
+func (h *Handler) renderCreateTask(w http.ResponseWriter, r *http.Request, status int, message string) {
+	ctx := r.Context()
+	courses, err := h.courseService.GetAllCourses(ctx)
 	if err != nil {
 		http.Error(w, "Error fetching courses", http.StatusInternalServerError)
 		return
 	}
 
-	h.templates.ExecuteTemplate(w, "create-task.html", courses)
+	tags, err := h.tagService.GetAllTags(ctx)
+	if err != nil {
+		http.Error(w, "Error fetching tags", http.StatusInternalServerError)
+		return
+	}
+
+	weekdays := make(map[string]bool)
+	for _, day := range r.PostForm["repeat_weekday"] {
+		weekdays[day] = true
+	}
+
+	data := struct {
+		Courses  []models.Course
+		Tags     []models.Tag
+		Form     url.Values
+		Weekdays map[string]bool
+		Error    string
+	}{
+		Courses:  courses,
+		Tags:     tags,
+		Form:     r.PostForm,
+		Weekdays: weekdays,
+		Error:    message,
+	}
+
+	w.WriteHeader(status)
+	h.templates.ExecuteTemplate(w, "create-task.html", data)
 }
 
 // CreateTask handles the submission of a new task from the web form.
+// Invalid submissions, including an invalid repeat rule, re-render the form with the error
+// and nothing is created; the task and its series are created together.
 func (h *Handler) CreateTask(w http.ResponseWriter, r *http.Request) {
 	if err := r.ParseForm(); err != nil {
 		http.Error(w, "Error parsing form", http.StatusBadRequest)
@@ -87,24 +226,37 @@ func (h *Handler) CreateTask(w http.ResponseWriter, r *http.Request) {
 
 	courseID, _ := strconv.ParseInt(r.FormValue("course_id"), 10, 64)
 	priority, _ := strconv.Atoi(r.FormValue("priority"))
+	estimate, _ := strconv.ParseFloat(r.FormValue("estimated_hours"), 64)
 	dueDate, err := time.Parse("2006-01-02T15:04", r.FormValue("due_date"))
 	if err != nil {
-		http.Error(w, "Invalid due date format", http.StatusBadRequest)
+		h.renderCreateTask(w, r, http.StatusBadRequest, "Invalid due date format")
 		return
 	}
 
 	task := &models.Task{
-		Title:       r.FormValue("title"),
-		Description: r.FormValue("description"),
-		DueDate:     dueDate,
-		Priority:    priority,
-		Status:      models.TaskStatusPending,
-		CourseID:    courseID,
+		Title:          r.FormValue("title"),
+		Description:    r.FormValue("description"),
+		DueDate:        dueDate,
+		Priority:       priority,
+		Status:         models.TaskStatusPending,
+		EstimatedHours: estimate,
+		CourseID:       courseID,
 	}
 
-	err = h.taskService.CreateTask(r.Context(), task)
+	rule, err := parseRecurrenceForm(r)
 	if err != nil {
-		http.Error(w, "Error creating task: "+err.Error(), http.StatusInternalServerError)
+		h.renderCreateTask(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// The task and its tags are created together, so a rejected tag name leaves no task behind
+	if err := h.taskService.CreateTaskWithTags(r.Context(), task, rule, parseTagList(r.FormValue("tags"))); err != nil {
+		status := errorStatus(err)
+		if status == http.StatusInternalServerError {
+			http.Error(w, "Error creating task", status)
+			return
+		}
+		h.renderCreateTask(w, r, status, err.Error())
 		return
 	}
 
@@ -120,10 +272,17 @@ func (h *Handler) EditTaskForm(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	h.renderEditTask(w, r, id, false)
+}
+
+// renderEditTask renders the edit page of a task with its current stored state.
+// With conflict set, the page explains that the submitted changes were made to an outdated
+// version of the task and were not saved.
+func (h *Handler) renderEditTask(w http.ResponseWriter, r *http.Request, id int64, conflict bool) {
 	ctx := r.Context()
 	task, err := h.taskService.GetTask(ctx, id)
 	if err != nil {
-		http.Error(w, "Error fetching task", http.StatusInternalServerError)
+		http.Error(w, "Error fetching task", errorStatus(err))
 		return
 	}
 
@@ -133,14 +292,132 @@ func (h *Handler) EditTaskForm(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	subtasks, err := h.taskService.GetSubtasks(ctx, id)
+	if err != nil {
+		http.Error(w, "Error fetching subtasks", http.StatusInternalServerError)
+		return
+	}
+
+	progress, err := h.taskService.GetTaskProgress(ctx, id)
+	if err != nil {
+		http.Error(w, "Error fetching task progress", http.StatusInternalServerError)
+		return
+	}
+
+	tags, err := h.tagService.GetTaskTags(ctx, id)
+	if err != nil {
+		http.Error(w, "Error fetching task tags", http.StatusInternalServerError)
+		return
+	}
+	tagNames := make([]string, len(tags))
+	for i, tag := range tags {
+		tagNames[i] = tag.Name
+	}
+
+	dependencies, err := h.taskService.GetDependencies(ctx, id)
+	if err != nil {
+		http.Error(w, "Error fetching task dependencies", http.StatusInternalServerError)
+		return
+	}
+
+	// Every other task that isn't a prerequisite yet can be added as one
+	allTasks, err := h.taskService.GetAllTasks(ctx)
+	if err != nil {
+		http.Error(w, "Error fetching tasks", http.StatusInternalServerError)
+		return
+	}
+	isPrerequisite := make(map[int64]bool, len(dependencies.Prerequisites))
+	for _, prerequisite := range dependencies.Prerequisites {
+		isPrerequisite[prerequisite.ID] = true
+	}
+	var candidates []models.Task
+	for _, candidate := range allTasks {
+		if candidate.ID != id && !isPrerequisite[candidate.ID] {
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	timeEntries, err := h.timeService.GetTimeEntries(ctx, id)
+	if err != nil {
+		http.Error(w, "Error fetching time entries", http.StatusInternalServerError)
+		return
+	}
+	var timeSpent time.Duration
+	var timerRunning bool
+	for _, entry := range timeEntries {
+		timeSpent += entry.Duration
+		timerRunning = timerRunning || entry.Running()
+	}
+
+	history, err := h.taskService.GetTaskHistory(ctx, id)
+	if err != nil {
+		http.Error(w, "Error fetching task history", http.StatusInternalServerError)
+		return
+	}
+
+	var parent *models.Task
+	if task.ParentID != 0 {
+		parent, err = h.taskService.GetTask(ctx, task.ParentID)
+		if err != nil {
+			http.Error(w, "Error fetching parent task", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	var series *models.TaskSeries
+	repeatDays := make(map[time.Weekday]bool)
+	if task.RecurrenceID != 0 {
+		series, err = h.taskService.GetSeries(ctx, id)
+		if err != nil && !errors.Is(err, services.ErrTaskNotRecurring) {
+			http.Error(w, "Error fetching task series", http.StatusInternalServerError)
+			return
+		}
+		if series != nil {
+			for _, day := range series.Recurrence.ByWeekday {
+				repeatDays[day] = true
+			}
+		}
+	}
+
 	data := struct {
-		Task    *models.Task
-		Courses []models.Course
+		Task         *models.Task
+		Parent       *models.Task
+		Subtasks     []models.Task
+		Progress     *models.TaskProgress
+		Series       *models.TaskSeries
+		Weekdays     []time.Weekday
+		RepeatDays   map[time.Weekday]bool
+		Courses      []models.Course
+		TagList      string
+		Depends      *models.TaskDependencies
+		Candidates   []models.Task
+		TimeEntries  []models.TimeEntry
+		TimeSpent    time.Duration
+		TimerRunning bool
+		History      []models.TaskHistoryEntry
+		Conflict     bool
 	}{
-		Task:    task,
-		Courses: courses,
+		Task:         task,
+		Parent:       parent,
+		Subtasks:     subtasks,
+		Progress:     progress,
+		Series:       series,
+		Weekdays:     weekdays,
+		RepeatDays:   repeatDays,
+		Courses:      courses,
+		TagList:      strings.Join(tagNames, ", "),
+		Depends:      dependencies,
+		Candidates:   candidates,
+		TimeEntries:  timeEntries,
+		TimeSpent:    timeSpent,
+		TimerRunning: timerRunning,
+		History:      history,
+		Conflict:     conflict,
 	}
 
+	if conflict {
+		w.WriteHeader(http.StatusConflict)
+	}
 	h.templates.ExecuteTemplate(w, "edit-task.html", data)
 }
 
@@ -160,6 +437,8 @@ func (h *Handler) UpdateTask(w http.ResponseWriter, r *http.Request) {
 
 	courseID, _ := strconv.ParseInt(r.FormValue("course_id"), 10, 64)
 	priority, _ := strconv.Atoi(r.FormValue("priority"))
+	version, _ := strconv.ParseInt(r.FormValue("version"), 10, 64)
+	estimate, _ := strconv.ParseFloat(r.FormValue("estimated_hours"), 64)
 	dueDate, err := time.Parse("2006-01-02T15:04", r.FormValue("due_date"))
 	if err != nil {
 		http.Error(w, "Invalid due date format", http.StatusBadRequest)
@@ -167,18 +446,47 @@ func (h *Handler) UpdateTask(w http.ResponseWriter, r *http.Request) {
 	}
 
 	task := &models.Task{
-		ID:          id,
-		Title:       r.FormValue("title"),
-		Description: r.FormValue("description"),
-		DueDate:     dueDate,
-		Priority:    priority,
-		Status:      models.TaskStatus(r.FormValue("status")),
-		CourseID:    courseID,
+		ID:             id,
+		Title:          r.FormValue("title"),
+		Description:    r.FormValue("description"),
+		DueDate:        dueDate,
+		Priority:       priority,
+		Status:         models.TaskStatus(r.FormValue("status")),
+		EstimatedHours: estimate,
+		CourseID:       courseID,
+		Version:        version,
+	}
+
+	err = h.taskService.UpdateTaskWithTags(r.Context(), task, parseTagList(r.FormValue("tags")))
+	if errors.Is(err, services.ErrVersionConflict) {
+		h.renderEditTask(w, r, id, true)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Error updating task: "+err.Error(), errorStatus(err))
+		return
+	}
+
+	http.Redirect(w, r, "/", http.StatusSeeOther)
+}
+
+// SetTaskStatus handles the quick status change of a task from the task list.
+func (h *Handler) SetTaskStatus(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Error parsing form", http.StatusBadRequest)
+		return
 	}
 
-	err = h.taskService.UpdateTask(r.Context(), task)
+	_, err = h.taskService.SetTaskStatus(r.Context(), id, models.TaskStatus(r.FormValue("status")))
 	if err != nil {
-		http.Error(w, "Error updating task: "+err.Error(), http.StatusInternalServerError)
+		http.Error(w, "Error updating task status: "+err.Error(), errorStatus(err))
 		return
 	}
 
@@ -194,15 +502,148 @@ func (h *Handler) DeleteTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.taskService.DeleteTask(r.Context(), id)
+	err = h.taskService.DeleteTask(r.Context(), id, 0)
 	if err != nil {
-		http.Error(w, "Error deleting task: "+err.Error(), http.StatusInternalServerError)
+		http.Error(w, "Error deleting task: "+err.Error(), errorStatus(err))
 		return
 	}
 
 	http.Redirect(w, r, "/", http.StatusSeeOther)
 }
 
+// CreateSubtask handles the submission of a new subtask from the task edit page.
+// The subtask inherits the course of its parent.
+func (h *Handler) CreateSubtask(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	parentID, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Error parsing form", http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	parent, err := h.taskService.GetTask(ctx, parentID)
+	if err != nil {
+		http.Error(w, "Error fetching task", errorStatus(err))
+		return
+	}
+
+	priority, _ := strconv.Atoi(r.FormValue("priority"))
+	dueDate, err := time.Parse("2006-01-02T15:04", r.FormValue("due_date"))
+	if err != nil {
+		http.Error(w, "Invalid due date format", http.StatusBadRequest)
+		return
+	}
+
+	subtask := &models.Task{
+		Title:    r.FormValue("title"),
+		DueDate:  dueDate,
+		Priority: priority,
+		Status:   models.TaskStatusPending,
+		CourseID: parent.CourseID,
+	}
+
+	err = h.taskService.CreateSubtask(ctx, parentID, subtask)
+	if err != nil {
+		http.Error(w, "Error creating subtask: "+err.Error(), errorStatus(err))
+		return
+	}
+
+	http.Redirect(w, r, "/tasks/"+vars["id"]+"/edit", http.StatusSeeOther)
+}
+
+// UpdateRecurrence handles the submission of the recurrence form from the task edit page.
+// An empty frequency stops the series.
+func (h *Handler) UpdateRecurrence(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Error parsing form", http.StatusBadRequest)
+		return
+	}
+
+	rule, err := parseRecurrenceForm(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if rule == nil {
+		err = h.taskService.RemoveRecurrence(r.Context(), id)
+		if errors.Is(err, services.ErrTaskNotRecurring) {
+			err = nil
+		}
+	} else {
+		err = h.taskService.SetRecurrence(r.Context(), id, rule)
+	}
+	if err != nil {
+		http.Error(w, "Error updating task recurrence: "+err.Error(), errorStatus(err))
+		return
+	}
+
+	http.Redirect(w, r, "/tasks/"+vars["id"]+"/edit", http.StatusSeeOther)
+}
+
+// AddDependency handles the submission of a new prerequisite from the task edit page.
+func (h *Handler) AddDependency(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Error parsing form", http.StatusBadRequest)
+		return
+	}
+
+	dependsOnID, err := strconv.ParseInt(r.FormValue("depends_on_id"), 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid prerequisite task ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.taskService.AddDependency(r.Context(), id, dependsOnID); err != nil {
+		http.Error(w, "Error adding prerequisite: "+err.Error(), errorStatus(err))
+		return
+	}
+
+	http.Redirect(w, r, "/tasks/"+vars["id"]+"/edit", http.StatusSeeOther)
+}
+
+// RemoveDependency handles the removal of a prerequisite from the task edit page.
+func (h *Handler) RemoveDependency(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return
+	}
+	dependsOnID, err := strconv.ParseInt(vars["dependsOnId"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid prerequisite task ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.taskService.RemoveDependency(r.Context(), id, dependsOnID); err != nil {
+		http.Error(w, "Error removing prerequisite: "+err.Error(), errorStatus(err))
+		return
+	}
+
+	http.Redirect(w, r, "/tasks/"+vars["id"]+"/edit", http.StatusSeeOther)
+}
+
 // Course Management Handlers
 
 // CreateCourseForm displays the form for creating a new course.
@@ -224,7 +665,7 @@ func (h *Handler) CreateCourse(w http.ResponseWriter, r *http.Request) {
 
 	err := h.courseService.CreateCourse(r.Context(), course)
 	if err != nil {
-		http.Error(w, "Error creating course: "+err.Error(), http.StatusInternalServerError)
+		http.Error(w, "Error creating course: "+err.Error(), errorStatus(err))
 		return
 	}
 
@@ -242,106 +683,981 @@ func (h *Handler) ListCourses(w http.ResponseWriter, r *http.Request) {
 	h.templates.ExecuteTemplate(w, "courses.html", courses)
 }
 
-// REST API Handlers
-
-// APIGetTasks handles GET requests to retrieve all tasks.
-// Returns a JSON array of tasks.
-func (h *Handler) APIGetTasks(w http.ResponseWriter, r *http.Request) {
-	tasks, err := h.taskService.GetAllTasks(r.Context())
-	if err != nil {
-		http.Error(w, "Error fetching tasks", http.StatusInternalServerError)
-		return
-	}
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(tasks)
-}
-
-// APIGetTask handles GET requests to retrieve a specific task.
-// Returns a JSON object containing task details or 404 if not found.
-func (h *Handler) APIGetTask(w http.ResponseWriter, r *http.Request) {
+// CourseDetail displays a course with its tasks and progress statistics.
+func (h *Handler) CourseDetail(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := strconv.ParseInt(vars["id"], 10, 64)
 	if err != nil {
-		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		http.Error(w, "Invalid course ID", http.StatusBadRequest)
 		return
 	}
 
-	task, err := h.taskService.GetTask(r.Context(), id)
+	ctx := r.Context()
+	course, err := h.courseService.GetCourse(ctx, id)
 	if err != nil {
-		http.Error(w, "Error fetching task", http.StatusInternalServerError)
+		http.Error(w, "Error fetching course", errorStatus(err))
 		return
 	}
-	if task == nil {
-		http.Error(w, "Task not found", http.StatusNotFound)
+
+	tasks, err := h.taskService.GetTasksByCourse(ctx, id)
+	if err != nil {
+		http.Error(w, "Error fetching tasks", errorStatus(err))
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(task)
-}
-
-// APICreateTask handles POST requests to create a new task.
-// Accepts a JSON task object in the request body.
-func (h *Handler) APICreateTask(w http.ResponseWriter, r *http.Request) {
-	var task models.Task
-	if err := json.NewDecoder(r.Body).Decode(&task); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	stats, err := h.courseService.GetCourseStats(ctx, id)
+	if err != nil {
+		http.Error(w, "Error fetching course statistics", errorStatus(err))
 		return
 	}
 
-	err := h.taskService.CreateTask(r.Context(), &task)
+	totals, err := h.timeService.GetTimeTotals(ctx)
 	if err != nil {
-		http.Error(w, "Error creating task: "+err.Error(), http.StatusInternalServerError)
+		http.Error(w, "Error fetching time totals", http.StatusInternalServerError)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	json.NewEncoder(w).Encode(task)
+	data := struct {
+		Course    *models.Course
+		Tasks     []models.Task
+		Stats     *models.CourseStats
+		TimeSpent time.Duration
+		TaskTime  map[int64]time.Duration
+	}{
+		Course:    course,
+		Tasks:     tasks,
+		Stats:     stats,
+		TimeSpent: totals.ByCourse[id],
+		TaskTime:  totals.ByTask,
+	}
+
+	h.templates.ExecuteTemplate(w, "course.html", data)
 }
 
-// APIUpdateTask handles PUT requests to update an existing task.
-// Accepts a JSON task object in the request body.
-func (h *Handler) APIUpdateTask(w http.ResponseWriter, r *http.Request) {
+// EditCourseForm displays the form for editing or deleting an existing course.
+func (h *Handler) EditCourseForm(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := strconv.ParseInt(vars["id"], 10, 64)
 	if err != nil {
-		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		http.Error(w, "Invalid course ID", http.StatusBadRequest)
 		return
 	}
 
-	var task models.Task
-	if err := json.NewDecoder(r.Body).Decode(&task); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+	h.renderEditCourse(w, r, id, false)
+}
+
+// renderEditCourse renders the edit page of a course with its current stored state.
+// With conflict set, the page explains that the submitted changes were made to an outdated
+// version of the course and were not saved.
+func (h *Handler) renderEditCourse(w http.ResponseWriter, r *http.Request, id int64, conflict bool) {
+	ctx := r.Context()
+	course, err := h.courseService.GetCourse(ctx, id)
+	if err != nil {
+		http.Error(w, "Error fetching course", errorStatus(err))
 		return
 	}
 
-	task.ID = id
-	err = h.taskService.UpdateTask(r.Context(), &task)
+	stats, err := h.courseService.GetCourseStats(ctx, id)
 	if err != nil {
-		http.Error(w, "Error updating task: "+err.Error(), http.StatusInternalServerError)
+		http.Error(w, "Error fetching course statistics", errorStatus(err))
 		return
 	}
 
-	w.WriteHeader(http.StatusOK)
+	data := struct {
+		Course   *models.Course
+		Stats    *models.CourseStats
+		Conflict bool
+	}{
+		Course:   course,
+		Stats:    stats,
+		Conflict: conflict,
+	}
+
+	if conflict {
+		w.WriteHeader(http.StatusConflict)
+	}
+	h.templates.ExecuteTemplate(w, "edit-course.html", data)
 }
 
-// APIDeleteTask handles DELETE requests to remove a task.
-// Returns 204 No Content on success or appropriate error status.
-func (h *Handler) APIDeleteTask(w http.ResponseWriter, r *http.Request) {
+// UpdateCourse handles the submission of course updates from the web form.
+func (h *Handler) UpdateCourse(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	id, err := strconv.ParseInt(vars["id"], 10, 64)
 	if err != nil {
-		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		http.Error(w, "Invalid course ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Error parsing form", http.StatusBadRequest)
+		return
+	}
+
+	version, _ := strconv.ParseInt(r.FormValue("version"), 10, 64)
+	course := &models.Course{
+		ID:        id,
+		Name:      r.FormValue("name"),
+		Professor: r.FormValue("professor"),
+		Version:   version,
+	}
+
+	err = h.courseService.UpdateCourse(r.Context(), course)
+	if errors.Is(err, services.ErrVersionConflict) {
+		h.renderEditCourse(w, r, id, true)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Error updating course: "+err.Error(), errorStatus(err))
+		return
+	}
+
+	http.Redirect(w, r, "/courses/"+strconv.FormatInt(id, 10), http.StatusSeeOther)
+}
+
+// DeleteCourse handles the deletion of a course from the web interface.
+// The policy form field decides what happens to the tasks of the course.
+func (h *Handler) DeleteCourse(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid course ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Error parsing form", http.StatusBadRequest)
 		return
 	}
 
-	err = h.taskService.DeleteTask(r.Context(), id)
+	policy := models.CourseDeletePolicy(r.FormValue("policy"))
+	err = h.courseService.DeleteCourse(r.Context(), id, 0, policy)
 	if err != nil {
-		http.Error(w, "Error deleting task: "+err.Error(), http.StatusInternalServerError)
+		http.Error(w, "Error deleting course: "+err.Error(), errorStatus(err))
+		return
+	}
+
+	http.Redirect(w, r, "/courses", http.StatusSeeOther)
+}
+
+// REST API Handlers
+
+// APIGetTasks handles GET requests to retrieve a page of tasks.
+// Supports the status, course_id, priority_min, due_before, due_after, sort, order, limit
+// and cursor query parameters. Returns a JSON array of tasks; the cursor of the next page,
+// if any, is sent in the X-Next-Cursor header.
+func (h *Handler) APIGetTasks(w http.ResponseWriter, r *http.Request) {
+	query, err := parseTaskQuery(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidQuery, err.Error())
+		return
+	}
+
+	ctx := r.Context()
+	page, err := h.taskService.ListTasks(ctx, query)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	relations, err := h.taskRelations(ctx, page.Tasks)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	if page.NextCursor != "" {
+		w.Header().Set("X-Next-Cursor", page.NextCursor)
+	}
+	writeJSON(w, http.StatusOK, newTaskResponses(page.Tasks, relations))
+}
+
+// APISearchTasks handles GET requests to search tasks by full text.
+// The q parameter holds the words to look for and limit bounds the number of results.
+// Returns a JSON array of matches, most relevant first, each with a highlighted snippet.
+func (h *Handler) APISearchTasks(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+
+	limit := 0
+	if v := params.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			writeError(w, http.StatusBadRequest, codeInvalidQuery, "invalid limit parameter")
+			return
+		}
+		limit = n
+	}
+
+	ctx := r.Context()
+	results, err := h.taskService.SearchTasks(ctx, params.Get("q"), limit)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	tasks := make([]models.Task, 0, len(results))
+	for _, result := range results {
+		tasks = append(tasks, result.Task)
+	}
+	relations, err := h.taskRelations(ctx, tasks)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newSearchResultResponses(results, relations))
+}
+
+// APIGetTask handles GET requests to retrieve a specific task.
+// Returns a JSON object containing task details, with its version as ETag, or a 404 error if not found.
+func (h *Handler) APIGetTask(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidID, "Invalid task ID")
+		return
+	}
+
+	task, err := h.taskService.GetTask(r.Context(), id)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	h.writeTask(w, r, http.StatusOK, task)
+}
+
+// APICreateTask handles POST requests to create a new task.
+// Accepts a JSON task object in the request body.
+func (h *Handler) APICreateTask(w http.ResponseWriter, r *http.Request) {
+	var request taskRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	task := request.toModel()
+	if err := h.taskService.CreateTask(r.Context(), task); err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	h.writeTask(w, r, http.StatusCreated, task)
+}
+
+// APIUpdateTask handles PUT requests to replace an existing task.
+// Accepts a JSON task object in the request body and returns the updated task.
+// With an If-Match header, the update only succeeds if the task still has that ETag.
+func (h *Handler) APIUpdateTask(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidID, "Invalid task ID")
+		return
+	}
+
+	version, ok := requireIfMatch(w, r)
+	if !ok {
+		return
+	}
+
+	var request taskRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	task := request.toModel()
+	task.ID = id
+	task.Version = version
+	if err := h.taskService.UpdateTask(r.Context(), task); err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	h.writeTask(w, r, http.StatusOK, task)
+}
+
+// APIPatchTask handles PATCH requests to partially update a task.
+// Accepts a JSON Merge Patch (RFC 7386) in the request body and returns the updated task.
+// With an If-Match header, the patch only applies if the task still has that ETag.
+func (h *Handler) APIPatchTask(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidID, "Invalid task ID")
+		return
+	}
+
+	version, ok := requireIfMatch(w, r)
+	if !ok {
+		return
+	}
+
+	var request taskPatchRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	patch, fields := request.toModel()
+	if len(fields) > 0 {
+		writeError(w, http.StatusBadRequest, codeInvalidBody, "Invalid request body", fields...)
+		return
+	}
+	patch.Version = version
+
+	task, err := h.taskService.PatchTask(r.Context(), id, patch)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	h.writeTask(w, r, http.StatusOK, task)
+}
+
+// APISetTaskStatus handles POST requests to move a task to another status.
+// Accepts a JSON object with the new status and returns the updated task.
+func (h *Handler) APISetTaskStatus(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidID, "Invalid task ID")
+		return
+	}
+
+	var request taskStatusRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	task, err := h.taskService.SetTaskStatus(r.Context(), id, request.Status)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	h.writeTask(w, r, http.StatusOK, task)
+}
+
+// APIDeleteTask handles DELETE requests to remove a task.
+// Returns 204 No Content on success, 412 if an If-Match header no longer matches, or appropriate error status.
+func (h *Handler) APIDeleteTask(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidID, "Invalid task ID")
+		return
+	}
+
+	version, ok := requireIfMatch(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.taskService.DeleteTask(r.Context(), id, version); err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// APIGetSubtasks handles GET requests to retrieve the subtasks of a task.
+// Returns a JSON object with the parent's progress and the array of subtasks.
+func (h *Handler) APIGetSubtasks(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidID, "Invalid task ID")
+		return
+	}
+
+	ctx := r.Context()
+	subtasks, err := h.taskService.GetSubtasks(ctx, id)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	progress, err := h.taskService.GetTaskProgress(ctx, id)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	relations, err := h.taskRelations(ctx, subtasks)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	response := struct {
+		Progress progressResponse `json:"progress"`
+		Subtasks []taskResponse   `json:"subtasks"`
+	}{
+		Progress: newProgressResponse(progress),
+		Subtasks: newTaskResponses(subtasks, relations),
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// APICreateSubtask handles POST requests to create a subtask of an existing task.
+// Accepts a JSON task object in the request body.
+func (h *Handler) APICreateSubtask(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	parentID, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidID, "Invalid task ID")
+		return
+	}
+
+	var request taskRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	task := request.toModel()
+	if err := h.taskService.CreateSubtask(r.Context(), parentID, task); err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	h.writeTask(w, r, http.StatusCreated, task)
+}
+
+// APISetRecurrence handles PUT requests to make a task recurring or change its rule.
+// Accepts a JSON recurrence object in the request body.
+func (h *Handler) APISetRecurrence(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidID, "Invalid task ID")
+		return
+	}
+
+	var request recurrenceRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	rule, fields := request.toModel()
+	if len(fields) > 0 {
+		writeError(w, http.StatusUnprocessableEntity, codeValidationFailed, "The request contains invalid fields", fields...)
+		return
+	}
+
+	if err := h.taskService.SetRecurrence(r.Context(), id, rule); err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newRecurrenceResponse(rule))
+}
+
+// APIDeleteRecurrence handles DELETE requests to stop the series of a task.
+// Returns 204 No Content on success or 409 if the task isn't recurring.
+func (h *Handler) APIDeleteRecurrence(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidID, "Invalid task ID")
+		return
+	}
+
+	if err := h.taskService.RemoveRecurrence(r.Context(), id); err != nil {
+		writeDomainError(w, err)
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// APIGetSeries handles GET requests to retrieve the recurring series of a task.
+// Returns a JSON object with the recurrence rule and every occurrence of the series.
+func (h *Handler) APIGetSeries(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidID, "Invalid task ID")
+		return
+	}
+
+	ctx := r.Context()
+	series, err := h.taskService.GetSeries(ctx, id)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	relations, err := h.taskRelations(ctx, series.Tasks)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	response := struct {
+		Recurrence recurrenceResponse `json:"recurrence"`
+		Tasks      []taskResponse     `json:"tasks"`
+	}{
+		Recurrence: newRecurrenceResponse(series.Recurrence),
+		Tasks:      newTaskResponses(series.Tasks, relations),
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// APIGetCourses handles GET requests to retrieve all courses.
+// Returns a JSON array of courses.
+func (h *Handler) APIGetCourses(w http.ResponseWriter, r *http.Request) {
+	courses, err := h.courseService.GetAllCourses(r.Context())
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	response := make([]courseResponse, 0, len(courses))
+	for _, course := range courses {
+		response = append(response, newCourseResponse(course))
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// APIGetCourse handles GET requests to retrieve a specific course.
+// Returns a JSON object containing course details or 404 if not found.
+func (h *Handler) APIGetCourse(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidID, "Invalid course ID")
+		return
+	}
+
+	course, err := h.courseService.GetCourse(r.Context(), id)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", etag(course.Version))
+	writeJSON(w, http.StatusOK, newCourseResponse(*course))
+}
+
+// APICreateCourse handles POST requests to create a new course.
+// Accepts a JSON course object in the request body.
+func (h *Handler) APICreateCourse(w http.ResponseWriter, r *http.Request) {
+	var request courseRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	course := request.toModel()
+	if err := h.courseService.CreateCourse(r.Context(), course); err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", etag(course.Version))
+	writeJSON(w, http.StatusCreated, newCourseResponse(*course))
+}
+
+// APIUpdateCourse handles PUT requests to replace an existing course.
+// Accepts a JSON course object in the request body and returns the updated course.
+// With an If-Match header, the update only succeeds if the course still has that ETag.
+func (h *Handler) APIUpdateCourse(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidID, "Invalid course ID")
+		return
+	}
+
+	version, ok := requireIfMatch(w, r)
+	if !ok {
+		return
+	}
+
+	var request courseRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	course := request.toModel()
+	course.ID = id
+	course.Version = version
+	if err := h.courseService.UpdateCourse(r.Context(), course); err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", etag(course.Version))
+	writeJSON(w, http.StatusOK, newCourseResponse(*course))
+}
+
+// APIDeleteCourse handles DELETE requests to remove a course.
+// The policy query parameter (restrict, cascade or detach) decides what happens to its tasks.
+// Returns 204 No Content on success, 409 if the course still has tasks under the restrict policy,
+// 412 if an If-Match header no longer matches, or appropriate error status.
+func (h *Handler) APIDeleteCourse(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidID, "Invalid course ID")
+		return
+	}
+
+	version, ok := requireIfMatch(w, r)
+	if !ok {
+		return
+	}
+
+	policy := models.CourseDeletePolicy(r.URL.Query().Get("policy"))
+	if err := h.courseService.DeleteCourse(r.Context(), id, version, policy); err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// APIGetCourseTasks handles GET requests to retrieve the tasks of a specific course.
+// Returns a JSON array of tasks or 404 if the course doesn't exist.
+func (h *Handler) APIGetCourseTasks(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidID, "Invalid course ID")
+		return
+	}
+
+	ctx := r.Context()
+	tasks, err := h.taskService.GetTasksByCourse(ctx, id)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	relations, err := h.taskRelations(ctx, tasks)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newTaskResponses(tasks, relations))
+}
+
+// APIGetTaskTags handles GET requests to retrieve the tags of a specific task.
+// Returns a JSON array of tags or 404 if the task doesn't exist.
+func (h *Handler) APIGetTaskTags(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidID, "Invalid task ID")
+		return
+	}
+
+	tags, err := h.tagService.GetTaskTags(r.Context(), id)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newTagResponses(tags))
+}
+
+// APISetTaskTags handles PUT requests to replace the tags of a task.
+// Accepts a JSON object with the tag names; unknown tags are created.
+// Returns the resulting tags of the task.
+func (h *Handler) APISetTaskTags(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidID, "Invalid task ID")
+		return
+	}
+
+	var request taskTagsRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	tags, err := h.tagService.SetTaskTags(r.Context(), id, request.Tags)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newTagResponses(tags))
+}
+
+// APIGetDependencies handles GET requests to retrieve the dependencies of a specific task.
+// Returns the prerequisites and dependents of the task and whether it is blocked.
+func (h *Handler) APIGetDependencies(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidID, "Invalid task ID")
+		return
+	}
+
+	h.writeDependencies(w, r, http.StatusOK, id)
+}
+
+// APIAddDependency handles POST requests to make a task depend on another task.
+// Accepts a JSON object with the prerequisite's ID; returns 409 if the dependency would create a cycle.
+// Returns the updated dependencies of the task.
+func (h *Handler) APIAddDependency(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidID, "Invalid task ID")
+		return
+	}
+
+	var request dependencyRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	if err := h.taskService.AddDependency(r.Context(), id, request.DependsOnID); err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	h.writeDependencies(w, r, http.StatusCreated, id)
+}
+
+// APIRemoveDependency handles DELETE requests to stop a task from depending on another task.
+// Returns 204 No Content on success or 404 if the dependency doesn't exist.
+func (h *Handler) APIRemoveDependency(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidID, "Invalid task ID")
+		return
+	}
+	dependsOnID, err := strconv.ParseInt(vars["dependsOnId"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidID, "Invalid prerequisite task ID")
+		return
+	}
+
+	if err := h.taskService.RemoveDependency(r.Context(), id, dependsOnID); err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeDependencies responds with the JSON representation of the dependencies of a task.
+func (h *Handler) writeDependencies(w http.ResponseWriter, r *http.Request, status int, id int64) {
+	ctx := r.Context()
+	dependencies, err := h.taskService.GetDependencies(ctx, id)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	relations, err := h.taskRelations(ctx, append(dependencies.Prerequisites, dependencies.Dependents...))
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	writeJSON(w, status, newDependenciesResponse(dependencies, relations))
+}
+
+// writeTask responds with the JSON representation of a single task, with its course, tags and blocked state embedded.
+// The version of the task is sent in the ETag header for conditional updates.
+func (h *Handler) writeTask(w http.ResponseWriter, r *http.Request, status int, task *models.Task) {
+	ctx := r.Context()
+	relations := taskRelations{courses: make(map[int64]courseSummary)}
+	if task.CourseID != 0 {
+		course, err := h.courseService.GetCourse(ctx, task.CourseID)
+		if err != nil {
+			writeDomainError(w, err)
+			return
+		}
+		relations.courses = newCourseSummaries([]models.Course{*course})
+	}
+
+	tags, err := h.tagService.GetTaskTags(ctx, task.ID)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+	relations.tags = newTagNames(map[int64][]models.Tag{task.ID: tags})
+
+	relations.blocked, err = h.taskService.GetBlockedTasks(ctx, []int64{task.ID})
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", etag(task.Version))
+	writeJSON(w, status, newTaskResponse(*task, relations, time.Now()))
+}
+
+// taskRelations returns the short form of the current user's courses, the tags of the given tasks
+// and the tasks that are blocked, for embedding in the responses of those tasks.
+func (h *Handler) taskRelations(ctx context.Context, tasks []models.Task) (taskRelations, error) {
+	courses, err := h.courseService.GetAllCourses(ctx)
+	if err != nil {
+		return taskRelations{}, err
+	}
+
+	ids := taskIDs(tasks)
+	tags, err := h.tagService.GetTagsOfTasks(ctx, ids)
+	if err != nil {
+		return taskRelations{}, err
+	}
+
+	blocked, err := h.taskService.GetBlockedTasks(ctx, ids)
+	if err != nil {
+		return taskRelations{}, err
+	}
+
+	return taskRelations{courses: newCourseSummaries(courses), tags: newTagNames(tags), blocked: blocked}, nil
+}
+
+// taskIDs returns the IDs of the given tasks.
+func taskIDs(tasks []models.Task) []int64 {
+	ids := make([]int64, 0, len(tasks))
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+	return ids
+}
+
+// parseTaskQuery builds a task listing query from the URL query parameters.
+// Dates are accepted either as RFC 3339 timestamps or as plain YYYY-MM-DD days.
+func parseTaskQuery(r *http.Request) (models.TaskQuery, error) {
+	params := r.URL.Query()
+	query := models.TaskQuery{
+		Status: models.TaskStatus(params.Get("status")),
+		Sort:   models.TaskSortField(params.Get("sort")),
+		Tag:    params.Get("tag"),
+		Cursor: params.Get("cursor"),
+	}
+
+	var err error
+	if v := params.Get("course_id"); v != "" {
+		if query.CourseID, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return query, errors.New("invalid course_id parameter")
+		}
+	}
+	if v := params.Get("priority_min"); v != "" {
+		if query.PriorityMin, err = strconv.Atoi(v); err != nil {
+			return query, errors.New("invalid priority_min parameter")
+		}
+	}
+	if v := params.Get("limit"); v != "" {
+		if query.Limit, err = strconv.Atoi(v); err != nil {
+			return query, errors.New("invalid limit parameter")
+		}
+	}
+	if v := params.Get("due_before"); v != "" {
+		if query.DueBefore, err = parseQueryTime(v); err != nil {
+			return query, errors.New("invalid due_before parameter")
+		}
+	}
+	if v := params.Get("due_after"); v != "" {
+		if query.DueAfter, err = parseQueryTime(v); err != nil {
+			return query, errors.New("invalid due_after parameter")
+		}
+	}
+
+	switch params.Get("order") {
+	case "", "asc":
+	case "desc":
+		query.Descending = true
+	default:
+		return query, errors.New("invalid order parameter")
+	}
+
+	return query, nil
+}
+
+// weekdays lists the days of the week in the order they are offered by the recurrence forms
+var weekdays = []time.Weekday{
+	time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday,
+}
+
+// hasTag reports whether the tags include one with the given name, ignoring case.
+func hasTag(tags []models.Tag, name string) bool {
+	for _, tag := range tags {
+		if strings.EqualFold(tag.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseTagList splits the comma-separated tags field of a task form into tag names.
+func parseTagList(value string) []string {
+	var names []string
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// parseRecurrenceForm builds a recurrence rule from the repeat fields of a task form.
+// Returns nil when no frequency is selected, i.e. the task doesn't repeat.
+func parseRecurrenceForm(r *http.Request) (*models.Recurrence, error) {
+	frequency := r.FormValue("repeat")
+	if frequency == "" {
+		return nil, nil
+	}
+
+	rule := &models.Recurrence{Frequency: models.RecurrenceFrequency(frequency)}
+
+	var err error
+	if v := r.FormValue("repeat_interval"); v != "" {
+		if rule.Interval, err = strconv.Atoi(v); err != nil {
+			return nil, errors.New("invalid repeat interval")
+		}
+	}
+	if v := r.FormValue("repeat_count"); v != "" {
+		if rule.Count, err = strconv.Atoi(v); err != nil {
+			return nil, errors.New("invalid repeat count")
+		}
+	}
+	if v := r.FormValue("repeat_until"); v != "" {
+		until, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return nil, errors.New("invalid repeat end date")
+		}
+		// The end date is inclusive: occurrences on that day are still created
+		rule.Until = until.Add(24*time.Hour - time.Second)
+	}
+	for _, v := range r.Form["repeat_weekday"] {
+		day, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, errors.New("invalid repeat weekday")
+		}
+		rule.ByWeekday = append(rule.ByWeekday, time.Weekday(day))
+	}
+	for _, v := range strings.Split(r.FormValue("repeat_except"), ",") {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		date, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			return nil, errors.New("invalid exception date " + v)
+		}
+		rule.ExDates = append(rule.ExDates, date)
+	}
+
+	return rule, nil
+}
+
+// parseQueryTime parses a timestamp query parameter in RFC 3339 or YYYY-MM-DD format.
+func parseQueryTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
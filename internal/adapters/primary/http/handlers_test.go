@@ -0,0 +1,260 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"uni-task-manager/internal/adapters/secondary/sqlite"
+	"uni-task-manager/internal/domain/models"
+	"uni-task-manager/internal/domain/services"
+	"uni-task-manager/internal/ports/output"
+
+	"github.com/gorilla/mux"
+	_ "modernc.org/sqlite"
+)
+
+// testAPI serves the JSON API over a migrated file database with two registered users
+type testAPI struct {
+	router      *mux.Router
+	taskService *services.TaskService
+}
+
+// newTestAPI opens a new database with the same connection settings as the application
+// and wires the handlers the way main does
+func newTestAPI(t *testing.T) *testAPI {
+	t.Helper()
+	ctx := context.Background()
+
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	db, err := sql.Open("sqlite", "file:"+dbPath+"?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_txlock=immediate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	migrator, err := sqlite.NewMigrator(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(`
+		INSERT INTO users (id, email, name, password_hash, created_at, updated_at) VALUES
+			(1, 'ada@example.com', 'Ada', '', '2030-01-01T00:00:00Z', '2030-01-01T00:00:00Z'),
+			(2, 'alan@example.com', 'Alan', '', '2030-01-01T00:00:00Z', '2030-01-01T00:00:00Z')
+	`); err != nil {
+		t.Fatal(err)
+	}
+
+	taskRepo := sqlite.NewTaskRepository(db)
+	courseRepo := sqlite.NewCourseRepository(db)
+	tagRepo := sqlite.NewTagRepository(db)
+	historyRepo := sqlite.NewTaskHistoryRepository(db)
+	uow := sqlite.NewUnitOfWork(db)
+	outboxService := services.NewOutboxService(sqlite.NewOutboxRepository(db), uow, []output.EventSubscriber{})
+	taskService := services.NewTaskService(taskRepo, courseRepo, sqlite.NewRecurrenceRepository(db), tagRepo,
+		sqlite.NewDependencyRepository(db), historyRepo, uow, outboxService)
+	courseService := services.NewCourseService(courseRepo, taskRepo, historyRepo, uow, outboxService)
+	tagService := services.NewTagService(tagRepo, taskRepo, uow)
+	handler := NewHandler(taskService, courseService, tagService, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	router := mux.NewRouter()
+	api := router.PathPrefix("/api").Subrouter()
+	api.HandleFunc("/tasks", handler.APIGetTasks).Methods("GET")
+	api.HandleFunc("/tasks/{id:[0-9]+}", handler.APIGetTask).Methods("GET")
+	api.HandleFunc("/courses", handler.APIGetCourses).Methods("GET")
+	api.HandleFunc("/courses/{id:[0-9]+}", handler.APIGetCourse).Methods("GET")
+	api.HandleFunc("/courses", handler.APICreateCourse).Methods("POST")
+	api.HandleFunc("/courses/{id:[0-9]+}", handler.APIUpdateCourse).Methods("PUT")
+	api.HandleFunc("/courses/{id:[0-9]+}", handler.APIDeleteCourse).Methods("DELETE")
+	api.HandleFunc("/courses/{id:[0-9]+}/tasks", handler.APIGetCourseTasks).Methods("GET")
+
+	return &testAPI{router: router, taskService: taskService}
+}
+
+// do sends a request on behalf of a user; ifMatch and body are left out when empty
+func (a *testAPI) do(t *testing.T, userID int64, method, path, ifMatch, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+	req = req.WithContext(services.ContextWithUserID(req.Context(), userID))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
+	rec := httptest.NewRecorder()
+	a.router.ServeHTTP(rec, req)
+	return rec
+}
+
+// decodeBody decodes the JSON body of a response
+func decodeBody(t *testing.T, rec *httptest.ResponseRecorder, target interface{}) {
+	t.Helper()
+	if err := json.Unmarshal(rec.Body.Bytes(), target); err != nil {
+		t.Fatalf("invalid JSON body %q: %v", rec.Body.String(), err)
+	}
+}
+
+// errorCode returns the code of a JSON error envelope
+func errorCode(t *testing.T, rec *httptest.ResponseRecorder) string {
+	t.Helper()
+	var response errorResponse
+	decodeBody(t, rec, &response)
+	return response.Error.Code
+}
+
+func TestCourseAPI(t *testing.T) {
+	api := newTestAPI(t)
+
+	rec := api.do(t, 1, "POST", "/api/courses", "", `{"name": "Algorithms", "professor": "Knuth"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("POST /api/courses = %d %s, want 201", rec.Code, rec.Body)
+	}
+	var created courseResponse
+	decodeBody(t, rec, &created)
+	if created.ID == 0 || created.Name != "Algorithms" || created.Professor != "Knuth" || created.Version != 1 {
+		t.Errorf("POST /api/courses = %+v, want version 1 of Algorithms by Knuth", created)
+	}
+	if etag := rec.Header().Get("ETag"); etag != `"1"` {
+		t.Errorf("POST /api/courses ETag = %s, want \"1\"", etag)
+	}
+	path := "/api/courses/" + strconv.FormatInt(created.ID, 10)
+
+	rec = api.do(t, 1, "GET", "/api/courses", "", "")
+	var courses []courseResponse
+	decodeBody(t, rec, &courses)
+	if rec.Code != http.StatusOK || len(courses) != 1 || courses[0].ID != created.ID {
+		t.Errorf("GET /api/courses = %d %+v, want the created course", rec.Code, courses)
+	}
+
+	rec = api.do(t, 1, "PUT", path, `"1"`, `{"name": "Advanced Algorithms", "professor": "Knuth"}`)
+	var updated courseResponse
+	decodeBody(t, rec, &updated)
+	if rec.Code != http.StatusOK || updated.Name != "Advanced Algorithms" || updated.Version != 2 {
+		t.Errorf("PUT %s = %d %+v, want version 2 of Advanced Algorithms", path, rec.Code, updated)
+	}
+
+	rec = api.do(t, 1, "GET", path, "", "")
+	var fetched courseResponse
+	decodeBody(t, rec, &fetched)
+	if rec.Code != http.StatusOK || fetched.Name != "Advanced Algorithms" || rec.Header().Get("ETag") != `"2"` {
+		t.Errorf("GET %s = %d %+v (ETag %s), want version 2 of Advanced Algorithms", path, rec.Code, fetched, rec.Header().Get("ETag"))
+	}
+
+	rec = api.do(t, 1, "DELETE", path, `"2"`, "")
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("DELETE %s = %d %s, want 204", path, rec.Code, rec.Body)
+	}
+	if rec = api.do(t, 1, "GET", path, "", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("GET %s after delete = %d, want 404", path, rec.Code)
+	}
+}
+
+func TestCourseAPIErrors(t *testing.T) {
+	api := newTestAPI(t)
+
+	rec := api.do(t, 1, "POST", "/api/courses", "", `{"name": "Algorithms", "professor": "Knuth"}`)
+	var course courseResponse
+	decodeBody(t, rec, &course)
+	path := "/api/courses/" + strconv.FormatInt(course.ID, 10)
+
+	tests := []struct {
+		name       string
+		userID     int64
+		method     string
+		path       string
+		ifMatch    string
+		body       string
+		wantStatus int
+		wantCode   string
+	}{
+		{"create without name", 1, "POST", "/api/courses", "", `{"professor": "Knuth"}`, http.StatusUnprocessableEntity, "empty_name"},
+		{"create with malformed body", 1, "POST", "/api/courses", "", `{"name": `, http.StatusBadRequest, codeInvalidBody},
+		{"get unknown course", 1, "GET", "/api/courses/999", "", "", http.StatusNotFound, "course_not_found"},
+		{"get course of another user", 2, "GET", path, "", "", http.StatusNotFound, "course_not_found"},
+		{"update course of another user", 2, "PUT", path, "", `{"name": "Mine"}`, http.StatusNotFound, "course_not_found"},
+		{"update without name", 1, "PUT", path, "", `{"name": ""}`, http.StatusUnprocessableEntity, "empty_name"},
+		{"update stale version", 1, "PUT", path, `"7"`, `{"name": "Algorithms"}`, http.StatusPreconditionFailed, "version_conflict"},
+		{"update with weak ETag", 1, "PUT", path, `W/"1"`, `{"name": "Algorithms"}`, http.StatusPreconditionFailed, "version_conflict"},
+		{"delete stale version", 1, "DELETE", path, `"7"`, "", http.StatusPreconditionFailed, "version_conflict"},
+		{"delete with unknown policy", 1, "DELETE", path + "?policy=archive", "", "", http.StatusBadRequest, codeInvalidQuery},
+		{"delete course of another user", 2, "DELETE", path, "", "", http.StatusNotFound, "course_not_found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := api.do(t, tt.userID, tt.method, tt.path, tt.ifMatch, tt.body)
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("%s %s = %d %s, want %d", tt.method, tt.path, rec.Code, rec.Body, tt.wantStatus)
+			}
+			if code := errorCode(t, rec); code != tt.wantCode {
+				t.Errorf("%s %s error code = %s, want %s", tt.method, tt.path, code, tt.wantCode)
+			}
+		})
+	}
+
+	// None of the failed requests changed the course
+	rec = api.do(t, 1, "GET", path, "", "")
+	var fetched courseResponse
+	decodeBody(t, rec, &fetched)
+	if fetched.Name != "Algorithms" || fetched.Version != 1 {
+		t.Errorf("GET %s = %+v, want version 1 of Algorithms", path, fetched)
+	}
+}
+
+func TestCourseAPIDeletePolicies(t *testing.T) {
+	ctx := services.ContextWithUserID(context.Background(), 1)
+	dueDate := time.Now().UTC().AddDate(0, 0, 7)
+
+	tests := []struct {
+		name       string
+		policy     string
+		wantStatus int
+		wantTasks  int
+	}{
+		{"restrict by default", "", http.StatusConflict, 1},
+		{"restrict", "restrict", http.StatusConflict, 1},
+		{"detach", "detach", http.StatusNoContent, 1},
+		{"cascade", "cascade", http.StatusNoContent, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newTestAPI(t)
+
+			rec := api.do(t, 1, "POST", "/api/courses", "", `{"name": "Algorithms"}`)
+			var course courseResponse
+			decodeBody(t, rec, &course)
+			task := &models.Task{Title: "Problem set", DueDate: dueDate, Priority: 3, Status: models.TaskStatusPending, CourseID: course.ID}
+			if err := api.taskService.CreateTask(ctx, task); err != nil {
+				t.Fatal(err)
+			}
+
+			path := "/api/courses/" + strconv.FormatInt(course.ID, 10)
+			rec = api.do(t, 1, "DELETE", path+"?policy="+tt.policy, "", "")
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("DELETE %s?policy=%s = %d %s, want %d", path, tt.policy, rec.Code, rec.Body, tt.wantStatus)
+			}
+
+			rec = api.do(t, 1, "GET", "/api/tasks", "", "")
+			var tasks []taskResponse
+			decodeBody(t, rec, &tasks)
+			if len(tasks) != tt.wantTasks {
+				t.Fatalf("GET /api/tasks = %d tasks, want %d", len(tasks), tt.wantTasks)
+			}
+			if tt.wantStatus == http.StatusNoContent && tt.wantTasks > 0 && tasks[0].Course != nil {
+				t.Errorf("task still belongs to course %+v after the course was deleted", tasks[0].Course)
+			}
+		})
+	}
+}
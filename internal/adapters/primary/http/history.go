@@ -0,0 +1,144 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"uni-task-manager/internal/domain/models"
+
+	"github.com/gorilla/mux"
+)
+
+// fieldChangeResponse is the JSON representation of the change of a task field.
+// Values are formatted as text and null when the field had no value.
+type fieldChangeResponse struct {
+	Field string  `json:"field"`
+	Old   *string `json:"old"`
+	New   *string `json:"new"`
+}
+
+// historyActorResponse is the JSON representation of the user who changed a task.
+type historyActorResponse struct {
+	ID    int64  `json:"id"`
+	Email string `json:"email"`
+}
+
+// taskHistoryEntryResponse is the JSON representation of a recorded change of a task.
+type taskHistoryEntryResponse struct {
+	ID        int64                   `json:"id"`
+	TaskID    int64                   `json:"task_id"`
+	Action    models.TaskChangeAction `json:"action"`
+	Version   int64                   `json:"version"`
+	Actor     *historyActorResponse   `json:"actor"`
+	Changes   []fieldChangeResponse   `json:"changes"`
+	CreatedAt time.Time               `json:"created_at"`
+}
+
+// newTaskHistoryResponse converts the history of a task to its JSON representation.
+// The result is never nil so an empty history encodes as [].
+func newTaskHistoryResponse(entries []models.TaskHistoryEntry) []taskHistoryEntryResponse {
+	response := make([]taskHistoryEntryResponse, 0, len(entries))
+	for _, entry := range entries {
+		item := taskHistoryEntryResponse{
+			ID:        entry.ID,
+			TaskID:    entry.TaskID,
+			Action:    entry.Action,
+			Version:   entry.Version,
+			Changes:   make([]fieldChangeResponse, 0, len(entry.Changes)),
+			CreatedAt: entry.CreatedAt,
+		}
+		if entry.ActorID != 0 {
+			item.Actor = &historyActorResponse{ID: entry.ActorID, Email: entry.ActorEmail}
+		}
+		for _, change := range entry.Changes {
+			item.Changes = append(item.Changes, fieldChangeResponse{
+				Field: change.Field,
+				Old:   optionalText(change.Old),
+				New:   optionalText(change.New),
+			})
+		}
+		response = append(response, item)
+	}
+	return response
+}
+
+// optionalText returns nil for an empty value, so it is encoded as JSON null.
+func optionalText(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// RestoreTaskVersion handles form submissions to bring a task back to its state before a recorded change.
+func (h *Handler) RestoreTaskVersion(w http.ResponseWriter, r *http.Request) {
+	id, entryID, ok := historyRoute(r)
+	if !ok {
+		http.Error(w, "Invalid task or history entry ID", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := h.taskService.RestoreTaskVersion(r.Context(), id, entryID, 0); err != nil {
+		http.Error(w, "Error restoring task: "+err.Error(), errorStatus(err))
+		return
+	}
+
+	http.Redirect(w, r, "/tasks/"+strconv.FormatInt(id, 10)+"/edit", http.StatusSeeOther)
+}
+
+// API Handlers
+
+// APIGetTaskHistory handles GET requests to retrieve the recorded changes of a task, newest first.
+func (h *Handler) APIGetTaskHistory(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidID, "Invalid task ID")
+		return
+	}
+
+	entries, err := h.taskService.GetTaskHistory(r.Context(), id)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newTaskHistoryResponse(entries))
+}
+
+// APIRestoreTaskVersion handles POST requests to bring a task back to its state before a recorded change.
+// Returns the restored task, or 412 if an If-Match header no longer matches.
+func (h *Handler) APIRestoreTaskVersion(w http.ResponseWriter, r *http.Request) {
+	id, entryID, ok := historyRoute(r)
+	if !ok {
+		writeError(w, http.StatusBadRequest, codeInvalidID, "Invalid task or history entry ID")
+		return
+	}
+
+	version, ok := requireIfMatch(w, r)
+	if !ok {
+		return
+	}
+
+	task, err := h.taskService.RestoreTaskVersion(r.Context(), id, entryID, version)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	h.writeTask(w, r, http.StatusOK, task)
+}
+
+// historyRoute parses the task and history entry IDs of the route.
+func historyRoute(r *http.Request) (id, entryID int64, ok bool) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	entryID, err = strconv.ParseInt(vars["entryId"], 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return id, entryID, true
+}
@@ -0,0 +1,348 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"uni-task-manager/internal/domain/models"
+	"uni-task-manager/internal/domain/services"
+
+	"github.com/gorilla/mux"
+)
+
+// iCalendar (RFC 5545) constants used by the calendar feeds
+const (
+	// icalProductID identifies this application as the producer of the feeds
+	icalProductID = "-//uni-task-manager//University Task Manager//EN"
+
+	// icalUIDDomain is the right-hand side of every generated UID
+	icalUIDDomain = "uni-task-manager"
+
+	// icalTimeLayout is the UTC DATE-TIME form of RFC 5545
+	icalTimeLayout = "20060102T150405Z"
+
+	// icalMaxLineOctets is the maximum length of a content line before folding
+	icalMaxLineOctets = 75
+)
+
+// Calendar component selectors accepted by the component query parameter
+const (
+	icalComponentEvent = "vevent"
+	icalComponentTodo  = "vtodo"
+	icalComponentBoth  = "both"
+)
+
+// Calendar handles GET requests for the iCalendar feed of all tasks.
+// The component query parameter selects VEVENT (default), VTODO or both entries per task;
+// feeds with events also carry the planned study sessions as blocks.
+func (h *Handler) Calendar(w http.ResponseWriter, r *http.Request) {
+	component, err := parseCalendarComponent(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	days, maxHours, err := parsePlanQuery(r.URL.Query(), "plan_days")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	sessions, err := h.studySessions(r, component, days, maxHours)
+	if err != nil {
+		http.Error(w, "Error planning study sessions: "+err.Error(), errorStatus(err))
+		return
+	}
+
+	tasks, err := h.taskService.GetAllTasks(ctx)
+	if err != nil {
+		http.Error(w, "Error fetching tasks", http.StatusInternalServerError)
+		return
+	}
+
+	courses, err := h.courseService.GetAllCourses(ctx)
+	if err != nil {
+		http.Error(w, "Error fetching courses", http.StatusInternalServerError)
+		return
+	}
+
+	courseMap := make(map[int64]string)
+	for _, course := range courses {
+		courseMap[course.ID] = course.Name
+	}
+
+	writeCalendar(w, "University Tasks", tasks, sessions, courseMap, component)
+}
+
+// CourseCalendar handles GET requests for the iCalendar feed of a single course's tasks.
+func (h *Handler) CourseCalendar(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid course ID", http.StatusBadRequest)
+		return
+	}
+
+	component, err := parseCalendarComponent(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	days, maxHours, err := parsePlanQuery(r.URL.Query(), "plan_days")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	course, err := h.courseService.GetCourse(ctx, id)
+	if errors.Is(err, services.ErrCourseNotFound) {
+		http.Error(w, "Course not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Error fetching course", http.StatusInternalServerError)
+		return
+	}
+
+	tasks, err := h.taskService.GetTasksByCourse(ctx, id)
+	if err != nil {
+		http.Error(w, "Error fetching tasks", http.StatusInternalServerError)
+		return
+	}
+
+	sessions, err := h.studySessions(r, component, days, maxHours)
+	if err != nil {
+		http.Error(w, "Error planning study sessions: "+err.Error(), errorStatus(err))
+		return
+	}
+	var courseSessions []models.StudySession
+	for _, session := range sessions {
+		if session.CourseID == id {
+			courseSessions = append(courseSessions, session)
+		}
+	}
+
+	writeCalendar(w, course.Name, tasks, courseSessions, map[int64]string{course.ID: course.Name}, component)
+}
+
+// studySessions plans the study sessions of a calendar feed with events, as requested by the
+// plan_days and max_hours query parameters. Feeds of VTODO entries only carry no sessions.
+func (h *Handler) studySessions(r *http.Request, component string, days int, maxHours float64) ([]models.StudySession, error) {
+	if component == icalComponentTodo {
+		return nil, nil
+	}
+
+	plan, err := h.plannerService.GetPlan(r.Context(), days, maxHours)
+	if err != nil {
+		return nil, err
+	}
+
+	var sessions []models.StudySession
+	for _, day := range plan.Days {
+		sessions = append(sessions, day.Sessions...)
+	}
+	return sessions, nil
+}
+
+// parseCalendarComponent reads the component query parameter of a calendar feed.
+func parseCalendarComponent(r *http.Request) (string, error) {
+	switch component := strings.ToLower(r.URL.Query().Get("component")); component {
+	case "":
+		return icalComponentEvent, nil
+	case icalComponentEvent, icalComponentTodo, icalComponentBoth:
+		return component, nil
+	default:
+		return "", errors.New("invalid component parameter")
+	}
+}
+
+// writeCalendar renders the tasks and study sessions as an iCalendar object on the response.
+func writeCalendar(w http.ResponseWriter, name string, tasks []models.Task, sessions []models.StudySession, courseMap map[int64]string, component string) {
+	cal := &icalWriter{}
+	now := time.Now()
+
+	cal.line("BEGIN", "VCALENDAR")
+	cal.line("VERSION", "2.0")
+	cal.line("PRODID", icalProductID)
+	cal.line("CALSCALE", "GREGORIAN")
+	cal.line("METHOD", "PUBLISH")
+	cal.line("X-WR-CALNAME", icalText(name))
+
+	for _, task := range tasks {
+		// A task without a due date has no place on the calendar, only on the to-do list
+		if (component == icalComponentEvent || component == icalComponentBoth) && !task.DueDate.IsZero() {
+			writeTaskEvent(cal, task, courseMap[task.CourseID], now)
+		}
+		if component == icalComponentTodo || component == icalComponentBoth {
+			writeTaskTodo(cal, task, courseMap[task.CourseID], now)
+		}
+	}
+	for _, session := range sessions {
+		writeStudySession(cal, session, courseMap[session.CourseID], now)
+	}
+
+	cal.line("END", "VCALENDAR")
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="calendar.ics"`)
+	w.Write([]byte(cal.String()))
+}
+
+// writeTaskEvent emits the due date of a task as a zero-length VEVENT.
+func writeTaskEvent(cal *icalWriter, task models.Task, courseName string, now time.Time) {
+	cal.line("BEGIN", "VEVENT")
+	cal.line("UID", taskEventUID(task.ID))
+	cal.line("DTSTAMP", icalTime(now))
+	cal.line("DTSTART", icalTime(task.DueDate))
+	writeTaskProperties(cal, task, courseName)
+	cal.line("END", "VEVENT")
+}
+
+// writeTaskTodo emits a task as a VTODO with its due date, if it has one, priority and status.
+func writeTaskTodo(cal *icalWriter, task models.Task, courseName string, now time.Time) {
+	cal.line("BEGIN", "VTODO")
+	cal.line("UID", taskTodoUID(task.ID))
+	cal.line("DTSTAMP", icalTime(now))
+	if !task.DueDate.IsZero() {
+		cal.line("DUE", icalTime(task.DueDate))
+	}
+	cal.line("STATUS", icalTodoStatus(task.Status))
+	if task.Status == models.TaskStatusCompleted {
+		cal.line("PERCENT-COMPLETE", "100")
+	}
+	if task.ParentID != 0 {
+		cal.line("RELATED-TO", taskTodoUID(task.ParentID))
+	}
+	writeTaskProperties(cal, task, courseName)
+	cal.line("END", "VTODO")
+}
+
+// writeStudySession emits a planned study session as a VEVENT blocking its time.
+// The session is related to the VTODO of its task.
+func writeStudySession(cal *icalWriter, session models.StudySession, courseName string, now time.Time) {
+	summary := "Study: " + session.Title
+	if courseName != "" {
+		summary = "Study: " + courseName + ": " + session.Title
+	}
+
+	cal.line("BEGIN", "VEVENT")
+	cal.line("UID", studySessionUID(session))
+	cal.line("DTSTAMP", icalTime(now))
+	cal.line("DTSTART", icalTime(session.Start))
+	cal.line("DTEND", icalTime(session.End))
+	cal.line("SUMMARY", icalText(summary))
+	if courseName != "" {
+		cal.line("CATEGORIES", icalText(courseName))
+	}
+	cal.line("RELATED-TO", taskTodoUID(session.TaskID))
+	cal.line("TRANSP", "OPAQUE")
+	cal.line("END", "VEVENT")
+}
+
+// writeTaskProperties emits the properties shared by the VEVENT and VTODO of a task.
+// The summary is prefixed with the course name, the form understood by the calendar import.
+func writeTaskProperties(cal *icalWriter, task models.Task, courseName string) {
+	summary := task.Title
+	if courseName != "" {
+		summary = courseName + ": " + task.Title
+		cal.line("CATEGORIES", icalText(courseName))
+	}
+	cal.line("SUMMARY", icalText(summary))
+	if task.Description != "" {
+		cal.line("DESCRIPTION", icalText(task.Description))
+	}
+	cal.line("PRIORITY", strconv.Itoa(icalPriority(task.Priority)))
+	cal.line("CREATED", icalTime(task.CreatedAt))
+	cal.line("LAST-MODIFIED", icalTime(task.UpdatedAt))
+}
+
+// taskEventUID returns the stable UID of the VEVENT generated for a task.
+func taskEventUID(id int64) string {
+	return fmt.Sprintf("task-%d-due@%s", id, icalUIDDomain)
+}
+
+// studySessionUID returns the UID of the VEVENT generated for a study session, derived from
+// its task and start so that an unchanged session keeps its UID when the feed is refreshed.
+func studySessionUID(session models.StudySession) string {
+	return fmt.Sprintf("study-%d-%s@%s", session.TaskID, session.Start.UTC().Format(icalTimeLayout), icalUIDDomain)
+}
+
+// taskTodoUID returns the stable UID of the VTODO generated for a task.
+func taskTodoUID(id int64) string {
+	return fmt.Sprintf("task-%d@%s", id, icalUIDDomain)
+}
+
+// icalPriority maps the 1 (lowest) to 5 (highest) task priority onto the
+// iCalendar scale, where 1 is the highest, 9 the lowest and 0 undefined.
+func icalPriority(priority int) int {
+	if priority < 1 || priority > 5 {
+		return 0
+	}
+	return 11 - 2*priority
+}
+
+// icalTodoStatus maps a task status onto the VTODO STATUS values.
+func icalTodoStatus(status models.TaskStatus) string {
+	switch status {
+	case models.TaskStatusInProgress:
+		return "IN-PROCESS"
+	case models.TaskStatusCompleted:
+		return "COMPLETED"
+	default:
+		return "NEEDS-ACTION"
+	}
+}
+
+// icalTime formats a timestamp as a UTC DATE-TIME value.
+func icalTime(t time.Time) string {
+	return t.UTC().Format(icalTimeLayout)
+}
+
+// icalText escapes a TEXT property value.
+func icalText(value string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	)
+	return replacer.Replace(value)
+}
+
+// icalWriter accumulates the CRLF-terminated content lines of an iCalendar object.
+type icalWriter struct {
+	b strings.Builder
+}
+
+// line appends a "NAME:value" content line, folded at 75 octets without splitting UTF-8 sequences.
+func (w *icalWriter) line(name, value string) {
+	line := name + ":" + value
+	limit := icalMaxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		w.b.WriteString(line[:cut])
+		w.b.WriteString("\r\n ")
+		line = line[cut:]
+		// Continuation lines start with a space, which counts towards the limit
+		limit = icalMaxLineOctets - 1
+	}
+	w.b.WriteString(line)
+	w.b.WriteString("\r\n")
+}
+
+// String returns the accumulated iCalendar object.
+func (w *icalWriter) String() string {
+	return w.b.String()
+}
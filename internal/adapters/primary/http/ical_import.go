@@ -0,0 +1,407 @@
+package http
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"uni-task-manager/internal/domain/models"
+)
+
+// maxImportSize caps the size of an uploaded calendar file
+const maxImportSize = 10 << 20
+
+// errInvalidCalendar indicates that an uploaded file is not a valid iCalendar object
+var errInvalidCalendar = errors.New("invalid iCalendar file")
+
+// ImportTasksForm displays the form for importing tasks from an iCalendar file.
+func (h *Handler) ImportTasksForm(w http.ResponseWriter, r *http.Request) {
+	courses, err := h.courseService.GetAllCourses(r.Context())
+	if err != nil {
+		http.Error(w, "Error fetching courses", http.StatusInternalServerError)
+		return
+	}
+
+	data := struct {
+		Courses []models.Course
+		Result  *models.ImportResult
+	}{
+		Courses: courses,
+	}
+
+	h.templates.ExecuteTemplate(w, "import-tasks.html", data)
+}
+
+// ImportTasks handles the upload of an iCalendar file from the web form.
+// The import summary is rendered on the same page.
+func (h *Handler) ImportTasks(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+	if err := r.ParseMultipartForm(maxImportSize); err != nil {
+		http.Error(w, "Error parsing form", http.StatusBadRequest)
+		return
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "Missing calendar file", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	items, err := parseCalendar(file)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	opts, err := parseImportOptions(r.FormValue)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	result, err := h.importService.ImportCalendar(ctx, items, opts)
+	if err != nil {
+		http.Error(w, "Error importing calendar: "+err.Error(), errorStatus(err))
+		return
+	}
+
+	courses, err := h.courseService.GetAllCourses(ctx)
+	if err != nil {
+		http.Error(w, "Error fetching courses", http.StatusInternalServerError)
+		return
+	}
+
+	data := struct {
+		Courses []models.Course
+		Result  *models.ImportResult
+	}{
+		Courses: courses,
+		Result:  result,
+	}
+
+	h.templates.ExecuteTemplate(w, "import-tasks.html", data)
+}
+
+// APIImportICS handles POST requests importing an iCalendar file.
+// The file is sent either as the raw request body or as the "file" field of a multipart form;
+// the course_match, separator and course_id query parameters configure the import.
+// Returns a JSON summary of the import.
+func (h *Handler) APIImportICS(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+
+	var body io.Reader = r.Body
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			writeError(w, http.StatusBadRequest, codeInvalidBody, "Missing calendar file")
+			return
+		}
+		defer file.Close()
+		body = file
+	}
+
+	items, err := parseCalendar(body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidBody, err.Error())
+		return
+	}
+
+	opts, err := parseImportOptions(r.URL.Query().Get)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidQuery, err.Error())
+		return
+	}
+
+	result, err := h.importService.ImportCalendar(r.Context(), items, opts)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newImportResultResponse(result))
+}
+
+// parseImportOptions reads the import options from form or query values.
+func parseImportOptions(get func(string) string) (models.ImportOptions, error) {
+	opts := models.ImportOptions{
+		CourseMatch:     models.CourseMatchMode(get("course_match")),
+		PrefixSeparator: get("separator"),
+	}
+
+	if v := get("course_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return opts, errors.New("invalid course_id parameter")
+		}
+		opts.CourseID = id
+	}
+
+	return opts, nil
+}
+
+// icalProperty is a single parsed content line of an iCalendar object
+type icalProperty struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// parseCalendar extracts the VEVENT and VTODO entries of an iCalendar object.
+// Nested components such as VALARM are ignored; VTIMEZONE definitions are not interpreted,
+// TZID parameters being resolved through the system time zone database instead.
+func parseCalendar(r io.Reader) ([]models.CalendarItem, error) {
+	lines, err := unfoldLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []models.CalendarItem
+	var stack []string
+	var current []icalProperty
+	sawCalendar := false
+
+	for _, line := range lines {
+		prop, err := parseContentLine(line)
+		if err != nil {
+			return nil, err
+		}
+
+		switch prop.name {
+		case "BEGIN":
+			component := strings.ToUpper(prop.value)
+			if component == "VCALENDAR" {
+				sawCalendar = true
+			}
+			stack = append(stack, component)
+			if component == "VEVENT" || component == "VTODO" {
+				current = nil
+			}
+			continue
+		case "END":
+			component := strings.ToUpper(prop.value)
+			if len(stack) == 0 || stack[len(stack)-1] != component {
+				return nil, fmt.Errorf("%w: unexpected END:%s", errInvalidCalendar, prop.value)
+			}
+			stack = stack[:len(stack)-1]
+			if component == "VEVENT" || component == "VTODO" {
+				item, err := calendarItem(component, current)
+				if err != nil {
+					return nil, err
+				}
+				items = append(items, item)
+			}
+			continue
+		}
+
+		// Only the properties of the entry itself matter, not those of nested components
+		if len(stack) > 0 && (stack[len(stack)-1] == "VEVENT" || stack[len(stack)-1] == "VTODO") {
+			current = append(current, prop)
+		}
+	}
+
+	if !sawCalendar || len(stack) != 0 {
+		return nil, fmt.Errorf("%w: missing VCALENDAR", errInvalidCalendar)
+	}
+
+	return items, nil
+}
+
+// calendarItem maps the properties of a VEVENT or VTODO onto a calendar item.
+// A VTODO's deadline is its DUE date; a VEVENT's is its start.
+func calendarItem(component string, props []icalProperty) (models.CalendarItem, error) {
+	var item models.CalendarItem
+	var start, due time.Time
+
+	for _, prop := range props {
+		var err error
+		switch prop.name {
+		case "UID":
+			item.UID = prop.value
+		case "SUMMARY":
+			item.Summary = icalUnescape(prop.value)
+		case "DESCRIPTION":
+			item.Description = icalUnescape(prop.value)
+		case "CATEGORIES":
+			for _, category := range splitEscaped(prop.value, ',') {
+				item.Categories = append(item.Categories, icalUnescape(category))
+			}
+		case "ORGANIZER":
+			item.Organizer = prop.params["CN"]
+		case "DTSTART":
+			start, err = parseICalTime(prop)
+		case "DUE":
+			due, err = parseICalTime(prop)
+		case "PRIORITY":
+			if n, convErr := strconv.Atoi(prop.value); convErr == nil {
+				item.Priority = taskPriority(n)
+			}
+		case "STATUS":
+			switch strings.ToUpper(prop.value) {
+			case "COMPLETED":
+				item.Status = models.TaskStatusCompleted
+			case "IN-PROCESS":
+				item.Status = models.TaskStatusInProgress
+			case "CANCELLED":
+				item.Cancelled = true
+			}
+		}
+		if err != nil {
+			return item, fmt.Errorf("%w: %s of %q: %v", errInvalidCalendar, prop.name, item.UID, err)
+		}
+	}
+
+	item.Due = start
+	if component == "VTODO" && !due.IsZero() {
+		item.Due = due
+	}
+
+	return item, nil
+}
+
+// taskPriority maps an iCalendar priority (1 highest, 9 lowest, 0 undefined)
+// onto the task scale (5 highest, 1 lowest, 0 undefined).
+func taskPriority(priority int) int {
+	if priority < 1 || priority > 9 {
+		return 0
+	}
+	return 5 - (priority-1)/2
+}
+
+// parseICalTime parses a DATE or DATE-TIME property value.
+// Date-only deadlines are placed at the end of their day.
+func parseICalTime(prop icalProperty) (time.Time, error) {
+	if strings.EqualFold(prop.params["VALUE"], "DATE") || len(prop.value) == len("20060102") {
+		day, err := time.Parse("20060102", prop.value)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return day.Add(24*time.Hour - time.Minute), nil
+	}
+
+	if strings.HasSuffix(prop.value, "Z") {
+		return time.Parse(icalTimeLayout, prop.value)
+	}
+
+	location := time.UTC
+	if tzid := prop.params["TZID"]; tzid != "" {
+		if loc, err := time.LoadLocation(tzid); err == nil {
+			location = loc
+		}
+	}
+	return time.ParseInLocation("20060102T150405", prop.value, location)
+}
+
+// unfoldLines reads the content lines of an iCalendar object, joining folded continuation lines.
+func unfoldLines(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxImportSize)
+
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			continue
+		}
+		if (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// parseContentLine splits a "NAME;PARAM=value:VALUE" content line.
+// Colons and semicolons inside quoted parameter values are respected.
+func parseContentLine(line string) (icalProperty, error) {
+	prop := icalProperty{params: make(map[string]string)}
+
+	inQuotes := false
+	split := -1
+	for i, c := range line {
+		if c == '"' {
+			inQuotes = !inQuotes
+		}
+		if c == ':' && !inQuotes {
+			split = i
+			break
+		}
+	}
+	if split < 0 {
+		return prop, fmt.Errorf("%w: malformed line %q", errInvalidCalendar, line)
+	}
+
+	head, value := line[:split], line[split+1:]
+	parts := splitQuoted(head, ';')
+	prop.name = strings.ToUpper(parts[0])
+	prop.value = value
+	for _, param := range parts[1:] {
+		key, val, _ := strings.Cut(param, "=")
+		prop.params[strings.ToUpper(key)] = strings.Trim(val, `"`)
+	}
+
+	return prop, nil
+}
+
+// splitQuoted splits s on sep, ignoring separators inside double quotes.
+func splitQuoted(s string, sep rune) []string {
+	var parts []string
+	inQuotes := false
+	start := 0
+	for i, c := range s {
+		switch {
+		case c == '"':
+			inQuotes = !inQuotes
+		case c == sep && !inQuotes:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// splitEscaped splits a TEXT list value on sep, ignoring backslash-escaped separators.
+func splitEscaped(s string, sep byte) []string {
+	var parts []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case sep:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// icalUnescape reverses the TEXT escaping applied by icalText.
+func icalUnescape(value string) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' || i+1 == len(value) {
+			b.WriteByte(value[i])
+			continue
+		}
+		i++
+		switch value[i] {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(value[i])
+		}
+	}
+	return b.String()
+}
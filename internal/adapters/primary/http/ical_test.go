@@ -0,0 +1,35 @@
+package http
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"uni-task-manager/internal/domain/models"
+)
+
+func TestWriteCalendarSkipsMissingDueDates(t *testing.T) {
+	created := time.Date(2029, 12, 1, 9, 0, 0, 0, time.UTC)
+	tasks := []models.Task{
+		{ID: 1, Title: "Essay", DueDate: time.Date(2030, 1, 2, 15, 0, 0, 0, time.UTC), Priority: 3, Status: models.TaskStatusPending, CreatedAt: created, UpdatedAt: created},
+		{ID: 2, Title: "Reading", Priority: 2, Status: models.TaskStatusPending, CreatedAt: created, UpdatedAt: created},
+	}
+
+	rec := httptest.NewRecorder()
+	writeCalendar(rec, "Tasks", tasks, nil, nil, icalComponentBoth)
+	body := rec.Body.String()
+
+	if got := strings.Count(body, "BEGIN:VEVENT"); got != 1 {
+		t.Errorf("got %d VEVENTs, want 1", got)
+	}
+	if got := strings.Count(body, "BEGIN:VTODO"); got != 2 {
+		t.Errorf("got %d VTODOs, want 2", got)
+	}
+	if got := strings.Count(body, "DUE:"); got != 1 {
+		t.Errorf("got %d DUE properties, want 1", got)
+	}
+	if strings.Contains(body, "00010101") {
+		t.Errorf("calendar contains the zero time:\n%s", body)
+	}
+}
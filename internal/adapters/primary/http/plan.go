@@ -0,0 +1,378 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"uni-task-manager/internal/domain/models"
+	"uni-task-manager/internal/domain/services"
+
+	"github.com/gorilla/mux"
+)
+
+// availabilityRequest is the JSON body accepted when adding an availability window.
+// The weekday is a lowercase English day name; times are HH:MM in UTC, the end may be 24:00.
+type availabilityRequest struct {
+	Weekday string `json:"weekday"`
+	Start   string `json:"start"`
+	End     string `json:"end"`
+}
+
+// toModel converts the request into a domain availability window.
+func (req availabilityRequest) toModel() (*models.AvailabilityWindow, []fieldError) {
+	window := &models.AvailabilityWindow{}
+
+	var fields []fieldError
+	day, ok := weekdayNames[strings.ToLower(req.Weekday)]
+	if !ok {
+		fields = append(fields, fieldError{Field: "weekday", Code: "invalid_weekday", Message: fmt.Sprintf("unknown weekday %q", req.Weekday)})
+	}
+	window.Weekday = day
+
+	var err error
+	if window.Start, err = parseClock(req.Start); err != nil {
+		fields = append(fields, fieldError{Field: "start", Code: "invalid_time", Message: fmt.Sprintf("invalid time %q, expected HH:MM", req.Start)})
+	}
+	if window.End, err = parseClock(req.End); err != nil {
+		fields = append(fields, fieldError{Field: "end", Code: "invalid_time", Message: fmt.Sprintf("invalid time %q, expected HH:MM", req.End)})
+	}
+
+	return window, fields
+}
+
+// availabilityResponse is the JSON representation of an availability window.
+type availabilityResponse struct {
+	ID      int64  `json:"id"`
+	Weekday string `json:"weekday"`
+	Start   string `json:"start"`
+	End     string `json:"end"`
+}
+
+// newAvailabilityResponse converts an availability window to its JSON representation.
+func newAvailabilityResponse(window models.AvailabilityWindow) availabilityResponse {
+	return availabilityResponse{
+		ID:      window.ID,
+		Weekday: strings.ToLower(window.Weekday.String()),
+		Start:   formatClock(window.Start),
+		End:     formatClock(window.End),
+	}
+}
+
+// newAvailabilityResponses converts availability windows to their JSON representation.
+// The result is never nil so an empty list encodes as [].
+func newAvailabilityResponses(windows []models.AvailabilityWindow) []availabilityResponse {
+	responses := make([]availabilityResponse, 0, len(windows))
+	for _, window := range windows {
+		responses = append(responses, newAvailabilityResponse(window))
+	}
+	return responses
+}
+
+// studySessionResponse is the JSON representation of a planned study session.
+type studySessionResponse struct {
+	TaskID   int64     `json:"task_id"`
+	Title    string    `json:"title"`
+	CourseID *int64    `json:"course_id"`
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+}
+
+// planDayResponse is the JSON representation of the study planned on a day.
+type planDayResponse struct {
+	Date     string                 `json:"date"`
+	Hours    float64                `json:"hours"`
+	Sessions []studySessionResponse `json:"sessions"`
+}
+
+// unscheduledTaskResponse is the JSON representation of a task that doesn't fit in the plan.
+type unscheduledTaskResponse struct {
+	TaskID  int64     `json:"task_id"`
+	Title   string    `json:"title"`
+	DueDate time.Time `json:"due_date"`
+	Hours   float64   `json:"hours"`
+}
+
+// planResponse is the JSON representation of a study plan.
+type planResponse struct {
+	From           string                    `json:"from"`
+	To             string                    `json:"to"`
+	MaxHoursPerDay float64                   `json:"max_hours_per_day"`
+	Days           []planDayResponse         `json:"days"`
+	Unscheduled    []unscheduledTaskResponse `json:"unscheduled"`
+}
+
+// newPlanResponse converts a study plan to its JSON representation.
+// The lists are never nil so empty lists encode as [].
+func newPlanResponse(plan *models.StudyPlan) planResponse {
+	response := planResponse{
+		From:           plan.From.Format("2006-01-02"),
+		To:             plan.To.Format("2006-01-02"),
+		MaxHoursPerDay: plan.MaxHoursPerDay,
+		Days:           make([]planDayResponse, 0, len(plan.Days)),
+		Unscheduled:    make([]unscheduledTaskResponse, 0, len(plan.Unscheduled)),
+	}
+	for _, day := range plan.Days {
+		dayResponse := planDayResponse{
+			Date:     day.Date.Format("2006-01-02"),
+			Hours:    roundHours(day.Hours),
+			Sessions: make([]studySessionResponse, 0, len(day.Sessions)),
+		}
+		for _, session := range day.Sessions {
+			dayResponse.Sessions = append(dayResponse.Sessions, studySessionResponse{
+				TaskID:   session.TaskID,
+				Title:    session.Title,
+				CourseID: optionalID(session.CourseID),
+				Start:    session.Start,
+				End:      session.End,
+			})
+		}
+		response.Days = append(response.Days, dayResponse)
+	}
+	for _, task := range plan.Unscheduled {
+		response.Unscheduled = append(response.Unscheduled, unscheduledTaskResponse{
+			TaskID:  task.TaskID,
+			Title:   task.Title,
+			DueDate: task.DueDate,
+			Hours:   roundHours(task.Hours),
+		})
+	}
+	return response
+}
+
+// parseClock parses an HH:MM time of day into an offset from midnight; 24:00 is midnight at the end of the day.
+func parseClock(value string) (time.Duration, error) {
+	if value == "24:00" {
+		return 24 * time.Hour, nil
+	}
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// formatClock formats an offset from midnight as an HH:MM time of day.
+func formatClock(offset time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(offset/time.Hour), int(offset%time.Hour/time.Minute))
+}
+
+// parsePlanQuery reads the number of days and the daily limit of a plan from query parameters.
+// Missing parameters are returned as zero values so the service applies its defaults.
+func parsePlanQuery(params url.Values, daysParam string) (days int, maxHours float64, err error) {
+	if v := params.Get(daysParam); v != "" {
+		if days, err = strconv.Atoi(v); err != nil || days <= 0 {
+			return days, maxHours, fmt.Errorf("invalid %s parameter", daysParam)
+		}
+	}
+	if v := params.Get("max_hours"); v != "" {
+		if maxHours, err = strconv.ParseFloat(v, 64); err != nil || maxHours <= 0 {
+			return days, maxHours, errors.New("invalid max_hours parameter")
+		}
+	}
+	return days, maxHours, nil
+}
+
+// availabilityView is an availability window as shown on the plan page.
+type availabilityView struct {
+	ID      int64
+	Weekday time.Weekday
+	Start   string
+	End     string
+}
+
+// Plan handles the "My week" page, showing the study plan and the availability windows.
+// Supports the same days and max_hours query parameters as the API.
+func (h *Handler) Plan(w http.ResponseWriter, r *http.Request) {
+	h.renderPlan(w, r, "")
+}
+
+// AddAvailability handles form submissions to add an availability window.
+func (h *Handler) AddAvailability(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Error parsing form", http.StatusBadRequest)
+		return
+	}
+
+	weekday, err := strconv.Atoi(r.FormValue("weekday"))
+	if err != nil {
+		http.Error(w, "Invalid weekday", http.StatusBadRequest)
+		return
+	}
+	start, err := parseClock(r.FormValue("start"))
+	if err != nil {
+		http.Error(w, "Invalid start time", http.StatusBadRequest)
+		return
+	}
+	end, err := parseClock(r.FormValue("end"))
+	if err != nil {
+		http.Error(w, "Invalid end time", http.StatusBadRequest)
+		return
+	}
+	// A window ending at midnight is entered as 00:00 in the time picker
+	if end == 0 {
+		end = 24 * time.Hour
+	}
+
+	window := &models.AvailabilityWindow{Weekday: time.Weekday(weekday), Start: start, End: end}
+	err = h.plannerService.AddAvailability(r.Context(), window)
+	if errors.Is(err, services.ErrInvalidAvailability) || errors.Is(err, services.ErrAvailabilityOverlap) {
+		w.WriteHeader(errorStatus(err))
+		h.renderPlan(w, r, err.Error())
+		return
+	}
+	if err != nil {
+		http.Error(w, "Error adding availability", errorStatus(err))
+		return
+	}
+
+	http.Redirect(w, r, "/plan", http.StatusSeeOther)
+}
+
+// DeleteAvailability handles form submissions to remove an availability window.
+func (h *Handler) DeleteAvailability(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid availability ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.plannerService.DeleteAvailability(r.Context(), id); err != nil {
+		http.Error(w, "Error deleting availability", errorStatus(err))
+		return
+	}
+
+	http.Redirect(w, r, "/plan", http.StatusSeeOther)
+}
+
+// renderPlan renders the plan page, optionally with an error about the availability form.
+func (h *Handler) renderPlan(w http.ResponseWriter, r *http.Request, message string) {
+	days, maxHours, err := parsePlanQuery(r.URL.Query(), "days")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	plan, err := h.plannerService.GetPlan(ctx, days, maxHours)
+	if err != nil {
+		http.Error(w, "Error planning study sessions: "+err.Error(), errorStatus(err))
+		return
+	}
+
+	windows, err := h.plannerService.GetAvailability(ctx)
+	if err != nil {
+		http.Error(w, "Error fetching availability", http.StatusInternalServerError)
+		return
+	}
+
+	availability := make([]availabilityView, 0, len(windows))
+	for _, window := range windows {
+		availability = append(availability, availabilityView{
+			ID:      window.ID,
+			Weekday: window.Weekday,
+			Start:   formatClock(window.Start),
+			End:     formatClock(window.End),
+		})
+	}
+
+	var total float64
+	for _, day := range plan.Days {
+		total += day.Hours
+	}
+
+	data := struct {
+		Plan         *models.StudyPlan
+		Days         int
+		Total        float64
+		Availability []availabilityView
+		Weekdays     []time.Weekday
+		Error        string
+	}{
+		Plan:         plan,
+		Days:         len(plan.Days),
+		Total:        math.Round(total*10) / 10,
+		Availability: availability,
+		Weekdays:     weekdays,
+		Error:        message,
+	}
+
+	if err := h.templates.ExecuteTemplate(w, "plan.html", data); err != nil {
+		http.Error(w, "Error rendering template", http.StatusInternalServerError)
+	}
+}
+
+// APIGetPlan handles GET requests to plan study sessions for the next days.
+// Supports the days (default 7, max 31) and max_hours (default 4) query parameters.
+func (h *Handler) APIGetPlan(w http.ResponseWriter, r *http.Request) {
+	days, maxHours, err := parsePlanQuery(r.URL.Query(), "days")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidQuery, err.Error())
+		return
+	}
+
+	plan, err := h.plannerService.GetPlan(r.Context(), days, maxHours)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newPlanResponse(plan))
+}
+
+// APIGetAvailability handles GET requests to list the availability windows.
+func (h *Handler) APIGetAvailability(w http.ResponseWriter, r *http.Request) {
+	windows, err := h.plannerService.GetAvailability(r.Context())
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newAvailabilityResponses(windows))
+}
+
+// APIAddAvailability handles POST requests to add an availability window.
+// Returns the created window with 201 Created.
+func (h *Handler) APIAddAvailability(w http.ResponseWriter, r *http.Request) {
+	var request availabilityRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	window, fields := request.toModel()
+	if len(fields) > 0 {
+		writeError(w, http.StatusUnprocessableEntity, codeValidationFailed, "The request contains invalid fields", fields...)
+		return
+	}
+
+	if err := h.plannerService.AddAvailability(r.Context(), window); err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, newAvailabilityResponse(*window))
+}
+
+// APIDeleteAvailability handles DELETE requests to remove an availability window.
+// Returns 204 No Content on success.
+func (h *Handler) APIDeleteAvailability(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidID, "Invalid availability ID")
+		return
+	}
+
+	if err := h.plannerService.DeleteAvailability(r.Context(), id); err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
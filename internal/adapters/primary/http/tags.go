@@ -0,0 +1,223 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"uni-task-manager/internal/domain/models"
+	"uni-task-manager/internal/domain/services"
+
+	"github.com/gorilla/mux"
+)
+
+// tagRequest is the JSON body accepted when creating or renaming a tag.
+type tagRequest struct {
+	Name string `json:"name"`
+}
+
+// tagResponse is the JSON representation of a tag.
+type tagResponse struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// newTagResponse converts a tag to its JSON representation.
+func newTagResponse(tag models.Tag) tagResponse {
+	return tagResponse{
+		ID:        tag.ID,
+		Name:      tag.Name,
+		CreatedAt: tag.CreatedAt.Truncate(time.Second),
+	}
+}
+
+// newTagResponses converts a list of tags to their JSON representation.
+// The result is never nil so an empty list encodes as [].
+func newTagResponses(tags []models.Tag) []tagResponse {
+	responses := make([]tagResponse, 0, len(tags))
+	for _, tag := range tags {
+		responses = append(responses, newTagResponse(tag))
+	}
+	return responses
+}
+
+// ListTags handles requests to display the tags page.
+func (h *Handler) ListTags(w http.ResponseWriter, r *http.Request) {
+	h.renderTags(w, r, "")
+}
+
+// CreateTag handles form submissions to create a tag.
+func (h *Handler) CreateTag(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Error parsing form", http.StatusBadRequest)
+		return
+	}
+
+	err := h.tagService.CreateTag(r.Context(), &models.Tag{Name: r.FormValue("name")})
+	if errors.Is(err, services.ErrInvalidTagName) || errors.Is(err, services.ErrTagExists) {
+		w.WriteHeader(errorStatus(err))
+		h.renderTags(w, r, err.Error())
+		return
+	}
+	if err != nil {
+		http.Error(w, "Error creating tag", errorStatus(err))
+		return
+	}
+
+	http.Redirect(w, r, "/tags", http.StatusSeeOther)
+}
+
+// RenameTag handles form submissions to rename a tag.
+func (h *Handler) RenameTag(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid tag ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Error parsing form", http.StatusBadRequest)
+		return
+	}
+
+	err = h.tagService.UpdateTag(r.Context(), &models.Tag{ID: id, Name: r.FormValue("name")})
+	if errors.Is(err, services.ErrInvalidTagName) || errors.Is(err, services.ErrTagExists) {
+		w.WriteHeader(errorStatus(err))
+		h.renderTags(w, r, err.Error())
+		return
+	}
+	if err != nil {
+		http.Error(w, "Error renaming tag", errorStatus(err))
+		return
+	}
+
+	http.Redirect(w, r, "/tags", http.StatusSeeOther)
+}
+
+// DeleteTag handles form submissions to delete a tag; the tagged tasks are kept.
+func (h *Handler) DeleteTag(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid tag ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.tagService.DeleteTag(r.Context(), id); err != nil {
+		http.Error(w, "Error deleting tag", errorStatus(err))
+		return
+	}
+
+	http.Redirect(w, r, "/tags", http.StatusSeeOther)
+}
+
+// renderTags renders the tags page with the number of tasks carrying each tag, optionally with an error.
+func (h *Handler) renderTags(w http.ResponseWriter, r *http.Request, message string) {
+	ctx := r.Context()
+	tags, err := h.tagService.GetAllTags(ctx)
+	if err != nil {
+		http.Error(w, "Error fetching tags", http.StatusInternalServerError)
+		return
+	}
+
+	taskTags, err := h.tagService.GetAllTaskTags(ctx)
+	if err != nil {
+		http.Error(w, "Error fetching task tags", http.StatusInternalServerError)
+		return
+	}
+
+	counts := make(map[int64]int)
+	for _, tagged := range taskTags {
+		for _, tag := range tagged {
+			counts[tag.ID]++
+		}
+	}
+
+	data := struct {
+		Tags   []models.Tag
+		Counts map[int64]int
+		Error  string
+	}{
+		Tags:   tags,
+		Counts: counts,
+		Error:  message,
+	}
+
+	h.templates.ExecuteTemplate(w, "tags.html", data)
+}
+
+// API Handlers
+
+// APIGetTags handles GET requests to retrieve all tags.
+// Returns a JSON array of tags ordered by name.
+func (h *Handler) APIGetTags(w http.ResponseWriter, r *http.Request) {
+	tags, err := h.tagService.GetAllTags(r.Context())
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newTagResponses(tags))
+}
+
+// APICreateTag handles POST requests to create a new tag.
+// Accepts a JSON object with the tag name; returns 409 if the name is already taken.
+func (h *Handler) APICreateTag(w http.ResponseWriter, r *http.Request) {
+	var request tagRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	tag := &models.Tag{Name: request.Name}
+	if err := h.tagService.CreateTag(r.Context(), tag); err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, newTagResponse(*tag))
+}
+
+// APIUpdateTag handles PUT requests to rename an existing tag.
+// Accepts a JSON object with the new name and returns the renamed tag.
+func (h *Handler) APIUpdateTag(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidID, "Invalid tag ID")
+		return
+	}
+
+	var request tagRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	tag := &models.Tag{ID: id, Name: request.Name}
+	if err := h.tagService.UpdateTag(r.Context(), tag); err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newTagResponse(*tag))
+}
+
+// APIDeleteTag handles DELETE requests to remove a tag from every task and delete it.
+// Returns 204 No Content on success or 404 if the tag doesn't exist.
+func (h *Handler) APIDeleteTag(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidID, "Invalid tag ID")
+		return
+	}
+
+	if err := h.tagService.DeleteTag(r.Context(), id); err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
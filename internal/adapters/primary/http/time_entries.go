@@ -0,0 +1,348 @@
+package http
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"uni-task-manager/internal/domain/models"
+
+	"github.com/gorilla/mux"
+)
+
+// timeEntryRequest is the JSON body accepted when logging a finished session manually.
+type timeEntryRequest struct {
+	StartedAt *time.Time `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at"`
+	Note      string     `json:"note"`
+}
+
+// toModel converts the request into a domain time entry of the given task.
+func (req timeEntryRequest) toModel(taskID int64) *models.TimeEntry {
+	entry := &models.TimeEntry{TaskID: taskID, Note: req.Note}
+	if req.StartedAt != nil {
+		entry.StartedAt = *req.StartedAt
+	}
+	if req.EndedAt != nil {
+		entry.EndedAt = *req.EndedAt
+	}
+	return entry
+}
+
+// timerRequest is the optional JSON body of the timer endpoints.
+type timerRequest struct {
+	Note string `json:"note"`
+}
+
+// timeEntryResponse is the JSON representation of a time entry.
+// A running timer has no end and its duration is the time elapsed so far.
+type timeEntryResponse struct {
+	ID              int64      `json:"id"`
+	TaskID          int64      `json:"task_id"`
+	StartedAt       time.Time  `json:"started_at"`
+	EndedAt         *time.Time `json:"ended_at"`
+	Running         bool       `json:"running"`
+	DurationSeconds int64      `json:"duration_seconds"`
+	Note            string     `json:"note"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// newTimeEntryResponse converts a time entry to its JSON representation.
+func newTimeEntryResponse(entry models.TimeEntry) timeEntryResponse {
+	response := timeEntryResponse{
+		ID:              entry.ID,
+		TaskID:          entry.TaskID,
+		StartedAt:       entry.StartedAt.Truncate(time.Second),
+		Running:         entry.Running(),
+		DurationSeconds: int64(entry.Duration / time.Second),
+		Note:            entry.Note,
+		CreatedAt:       entry.CreatedAt.Truncate(time.Second),
+	}
+	if !entry.Running() {
+		ended := entry.EndedAt.Truncate(time.Second)
+		response.EndedAt = &ended
+	}
+	return response
+}
+
+// timeEntriesResponse is the JSON representation of the time logged on a task.
+type timeEntriesResponse struct {
+	TotalSeconds int64               `json:"total_seconds"`
+	Entries      []timeEntryResponse `json:"entries"`
+}
+
+// newTimeEntriesResponse converts the time entries of a task to their JSON representation.
+// The result is never nil so an empty list encodes as [].
+func newTimeEntriesResponse(entries []models.TimeEntry) timeEntriesResponse {
+	response := timeEntriesResponse{Entries: make([]timeEntryResponse, 0, len(entries))}
+	for _, entry := range entries {
+		response.TotalSeconds += int64(entry.Duration / time.Second)
+		response.Entries = append(response.Entries, newTimeEntryResponse(entry))
+	}
+	return response
+}
+
+// timeTotalResponse is the time logged on a single task or course.
+type timeTotalResponse struct {
+	ID      int64 `json:"id"`
+	Seconds int64 `json:"seconds"`
+}
+
+// timeTotalsResponse is the JSON representation of the time logged per task and per course.
+type timeTotalsResponse struct {
+	TotalSeconds int64               `json:"total_seconds"`
+	Courses      []timeTotalResponse `json:"courses"`
+	Tasks        []timeTotalResponse `json:"tasks"`
+}
+
+// newTimeTotalsResponse converts time totals to their JSON representation, ordered by ID.
+func newTimeTotalsResponse(totals *models.TimeTotals) timeTotalsResponse {
+	return timeTotalsResponse{
+		TotalSeconds: int64(totals.Total / time.Second),
+		Courses:      newTimeTotalResponses(totals.ByCourse),
+		Tasks:        newTimeTotalResponses(totals.ByTask),
+	}
+}
+
+// newTimeTotalResponses converts durations keyed by ID to a list ordered by ID.
+func newTimeTotalResponses(durations map[int64]time.Duration) []timeTotalResponse {
+	responses := make([]timeTotalResponse, 0, len(durations))
+	for id, spent := range durations {
+		responses = append(responses, timeTotalResponse{ID: id, Seconds: int64(spent / time.Second)})
+	}
+	sort.Slice(responses, func(i, j int) bool { return responses[i].ID < responses[j].ID })
+	return responses
+}
+
+// StartTimer handles the start of a timer from the task edit page.
+func (h *Handler) StartTimer(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Error parsing form", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := h.timeService.StartTimer(r.Context(), id, r.FormValue("note")); err != nil {
+		http.Error(w, "Error starting timer: "+err.Error(), errorStatus(err))
+		return
+	}
+
+	http.Redirect(w, r, "/tasks/"+vars["id"]+"/edit", http.StatusSeeOther)
+}
+
+// StopTimer handles the stop of a timer from the task edit page.
+func (h *Handler) StopTimer(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Error parsing form", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := h.timeService.StopTimer(r.Context(), id, r.FormValue("note")); err != nil {
+		http.Error(w, "Error stopping timer: "+err.Error(), errorStatus(err))
+		return
+	}
+
+	http.Redirect(w, r, "/tasks/"+vars["id"]+"/edit", http.StatusSeeOther)
+}
+
+// LogTime handles the submission of a manual time entry from the task edit page.
+func (h *Handler) LogTime(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Error parsing form", http.StatusBadRequest)
+		return
+	}
+
+	startedAt, err := time.Parse("2006-01-02T15:04", r.FormValue("started_at"))
+	if err != nil {
+		http.Error(w, "Invalid start time format", http.StatusBadRequest)
+		return
+	}
+	endedAt, err := time.Parse("2006-01-02T15:04", r.FormValue("ended_at"))
+	if err != nil {
+		http.Error(w, "Invalid end time format", http.StatusBadRequest)
+		return
+	}
+
+	entry := &models.TimeEntry{
+		TaskID:    id,
+		StartedAt: startedAt,
+		EndedAt:   endedAt,
+		Note:      r.FormValue("note"),
+	}
+	if err := h.timeService.LogTime(r.Context(), entry); err != nil {
+		http.Error(w, "Error logging time: "+err.Error(), errorStatus(err))
+		return
+	}
+
+	http.Redirect(w, r, "/tasks/"+vars["id"]+"/edit", http.StatusSeeOther)
+}
+
+// DeleteTimeEntry handles the deletion of a time entry from the task edit page.
+func (h *Handler) DeleteTimeEntry(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid task ID", http.StatusBadRequest)
+		return
+	}
+	entryID, err := strconv.ParseInt(vars["entryId"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid time entry ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.timeService.DeleteTimeEntry(r.Context(), id, entryID); err != nil {
+		http.Error(w, "Error deleting time entry: "+err.Error(), errorStatus(err))
+		return
+	}
+
+	http.Redirect(w, r, "/tasks/"+vars["id"]+"/edit", http.StatusSeeOther)
+}
+
+// API Handlers
+
+// APIGetTimeEntries handles GET requests to retrieve the time logged on a specific task.
+// Returns the total time and the entries of the task, latest first.
+func (h *Handler) APIGetTimeEntries(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidID, "Invalid task ID")
+		return
+	}
+
+	entries, err := h.timeService.GetTimeEntries(r.Context(), id)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newTimeEntriesResponse(entries))
+}
+
+// APILogTime handles POST requests to log a finished session on a task manually.
+// Accepts a JSON object with the start, end and an optional note; returns the created entry.
+func (h *Handler) APILogTime(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidID, "Invalid task ID")
+		return
+	}
+
+	var request timeEntryRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	entry := request.toModel(id)
+	if err := h.timeService.LogTime(r.Context(), entry); err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, newTimeEntryResponse(*entry))
+}
+
+// APIStartTimer handles POST requests to start timing a task.
+// Accepts an optional JSON object with a note; returns the running entry.
+func (h *Handler) APIStartTimer(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidID, "Invalid task ID")
+		return
+	}
+
+	var request timerRequest
+	if r.ContentLength != 0 && !decodeJSON(w, r, &request) {
+		return
+	}
+
+	entry, err := h.timeService.StartTimer(r.Context(), id, request.Note)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, newTimeEntryResponse(*entry))
+}
+
+// APIStopTimer handles POST requests to stop timing a task.
+// Accepts an optional JSON object with a note replacing the current one; returns the finished entry.
+func (h *Handler) APIStopTimer(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidID, "Invalid task ID")
+		return
+	}
+
+	var request timerRequest
+	if r.ContentLength != 0 && !decodeJSON(w, r, &request) {
+		return
+	}
+
+	entry, err := h.timeService.StopTimer(r.Context(), id, request.Note)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newTimeEntryResponse(*entry))
+}
+
+// APIDeleteTimeEntry handles DELETE requests to remove a time entry of a task.
+// Returns 204 No Content on success or 404 if the entry doesn't exist.
+func (h *Handler) APIDeleteTimeEntry(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidID, "Invalid task ID")
+		return
+	}
+	entryID, err := strconv.ParseInt(vars["entryId"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidID, "Invalid time entry ID")
+		return
+	}
+
+	if err := h.timeService.DeleteTimeEntry(r.Context(), id, entryID); err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// APIGetTimeTotals handles GET requests to retrieve the time logged per course and per task.
+func (h *Handler) APIGetTimeTotals(w http.ResponseWriter, r *http.Request) {
+	totals, err := h.timeService.GetTimeTotals(r.Context())
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newTimeTotalsResponse(totals))
+}
@@ -0,0 +1,196 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"uni-task-manager/internal/domain/models"
+	"uni-task-manager/internal/domain/services"
+
+	"github.com/gorilla/mux"
+)
+
+// tokenScopes lists the scopes offered when creating a token, in display order
+var tokenScopes = []models.TokenScope{models.ScopeTasksRead, models.ScopeTasksWrite}
+
+// apiTokenResponse is the JSON representation of an API token.
+// The secret is only included in the response to its creation.
+type apiTokenResponse struct {
+	ID         int64               `json:"id"`
+	Name       string              `json:"name"`
+	Token      string              `json:"token,omitempty"`
+	Scopes     []models.TokenScope `json:"scopes"`
+	LastUsedAt *time.Time          `json:"last_used_at"`
+	ExpiresAt  *time.Time          `json:"expires_at"`
+	CreatedAt  time.Time           `json:"created_at"`
+}
+
+// newAPITokenResponse converts a token to its JSON representation.
+func newAPITokenResponse(token models.APIToken) apiTokenResponse {
+	response := apiTokenResponse{
+		ID:        token.ID,
+		Name:      token.Name,
+		Token:     token.Token,
+		Scopes:    token.Scopes,
+		CreatedAt: token.CreatedAt,
+	}
+	if !token.LastUsedAt.IsZero() {
+		response.LastUsedAt = &token.LastUsedAt
+	}
+	if !token.ExpiresAt.IsZero() {
+		response.ExpiresAt = &token.ExpiresAt
+	}
+	return response
+}
+
+// ListTokens handles requests to display the API tokens page.
+func (h *Handler) ListTokens(w http.ResponseWriter, r *http.Request) {
+	h.renderTokens(w, r, nil, "")
+}
+
+// CreateToken handles form submissions to create an API token, showing its secret once.
+func (h *Handler) CreateToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Error parsing form", http.StatusBadRequest)
+		return
+	}
+
+	var scopes []models.TokenScope
+	for _, scope := range r.Form["scope"] {
+		scopes = append(scopes, models.TokenScope(scope))
+	}
+
+	var expiresAt time.Time
+	if value := r.FormValue("expires_on"); value != "" {
+		day, err := time.ParseInLocation("2006-01-02", value, time.Local)
+		if err != nil {
+			http.Error(w, "Invalid expiry date format", http.StatusBadRequest)
+			return
+		}
+		// The token stays valid for the whole expiry day
+		expiresAt = day.AddDate(0, 0, 1)
+	}
+
+	token, err := h.tokenService.CreateToken(r.Context(), r.FormValue("name"), scopes, expiresAt)
+	if errors.Is(err, services.ErrInvalidTokenRequest) {
+		w.WriteHeader(http.StatusBadRequest)
+		h.renderTokens(w, r, nil, err.Error())
+		return
+	}
+	if err != nil {
+		http.Error(w, "Error creating API token", http.StatusInternalServerError)
+		return
+	}
+
+	h.renderTokens(w, r, token, "")
+}
+
+// RevokeToken handles form submissions to revoke an API token.
+func (h *Handler) RevokeToken(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid token ID", http.StatusBadRequest)
+		return
+	}
+
+	err = h.tokenService.RevokeToken(r.Context(), id)
+	if errors.Is(err, services.ErrTokenNotFound) {
+		http.Error(w, "API token not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Error revoking API token", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/tokens", http.StatusSeeOther)
+}
+
+// renderTokens renders the API tokens page, optionally with a freshly created token or an error.
+func (h *Handler) renderTokens(w http.ResponseWriter, r *http.Request, created *models.APIToken, message string) {
+	tokens, err := h.tokenService.ListTokens(r.Context())
+	if err != nil {
+		http.Error(w, "Error fetching API tokens", http.StatusInternalServerError)
+		return
+	}
+
+	data := struct {
+		Tokens  []models.APIToken
+		Created *models.APIToken
+		Scopes  []models.TokenScope
+		Error   string
+		Now     time.Time
+	}{
+		Tokens:  tokens,
+		Created: created,
+		Scopes:  tokenScopes,
+		Error:   message,
+		Now:     time.Now(),
+	}
+
+	h.templates.ExecuteTemplate(w, "tokens.html", data)
+}
+
+// API Handlers
+
+// APIGetTokens handles API requests to list the current user's tokens.
+func (h *Handler) APIGetTokens(w http.ResponseWriter, r *http.Request) {
+	tokens, err := h.tokenService.ListTokens(r.Context())
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	response := make([]apiTokenResponse, 0, len(tokens))
+	for _, token := range tokens {
+		response = append(response, newAPITokenResponse(token))
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// APICreateToken handles API requests to create a token. The response carries the secret once.
+func (h *Handler) APICreateToken(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		Name      string              `json:"name"`
+		Scopes    []models.TokenScope `json:"scopes"`
+		ExpiresAt *time.Time          `json:"expires_at"`
+	}
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	var expiresAt time.Time
+	if request.ExpiresAt != nil {
+		expiresAt = *request.ExpiresAt
+	}
+
+	token, err := h.tokenService.CreateToken(r.Context(), request.Name, request.Scopes, expiresAt)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, newAPITokenResponse(*token))
+}
+
+// APIDeleteToken handles API requests to revoke a token.
+func (h *Handler) APIDeleteToken(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidID, "Invalid token ID")
+		return
+	}
+
+	err = h.tokenService.RevokeToken(r.Context(), id)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,211 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"uni-task-manager/internal/domain/models"
+
+	"github.com/gorilla/mux"
+)
+
+// webhookRequest is the JSON body accepted to create or replace a webhook.
+// A webhook is active unless active is false.
+type webhookRequest struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+	Active *bool    `json:"active"`
+}
+
+// toWebhook converts the request to a domain webhook.
+func (req webhookRequest) toWebhook() *models.Webhook {
+	webhook := &models.Webhook{
+		URL:    req.URL,
+		Events: req.Events,
+		Active: true,
+	}
+	if req.Active != nil {
+		webhook.Active = *req.Active
+	}
+	return webhook
+}
+
+// webhookResponse is the JSON representation of a webhook.
+// The secret is only included in the response to its creation.
+type webhookResponse struct {
+	ID        int64     `json:"id"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"secret,omitempty"`
+	Events    []string  `json:"events"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// newWebhookResponse converts a webhook to its JSON representation.
+func newWebhookResponse(webhook models.Webhook) webhookResponse {
+	return webhookResponse{
+		ID:        webhook.ID,
+		URL:       webhook.URL,
+		Secret:    webhook.Secret,
+		Events:    webhook.Events,
+		Active:    webhook.Active,
+		CreatedAt: webhook.CreatedAt,
+		UpdatedAt: webhook.UpdatedAt,
+	}
+}
+
+// webhookDeliveryResponse is the JSON representation of a webhook delivery and its last attempt.
+type webhookDeliveryResponse struct {
+	ID             int64                        `json:"id"`
+	EventID        string                       `json:"event_id"`
+	EventType      models.EventType             `json:"event_type"`
+	Status         models.WebhookDeliveryStatus `json:"status"`
+	Attempts       int                          `json:"attempts"`
+	NextAttemptAt  *time.Time                   `json:"next_attempt_at"`
+	ResponseStatus int                          `json:"response_status,omitempty"`
+	LastError      string                       `json:"last_error,omitempty"`
+	Payload        json.RawMessage              `json:"payload"`
+	CreatedAt      time.Time                    `json:"created_at"`
+	UpdatedAt      time.Time                    `json:"updated_at"`
+}
+
+// newWebhookDeliveryResponse converts a webhook delivery to its JSON representation.
+func newWebhookDeliveryResponse(delivery models.WebhookDelivery) webhookDeliveryResponse {
+	response := webhookDeliveryResponse{
+		ID:             delivery.ID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		ResponseStatus: delivery.ResponseStatus,
+		LastError:      delivery.LastError,
+		Payload:        json.RawMessage(delivery.Payload),
+		CreatedAt:      delivery.CreatedAt,
+		UpdatedAt:      delivery.UpdatedAt,
+	}
+	if !delivery.NextAttemptAt.IsZero() {
+		response.NextAttemptAt = &delivery.NextAttemptAt
+	}
+	return response
+}
+
+// API Handlers
+
+// APIGetWebhooks handles API requests to list the current user's webhooks.
+func (h *Handler) APIGetWebhooks(w http.ResponseWriter, r *http.Request) {
+	webhooks, err := h.webhookService.ListWebhooks(r.Context())
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	response := make([]webhookResponse, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		response = append(response, newWebhookResponse(webhook))
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// APICreateWebhook handles API requests to create a webhook. The response carries the secret once.
+func (h *Handler) APICreateWebhook(w http.ResponseWriter, r *http.Request) {
+	var request webhookRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	webhook := request.toWebhook()
+	if err := h.webhookService.CreateWebhook(r.Context(), webhook); err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, newWebhookResponse(*webhook))
+}
+
+// APIGetWebhook handles API requests to retrieve a specific webhook.
+func (h *Handler) APIGetWebhook(w http.ResponseWriter, r *http.Request) {
+	id, ok := webhookID(w, r)
+	if !ok {
+		return
+	}
+
+	webhook, err := h.webhookService.GetWebhook(r.Context(), id)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newWebhookResponse(*webhook))
+}
+
+// APIUpdateWebhook handles API requests to replace the URL, events and active flag of a webhook.
+func (h *Handler) APIUpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	id, ok := webhookID(w, r)
+	if !ok {
+		return
+	}
+
+	var request webhookRequest
+	if !decodeJSON(w, r, &request) {
+		return
+	}
+
+	webhook := request.toWebhook()
+	webhook.ID = id
+	if err := h.webhookService.UpdateWebhook(r.Context(), webhook); err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newWebhookResponse(*webhook))
+}
+
+// APIDeleteWebhook handles API requests to delete a webhook.
+func (h *Handler) APIDeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	id, ok := webhookID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.webhookService.DeleteWebhook(r.Context(), id); err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// APIGetWebhookDeliveries handles API requests to list the latest deliveries to a webhook.
+func (h *Handler) APIGetWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	id, ok := webhookID(w, r)
+	if !ok {
+		return
+	}
+
+	deliveries, err := h.webhookService.GetDeliveries(r.Context(), id)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	response := make([]webhookDeliveryResponse, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		response = append(response, newWebhookDeliveryResponse(delivery))
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// webhookID parses the webhook ID of the route, responding with an error if it is invalid.
+func webhookID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidID, "Invalid webhook ID")
+		return 0, false
+	}
+	return id, true
+}
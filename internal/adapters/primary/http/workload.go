@@ -0,0 +1,177 @@
+package http
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"uni-task-manager/internal/domain/models"
+)
+
+// workloadDayResponse is the JSON representation of the work required on a day.
+type workloadDayResponse struct {
+	Date  string  `json:"date"`
+	Hours float64 `json:"hours"`
+}
+
+// workloadTaskResponse is the JSON representation of the share of a task in the work of a week.
+type workloadTaskResponse struct {
+	TaskID int64   `json:"task_id"`
+	Title  string  `json:"title"`
+	Hours  float64 `json:"hours"`
+}
+
+// workloadWeekResponse is the JSON representation of the work required in a week.
+type workloadWeekResponse struct {
+	Start      string                 `json:"start"`
+	Hours      float64                `json:"hours"`
+	Overloaded bool                   `json:"overloaded"`
+	Tasks      []workloadTaskResponse `json:"tasks"`
+}
+
+// workloadResponse is the JSON representation of a workload forecast.
+// Hours are rounded to two decimals and days are formatted as YYYY-MM-DD.
+type workloadResponse struct {
+	From           string                 `json:"from"`
+	To             string                 `json:"to"`
+	WeeklyCapacity float64                `json:"weekly_capacity"`
+	TotalHours     float64                `json:"total_hours"`
+	Days           []workloadDayResponse  `json:"days"`
+	Weeks          []workloadWeekResponse `json:"weeks"`
+}
+
+// newWorkloadResponse converts a workload forecast to its JSON representation.
+// The lists are never nil so empty lists encode as [].
+func newWorkloadResponse(workload *models.Workload) workloadResponse {
+	response := workloadResponse{
+		From:           workload.From.Format("2006-01-02"),
+		To:             workload.To.Format("2006-01-02"),
+		WeeklyCapacity: workload.WeeklyCapacity,
+		TotalHours:     roundHours(workload.Total),
+		Days:           make([]workloadDayResponse, 0, len(workload.Days)),
+		Weeks:          make([]workloadWeekResponse, 0, len(workload.Weeks)),
+	}
+	for _, day := range workload.Days {
+		response.Days = append(response.Days, workloadDayResponse{
+			Date:  day.Date.Format("2006-01-02"),
+			Hours: roundHours(day.Hours),
+		})
+	}
+	for _, week := range workload.Weeks {
+		weekResponse := workloadWeekResponse{
+			Start:      week.Start.Format("2006-01-02"),
+			Hours:      roundHours(week.Hours),
+			Overloaded: week.Overloaded,
+			Tasks:      make([]workloadTaskResponse, 0, len(week.Tasks)),
+		}
+		for _, task := range week.Tasks {
+			weekResponse.Tasks = append(weekResponse.Tasks, workloadTaskResponse{
+				TaskID: task.TaskID,
+				Title:  task.Title,
+				Hours:  roundHours(task.Hours),
+			})
+		}
+		response.Weeks = append(response.Weeks, weekResponse)
+	}
+	return response
+}
+
+// roundHours rounds a number of hours to two decimals.
+func roundHours(hours float64) float64 {
+	return math.Round(hours*100) / 100
+}
+
+// workloadWeekView is a week of the workload chart, with the width of its bar in percent.
+type workloadWeekView struct {
+	models.WorkloadWeek
+	Percent float64
+}
+
+// Workload handles the workload page, charting the hours required per week.
+// Supports the same from, to and capacity query parameters as the API.
+func (h *Handler) Workload(w http.ResponseWriter, r *http.Request) {
+	from, to, capacity, err := parseWorkloadQuery(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	workload, err := h.workloadService.GetWorkload(r.Context(), from, to, capacity)
+	if err != nil {
+		http.Error(w, "Error forecasting workload: "+err.Error(), errorStatus(err))
+		return
+	}
+
+	// Bars are scaled so that the busiest week, or the capacity if no week exceeds it, fills the chart
+	scale := workload.WeeklyCapacity
+	for _, week := range workload.Weeks {
+		scale = math.Max(scale, week.Hours)
+	}
+	weeks := make([]workloadWeekView, 0, len(workload.Weeks))
+	overloaded := 0
+	for _, week := range workload.Weeks {
+		weeks = append(weeks, workloadWeekView{WorkloadWeek: week, Percent: week.Hours / scale * 100})
+		if week.Overloaded {
+			overloaded++
+		}
+	}
+
+	data := struct {
+		Workload        *models.Workload
+		Weeks           []workloadWeekView
+		CapacityPercent float64
+		Overloaded      int
+	}{
+		Workload:        workload,
+		Weeks:           weeks,
+		CapacityPercent: workload.WeeklyCapacity / scale * 100,
+		Overloaded:      overloaded,
+	}
+
+	if err := h.templates.ExecuteTemplate(w, "workload.html", data); err != nil {
+		http.Error(w, "Error rendering template", http.StatusInternalServerError)
+	}
+}
+
+// APIGetWorkload handles GET requests to forecast the hours required per day and per week.
+// Supports the from and to query parameters (RFC 3339 or YYYY-MM-DD, defaulting to today
+// and eight weeks later) and the weekly capacity in hours (defaulting to 20).
+func (h *Handler) APIGetWorkload(w http.ResponseWriter, r *http.Request) {
+	from, to, capacity, err := parseWorkloadQuery(r.URL.Query())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, codeInvalidQuery, err.Error())
+		return
+	}
+
+	workload, err := h.workloadService.GetWorkload(r.Context(), from, to, capacity)
+	if err != nil {
+		writeDomainError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newWorkloadResponse(workload))
+}
+
+// parseWorkloadQuery reads the range and capacity of a workload forecast from query parameters.
+// Missing parameters are returned as zero values so the service applies its defaults.
+func parseWorkloadQuery(params url.Values) (from, to time.Time, capacity float64, err error) {
+	if v := params.Get("from"); v != "" {
+		if from, err = parseQueryTime(v); err != nil {
+			return from, to, capacity, errors.New("invalid from parameter")
+		}
+	}
+	if v := params.Get("to"); v != "" {
+		if to, err = parseQueryTime(v); err != nil {
+			return from, to, capacity, errors.New("invalid to parameter")
+		}
+	}
+	if v := params.Get("capacity"); v != "" {
+		if capacity, err = strconv.ParseFloat(v, 64); err != nil || capacity <= 0 {
+			return from, to, capacity, errors.New("invalid capacity parameter")
+		}
+	}
+	return from, to, capacity, nil
+}
@@ -0,0 +1,151 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// ErrForbiddenAddress indicates a webhook URL resolving to a loopback, link-local, private or
+// otherwise special-purpose address
+var ErrForbiddenAddress = errors.New("address is not publicly routable")
+
+// forbiddenPrefixes lists the special-purpose ranges not covered by the netip.Addr predicates
+var forbiddenPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),      // "this" network
+	netip.MustParsePrefix("100.64.0.0/10"),  // shared address space (carrier-grade NAT)
+	netip.MustParsePrefix("192.0.0.0/24"),   // IETF protocol assignments
+	netip.MustParsePrefix("198.18.0.0/15"),  // benchmarking
+	netip.MustParsePrefix("240.0.0.0/4"),    // reserved, including the broadcast address
+	netip.MustParsePrefix("64:ff9b::/96"),   // NAT64, which can reach IPv4 addresses behind it
+	netip.MustParsePrefix("64:ff9b:1::/48"), // local-use NAT64
+}
+
+// WebhookClient implements output.WebhookClient with the standard HTTP client.
+// Since the URLs are chosen by users, it refuses to connect to loopback, link-local and private
+// addresses unless allowed to, and doesn't follow redirects, so webhooks cannot be used to probe
+// the network the server runs in.
+type WebhookClient struct {
+	client              *http.Client
+	resolver            *net.Resolver
+	allowPrivateNetwork bool
+}
+
+// NewWebhookClient creates a new instance of WebhookClient whose requests time out after 10 seconds.
+// allowPrivateNetwork lifts the address restrictions, for receivers on the local machine or network.
+func NewWebhookClient(allowPrivateNetwork bool) *WebhookClient {
+	c := &WebhookClient{
+		resolver:            net.DefaultResolver,
+		allowPrivateNetwork: allowPrivateNetwork,
+	}
+
+	// The address is checked once resolved, right before connecting, so a host name cannot
+	// pass CheckURL and then be pointed at a private address. Requests don't go through an
+	// environment proxy, which would hide the address actually requested.
+	dialer := &net.Dialer{
+		Timeout: 5 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			addr, err := netip.ParseAddr(host)
+			if err != nil {
+				return err
+			}
+			return c.checkAddress(addr)
+		},
+	}
+	c.client = &http.Client{
+		Timeout: 10 * time.Second,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: 5 * time.Second,
+			MaxIdleConns:        10,
+			IdleConnTimeout:     90 * time.Second,
+		},
+		// A redirect is reported as the response of the delivery instead of being followed
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	return c
+}
+
+// CheckURL implements output.WebhookClient.CheckURL.
+// The host of the URL must resolve, and only to publicly routable addresses.
+func (c *WebhookClient) CheckURL(ctx context.Context, rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+
+	host := parsed.Hostname()
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return c.checkAddress(addr)
+	}
+
+	addrs, err := c.resolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return fmt.Errorf("cannot resolve %s", host)
+	}
+	for _, addr := range addrs {
+		if err := c.checkAddress(addr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Post implements output.WebhookClient.Post. The response body is discarded.
+func (c *WebhookClient) Post(ctx context.Context, url string, headers map[string]string, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// Drain a bounded amount of the body so the connection can be reused
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	return resp.StatusCode, nil
+}
+
+// checkAddress returns ErrForbiddenAddress for addresses webhooks may not be delivered to.
+func (c *WebhookClient) checkAddress(addr netip.Addr) error {
+	if c.allowPrivateNetwork || publicAddress(addr) {
+		return nil
+	}
+	return fmt.Errorf("%s: %w", addr, ErrForbiddenAddress)
+}
+
+// publicAddress reports whether an address is publicly routable.
+func publicAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() || addr.IsUnspecified() || addr.IsLoopback() || addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() {
+		return false
+	}
+	for _, prefix := range forbiddenPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
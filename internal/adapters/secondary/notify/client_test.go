@@ -0,0 +1,98 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+)
+
+func TestPublicAddress(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"0.0.0.0", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"100.64.0.1", false},
+		{"fc00::1", false},
+		{"fe80::1", false},
+		{"::ffff:127.0.0.1", false},
+		{"::ffff:169.254.169.254", false},
+		{"64:ff9b::a9fe:a9fe", false},
+		{"255.255.255.255", false},
+		{"224.0.0.1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			if got := publicAddress(netip.MustParseAddr(tt.addr)); got != tt.want {
+				t.Errorf("publicAddress(%s) = %v, want %v", tt.addr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebhookClientCheckURL(t *testing.T) {
+	client := NewWebhookClient(false)
+	ctx := context.Background()
+
+	for _, rawURL := range []string{
+		"http://127.0.0.1:8080/hook",
+		"http://[::1]/hook",
+		"http://169.254.169.254/latest/meta-data/",
+		"https://10.0.0.5/hook",
+		"http://localhost/hook",
+	} {
+		if err := client.CheckURL(ctx, rawURL); !errors.Is(err, ErrForbiddenAddress) {
+			t.Errorf("CheckURL(%s) = %v, want %v", rawURL, err, ErrForbiddenAddress)
+		}
+	}
+
+	if err := client.CheckURL(ctx, "https://93.184.216.34/hook"); err != nil {
+		t.Errorf("CheckURL() of a public address = %v, want nil", err)
+	}
+	if err := NewWebhookClient(true).CheckURL(ctx, "http://127.0.0.1:8080/hook"); err != nil {
+		t.Errorf("CheckURL() with private networks allowed = %v, want nil", err)
+	}
+}
+
+func TestWebhookClientPost(t *testing.T) {
+	var hits int
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+	}))
+	defer target.Close()
+
+	redirect := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target.URL, http.StatusTemporaryRedirect)
+	}))
+	defer redirect.Close()
+
+	ctx := context.Background()
+
+	// The test servers listen on loopback, which is refused when connecting
+	if _, err := NewWebhookClient(false).Post(ctx, target.URL, nil, []byte("{}")); !errors.Is(err, ErrForbiddenAddress) {
+		t.Errorf("Post() to loopback = %v, want %v", err, ErrForbiddenAddress)
+	}
+
+	status, err := NewWebhookClient(true).Post(ctx, redirect.URL, nil, []byte("{}"))
+	if err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+	if status != http.StatusTemporaryRedirect {
+		t.Errorf("Post() status = %d, want %d", status, http.StatusTemporaryRedirect)
+	}
+	if hits != 0 {
+		t.Errorf("redirect was followed %d times, want 0", hits)
+	}
+}
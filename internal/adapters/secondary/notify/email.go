@@ -0,0 +1,57 @@
+// Package notify provides the notification adapters (driven adapters) that deliver
+// notifications to users, each implementing the output.Notifier port for one channel.
+package notify
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"uni-task-manager/internal/domain/models"
+)
+
+// EmailNotifier implements output.Notifier by sending plain-text emails through an SMTP server.
+type EmailNotifier struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+// NewEmailNotifier creates a new instance of EmailNotifier sending from the given address through
+// the SMTP server at addr (host:port). The server is authenticated with PLAIN if a username is given.
+func NewEmailNotifier(addr, from, username, password string) *EmailNotifier {
+	notifier := &EmailNotifier{addr: addr, from: from}
+	if username != "" {
+		host, _, _ := net.SplitHostPort(addr)
+		notifier.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return notifier
+}
+
+// Channel implements output.Notifier.Channel.
+func (n *EmailNotifier) Channel() string {
+	return "email"
+}
+
+// Notify implements output.Notifier.Notify by emailing the notification to the user.
+func (n *EmailNotifier) Notify(ctx context.Context, notification models.Notification) error {
+	if notification.Email == "" {
+		return fmt.Errorf("user %d has no email address", notification.UserID)
+	}
+
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", n.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", notification.Email)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", notification.Subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(strings.ReplaceAll(notification.Body, "\n", "\r\n"))
+
+	return smtp.SendMail(n.addr, n.auth, n.from, []string{notification.Email}, []byte(msg.String()))
+}
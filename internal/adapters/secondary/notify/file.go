@@ -0,0 +1,70 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"uni-task-manager/internal/domain/models"
+)
+
+// FileNotifier implements output.Notifier by appending notifications as JSON lines to a file or
+// another writer, such as standard output. It is useful for development and as an audit trail.
+type FileNotifier struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewFileNotifier creates a new instance of FileNotifier writing to w.
+func NewFileNotifier(w io.Writer) *FileNotifier {
+	return &FileNotifier{w: w}
+}
+
+// OpenFileNotifier creates a new instance of FileNotifier appending to the file at path, creating it if needed.
+func OpenFileNotifier(path string) (*FileNotifier, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return NewFileNotifier(file), nil
+}
+
+// fileRecord is the JSON line written for every notification.
+type fileRecord struct {
+	Time        time.Time `json:"time"`
+	UserID      int64     `json:"user_id"`
+	Email       string    `json:"email"`
+	TaskID      int64     `json:"task_id"`
+	Rule        string    `json:"rule"`
+	Subject     string    `json:"subject"`
+	ScheduledAt time.Time `json:"scheduled_at"`
+}
+
+// Channel implements output.Notifier.Channel.
+func (n *FileNotifier) Channel() string {
+	return "log"
+}
+
+// Notify implements output.Notifier.Notify.
+func (n *FileNotifier) Notify(ctx context.Context, notification models.Notification) error {
+	line, err := json.Marshal(fileRecord{
+		Time:        time.Now().UTC().Truncate(time.Second),
+		UserID:      notification.UserID,
+		Email:       notification.Email,
+		TaskID:      notification.TaskID,
+		Rule:        notification.Rule,
+		Subject:     notification.Subject,
+		ScheduledAt: notification.ScheduledAt.UTC(),
+	})
+	if err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	_, err = n.w.Write(append(line, '\n'))
+	return err
+}
@@ -0,0 +1,79 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"uni-task-manager/internal/domain/models"
+)
+
+// WebhookNotifier implements output.Notifier by posting notifications as JSON to a URL.
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookNotifier creates a new instance of WebhookNotifier posting to the given URL.
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{
+		url:    url,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// webhookPayload is the JSON body posted for every notification.
+type webhookPayload struct {
+	UserID      int64     `json:"user_id"`
+	Email       string    `json:"email"`
+	TaskID      int64     `json:"task_id"`
+	Title       string    `json:"title"`
+	DueDate     time.Time `json:"due_date"`
+	Rule        string    `json:"rule"`
+	Subject     string    `json:"subject"`
+	Body        string    `json:"body"`
+	ScheduledAt time.Time `json:"scheduled_at"`
+}
+
+// Channel implements output.Notifier.Channel.
+func (n *WebhookNotifier) Channel() string {
+	return "webhook"
+}
+
+// Notify implements output.Notifier.Notify. Any response other than 2xx is a failed delivery.
+func (n *WebhookNotifier) Notify(ctx context.Context, notification models.Notification) error {
+	body, err := json.Marshal(webhookPayload{
+		UserID:      notification.UserID,
+		Email:       notification.Email,
+		TaskID:      notification.TaskID,
+		Title:       notification.Title,
+		DueDate:     notification.DueDate.UTC(),
+		Rule:        notification.Rule,
+		Subject:     notification.Subject,
+		Body:        notification.Body,
+		ScheduledAt: notification.ScheduledAt.UTC(),
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"uni-task-manager/internal/domain/models"
+)
+
+// availabilityColumns lists the columns selected for every availability window query, in scanAvailabilityWindow order
+const availabilityColumns = "id, owner_id, weekday, start_minute, end_minute, created_at"
+
+// AvailabilityRepository implements output.AvailabilityRepository interface using SQLite as the storage backend.
+// The times of day of a window are stored as minutes since midnight.
+type AvailabilityRepository struct {
+	db *sql.DB
+}
+
+// NewAvailabilityRepository creates a new instance of AvailabilityRepository with the provided database connection.
+func NewAvailabilityRepository(db *sql.DB) *AvailabilityRepository {
+	return &AvailabilityRepository{db: db}
+}
+
+// GetAll retrieves all availability windows of the owner from the database, ordered by weekday and start.
+func (r *AvailabilityRepository) GetAll(ctx context.Context, ownerID int64) ([]models.AvailabilityWindow, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT `+availabilityColumns+`
+		FROM availability_windows
+		WHERE owner_id = ?
+		ORDER BY weekday ASC, start_minute ASC
+	`, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var windows []models.AvailabilityWindow
+	for rows.Next() {
+		window, err := scanAvailabilityWindow(rows)
+		if err != nil {
+			return nil, err
+		}
+		windows = append(windows, *window)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return windows, nil
+}
+
+// GetByID retrieves a specific availability window of the owner by its ID from the database.
+// Returns nil if the owner has no window with the given ID.
+func (r *AvailabilityRepository) GetByID(ctx context.Context, ownerID, id int64) (*models.AvailabilityWindow, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, `
+		SELECT `+availabilityColumns+`
+		FROM availability_windows
+		WHERE id = ? AND owner_id = ?
+	`, id, ownerID)
+
+	window, err := scanAvailabilityWindow(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return window, nil
+}
+
+// Create persists a new availability window in the database.
+// It sets the ID field of the window with the generated ID.
+func (r *AvailabilityRepository) Create(ctx context.Context, window *models.AvailabilityWindow) error {
+	result, err := conn(ctx, r.db).ExecContext(ctx, `
+		INSERT INTO availability_windows (owner_id, weekday, start_minute, end_minute, created_at)
+		VALUES (?, ?, ?, ?, ?)
+	`,
+		window.OwnerID,
+		int(window.Weekday),
+		int(window.Start/time.Minute),
+		int(window.End/time.Minute),
+		window.CreatedAt.UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	window.ID = id
+	return nil
+}
+
+// Delete removes an availability window of the owner from the database by its ID.
+func (r *AvailabilityRepository) Delete(ctx context.Context, ownerID, id int64) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, "DELETE FROM availability_windows WHERE id = ? AND owner_id = ?", id, ownerID)
+	return err
+}
+
+// scanAvailabilityWindow maps a single row selected with availabilityColumns to a domain AvailabilityWindow object.
+func scanAvailabilityWindow(row rowScanner) (*models.AvailabilityWindow, error) {
+	var window models.AvailabilityWindow
+	var weekday, startMinute, endMinute int
+	var createdAt string
+
+	if err := row.Scan(
+		&window.ID,
+		&window.OwnerID,
+		&weekday,
+		&startMinute,
+		&endMinute,
+		&createdAt,
+	); err != nil {
+		return nil, err
+	}
+
+	window.Weekday = time.Weekday(weekday)
+	window.Start = time.Duration(startMinute) * time.Minute
+	window.End = time.Duration(endMinute) * time.Minute
+	window.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+
+	return &window, nil
+}
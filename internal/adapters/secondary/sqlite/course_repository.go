@@ -9,6 +9,9 @@ import (
 	"uni-task-manager/internal/domain/models"
 )
 
+// courseColumns lists the columns selected for every course query, in scanCourse order
+const courseColumns = "id, owner_id, name, professor, version, created_at, updated_at"
+
 // CourseRepository implements output.CourseRepository interface using SQLite as the storage backend.
 // It handles all course-related database operations and mapping between domain models and database rows.
 type CourseRepository struct {
@@ -20,14 +23,15 @@ func NewCourseRepository(db *sql.DB) *CourseRepository {
 	return &CourseRepository{db: db}
 }
 
-// GetAll retrieves all courses from the database, ordered by name.
+// GetAll retrieves all courses of the owner from the database, ordered by name.
 // It maps the database rows to domain Course objects.
-func (r *CourseRepository) GetAll(ctx context.Context) ([]models.Course, error) {
-	rows, err := r.db.QueryContext(ctx, `
-		SELECT id, name, professor, created_at, updated_at
+func (r *CourseRepository) GetAll(ctx context.Context, ownerID int64) ([]models.Course, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT `+courseColumns+`
 		FROM courses
+		WHERE owner_id = ?
 		ORDER BY name ASC
-	`)
+	`, ownerID)
 	if err != nil {
 		return nil, err
 	}
@@ -35,23 +39,11 @@ func (r *CourseRepository) GetAll(ctx context.Context) ([]models.Course, error)
 
 	var courses []models.Course
 	for rows.Next() {
-		var course models.Course
-		var createdAt, updatedAt string
-
-		if err := rows.Scan(
-			&course.ID,
-			&course.Name,
-			&course.Professor,
-			&createdAt,
-			&updatedAt,
-		); err != nil {
+		course, err := scanCourse(rows)
+		if err != nil {
 			return nil, err
 		}
-
-		course.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
-		course.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
-
-		courses = append(courses, course)
+		courses = append(courses, *course)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -61,24 +53,16 @@ func (r *CourseRepository) GetAll(ctx context.Context) ([]models.Course, error)
 	return courses, nil
 }
 
-// GetByID retrieves a specific course by its ID from the database.
-// Returns nil if no course is found with the given ID.
-func (r *CourseRepository) GetByID(ctx context.Context, id int64) (*models.Course, error) {
-	var course models.Course
-	var createdAt, updatedAt string
-
-	err := r.db.QueryRowContext(ctx, `
-		SELECT id, name, professor, created_at, updated_at
+// GetByID retrieves a specific course of the owner by its ID from the database.
+// Returns nil if the owner has no course with the given ID.
+func (r *CourseRepository) GetByID(ctx context.Context, ownerID, id int64) (*models.Course, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, `
+		SELECT `+courseColumns+`
 		FROM courses
-		WHERE id = ?
-	`, id).Scan(
-		&course.ID,
-		&course.Name,
-		&course.Professor,
-		&createdAt,
-		&updatedAt,
-	)
+		WHERE id = ? AND owner_id = ?
+	`, id, ownerID)
 
+	course, err := scanCourse(row)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -86,23 +70,43 @@ func (r *CourseRepository) GetByID(ctx context.Context, id int64) (*models.Cours
 		return nil, err
 	}
 
-	course.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
-	course.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
+	return course, nil
+}
 
-	return &course, nil
+// GetByName retrieves a course of the owner by its name, compared case-insensitively.
+// Returns nil if the owner has no course with the given name.
+func (r *CourseRepository) GetByName(ctx context.Context, ownerID int64, name string) (*models.Course, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, `
+		SELECT `+courseColumns+`
+		FROM courses
+		WHERE name = ? COLLATE NOCASE AND owner_id = ?
+		ORDER BY id ASC
+		LIMIT 1
+	`, name, ownerID)
+
+	course, err := scanCourse(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return course, nil
 }
 
 // Create persists a new course in the database.
-// It sets the ID field of the course object with the generated ID.
+// It sets the ID field of the course object with the generated ID and its initial version.
 func (r *CourseRepository) Create(ctx context.Context, course *models.Course) error {
-	result, err := r.db.ExecContext(ctx, `
-		INSERT INTO courses (name, professor, created_at, updated_at)
-		VALUES (?, ?, ?, ?)
+	result, err := conn(ctx, r.db).ExecContext(ctx, `
+		INSERT INTO courses (owner_id, name, professor, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?)
 	`,
+		course.OwnerID,
 		course.Name,
 		course.Professor,
-		course.CreatedAt.Format(time.RFC3339),
-		course.UpdatedAt.Format(time.RFC3339),
+		course.CreatedAt.UTC().Format(time.RFC3339),
+		course.UpdatedAt.UTC().Format(time.RFC3339),
 	)
 	if err != nil {
 		return err
@@ -114,28 +118,67 @@ func (r *CourseRepository) Create(ctx context.Context, course *models.Course) er
 	}
 
 	course.ID = id
+	course.Version = 1
 	return nil
 }
 
-// Update modifies an existing course in the database.
-// All fields except CreatedAt can be updated.
+// Update modifies an existing course of its owner in the database.
+// All fields except CreatedAt can be updated, provided the row still has course.Version.
 func (r *CourseRepository) Update(ctx context.Context, course *models.Course) error {
-	_, err := r.db.ExecContext(ctx, `
+	result, err := conn(ctx, r.db).ExecContext(ctx, `
 		UPDATE courses
-		SET name = ?, professor = ?, updated_at = ?
-		WHERE id = ?
+		SET name = ?, professor = ?, updated_at = ?, version = version + 1
+		WHERE id = ? AND owner_id = ? AND version = ?
 	`,
 		course.Name,
 		course.Professor,
-		course.UpdatedAt.Format(time.RFC3339),
+		course.UpdatedAt.UTC().Format(time.RFC3339),
 		course.ID,
+		course.OwnerID,
+		course.Version,
 	)
+	if err != nil {
+		return err
+	}
 
-	return err
+	if err := checkVersionedUpdate(result); err != nil {
+		return err
+	}
+	course.Version++
+	return nil
 }
 
-// Delete removes a course from the database by its ID.
-func (r *CourseRepository) Delete(ctx context.Context, id int64) error {
-	_, err := r.db.ExecContext(ctx, "DELETE FROM courses WHERE id = ?", id)
-	return err
+// Delete removes a course of the owner from the database by its ID.
+// The course is only deleted if its version is still the given one, which makes the check and
+// the deletion a single atomic statement.
+func (r *CourseRepository) Delete(ctx context.Context, ownerID, id, version int64) error {
+	db := conn(ctx, r.db)
+	result, err := db.ExecContext(ctx, "DELETE FROM courses WHERE id = ? AND owner_id = ? AND version = ?", id, ownerID, version)
+	if err != nil {
+		return err
+	}
+	return checkVersionedDelete(ctx, db, result, "courses", id)
+}
+
+// scanCourse maps a single row selected with courseColumns to a domain Course object.
+func scanCourse(row rowScanner) (*models.Course, error) {
+	var course models.Course
+	var createdAt, updatedAt string
+
+	if err := row.Scan(
+		&course.ID,
+		&course.OwnerID,
+		&course.Name,
+		&course.Professor,
+		&course.Version,
+		&createdAt,
+		&updatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	course.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	course.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
+
+	return &course, nil
 }
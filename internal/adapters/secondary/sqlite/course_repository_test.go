@@ -0,0 +1,50 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"uni-task-manager/internal/ports/output"
+)
+
+func TestCourseRepositoryDeleteChecksVersion(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		id          int64
+		version     int64
+		wantErr     error
+		wantCourses int
+	}{
+		{"current version deletes the course", 1, 3, nil, 0},
+		{"stale version keeps the course", 1, 2, output.ErrVersionConflict, 1},
+		{"missing course isn't a conflict", 9, 1, nil, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := openMigratedTestDB(t)
+			if _, err := db.Exec(`
+				INSERT INTO courses (id, owner_id, name, professor, version, created_at, updated_at)
+				VALUES (1, 1, 'Algorithms', 'Knuth', 3, '2030-01-01T00:00:00Z', '2030-01-01T00:00:00Z');
+			`); err != nil {
+				t.Fatal(err)
+			}
+
+			err := NewCourseRepository(db).Delete(ctx, 1, tt.id, tt.version)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Delete() error = %v, want %v", err, tt.wantErr)
+			}
+
+			var courses int
+			if err := db.QueryRow("SELECT COUNT(*) FROM courses").Scan(&courses); err != nil {
+				t.Fatal(err)
+			}
+			if courses != tt.wantCourses {
+				t.Errorf("got %d courses after Delete(), want %d", courses, tt.wantCourses)
+			}
+		})
+	}
+}
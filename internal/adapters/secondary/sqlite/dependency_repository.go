@@ -0,0 +1,145 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"uni-task-manager/internal/domain/models"
+)
+
+// DependencyRepository implements output.DependencyRepository interface using SQLite as the storage backend.
+// Dependencies live in the task_dependencies table; ownership is checked through the tasks they link.
+type DependencyRepository struct {
+	db *sql.DB
+}
+
+// NewDependencyRepository creates a new instance of DependencyRepository with the provided database connection.
+func NewDependencyRepository(db *sql.DB) *DependencyRepository {
+	return &DependencyRepository{db: db}
+}
+
+// GetAll retrieves the whole dependency graph of the owner in a single query,
+// as the prerequisite IDs of every task keyed by task ID.
+func (r *DependencyRepository) GetAll(ctx context.Context, ownerID int64) (map[int64][]int64, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT d.task_id, d.depends_on_id
+		FROM task_dependencies d
+		JOIN tasks t ON t.id = d.task_id
+		WHERE t.owner_id = ?
+		ORDER BY d.task_id ASC, d.depends_on_id ASC
+	`, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	graph := make(map[int64][]int64)
+	for rows.Next() {
+		var taskID, dependsOnID int64
+		if err := rows.Scan(&taskID, &dependsOnID); err != nil {
+			return nil, err
+		}
+		graph[taskID] = append(graph[taskID], dependsOnID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return graph, nil
+}
+
+// GetPrerequisites retrieves the tasks a specific task depends on.
+// Tasks are ordered by due date.
+func (r *DependencyRepository) GetPrerequisites(ctx context.Context, ownerID, taskID int64) ([]models.Task, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT `+qualifiedColumns("t", taskColumns)+`
+		FROM tasks t
+		JOIN task_dependencies d ON d.depends_on_id = t.id
+		WHERE d.task_id = ? AND t.owner_id = ?
+		ORDER BY t.due_date ASC, t.id ASC
+	`, taskID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	return scanTasks(rows)
+}
+
+// GetDependents retrieves the tasks depending on a specific task.
+// Tasks are ordered by due date.
+func (r *DependencyRepository) GetDependents(ctx context.Context, ownerID, taskID int64) ([]models.Task, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT `+qualifiedColumns("t", taskColumns)+`
+		FROM tasks t
+		JOIN task_dependencies d ON d.task_id = t.id
+		WHERE d.depends_on_id = ? AND t.owner_id = ?
+		ORDER BY t.due_date ASC, t.id ASC
+	`, taskID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	return scanTasks(rows)
+}
+
+// CountOpenPrerequisites counts the prerequisites that aren't completed of each of the given tasks
+// that isn't completed either. Tasks without open prerequisites are left out of the result.
+func (r *DependencyRepository) CountOpenPrerequisites(ctx context.Context, ownerID int64, taskIDs []int64) (map[int64]int, error) {
+	counts := make(map[int64]int)
+	if len(taskIDs) == 0 {
+		return counts, nil
+	}
+
+	args := []interface{}{ownerID}
+	for _, id := range taskIDs {
+		args = append(args, id)
+	}
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT d.task_id, COUNT(*)
+		FROM task_dependencies d
+		JOIN tasks t ON t.id = d.task_id
+		JOIN tasks p ON p.id = d.depends_on_id
+		WHERE t.owner_id = ? AND d.task_id IN (`+placeholders(len(taskIDs))+`)
+			AND t.status <> 'completed' AND p.status <> 'completed'
+		GROUP BY d.task_id
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var taskID int64
+		var count int
+		if err := rows.Scan(&taskID, &count); err != nil {
+			return nil, err
+		}
+		counts[taskID] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+// Add persists a dependency of a task on another task in the database.
+// Adding a dependency that already exists keeps the original one.
+func (r *DependencyRepository) Add(ctx context.Context, taskID, dependsOnID int64, createdAt time.Time) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `
+		INSERT OR IGNORE INTO task_dependencies (task_id, depends_on_id, created_at)
+		VALUES (?, ?, ?)
+	`, taskID, dependsOnID, createdAt.UTC().Format(time.RFC3339))
+	return err
+}
+
+// Remove deletes the dependency of a task of the owner on another task from the database.
+func (r *DependencyRepository) Remove(ctx context.Context, ownerID, taskID, dependsOnID int64) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `
+		DELETE FROM task_dependencies
+		WHERE task_id = ? AND depends_on_id = ?
+			AND task_id IN (SELECT id FROM tasks WHERE owner_id = ?)
+	`, taskID, dependsOnID, ownerID)
+	return err
+}
@@ -0,0 +1,55 @@
+package sqlite
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestDependencyRepositoryCountOpenPrerequisites(t *testing.T) {
+	ctx := context.Background()
+	db := openMigratedTestDB(t)
+	if _, err := db.Exec(`
+		INSERT INTO tasks (id, owner_id, title, description, due_date, priority, status, created_at, updated_at) VALUES
+			(1, 1, 'Read', '', '2030-01-01T09:00:00Z', 3, 'completed', '2030-01-01T00:00:00Z', '2030-01-01T00:00:00Z'),
+			(2, 1, 'Outline', '', '2030-01-02T09:00:00Z', 3, 'pending', '2030-01-01T00:00:00Z', '2030-01-01T00:00:00Z'),
+			(3, 1, 'Draft', '', '2030-01-03T09:00:00Z', 3, 'pending', '2030-01-01T00:00:00Z', '2030-01-01T00:00:00Z'),
+			(4, 1, 'Review', '', '2030-01-04T09:00:00Z', 3, 'pending', '2030-01-01T00:00:00Z', '2030-01-01T00:00:00Z'),
+			(5, 1, 'Submit', '', '2030-01-05T09:00:00Z', 3, 'completed', '2030-01-01T00:00:00Z', '2030-01-01T00:00:00Z');
+		INSERT INTO task_dependencies (task_id, depends_on_id, created_at) VALUES
+			(2, 1, '2030-01-01T00:00:00Z'),
+			(3, 1, '2030-01-01T00:00:00Z'),
+			(3, 2, '2030-01-01T00:00:00Z'),
+			(4, 2, '2030-01-01T00:00:00Z'),
+			(4, 3, '2030-01-01T00:00:00Z'),
+			(5, 4, '2030-01-01T00:00:00Z');
+	`); err != nil {
+		t.Fatal(err)
+	}
+	repo := NewDependencyRepository(db)
+
+	tests := []struct {
+		name    string
+		ownerID int64
+		taskIDs []int64
+		want    map[int64]int
+	}{
+		{"only the given tasks", 1, []int64{3}, map[int64]int{3: 1}},
+		{"completed prerequisites don't count", 1, []int64{2, 3, 4}, map[int64]int{3: 1, 4: 2}},
+		{"completed tasks aren't blocked", 1, []int64{1, 5}, map[int64]int{}},
+		{"tasks of another owner are left out", 2, []int64{3, 4}, map[int64]int{}},
+		{"no tasks", 1, nil, map[int64]int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.CountOpenPrerequisites(ctx, tt.ownerID, tt.taskIDs)
+			if err != nil {
+				t.Fatalf("CountOpenPrerequisites() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CountOpenPrerequisites() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+-- Initial schema. Uses IF NOT EXISTS so databases created before the
+-- migration runner existed are adopted without changes.
+CREATE TABLE IF NOT EXISTS courses (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	name TEXT NOT NULL,
+	professor TEXT NOT NULL,
+	created_at DATETIME NOT NULL,
+	updated_at DATETIME NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS tasks (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	title TEXT NOT NULL,
+	description TEXT,
+	due_date DATETIME NOT NULL,
+	priority INTEGER NOT NULL CHECK (priority BETWEEN 1 AND 5),
+	status TEXT NOT NULL CHECK (status IN ('pending', 'in_progress', 'completed')),
+	course_id INTEGER,
+	created_at DATETIME NOT NULL,
+	updated_at DATETIME NOT NULL,
+	FOREIGN KEY (course_id) REFERENCES courses(id)
+);
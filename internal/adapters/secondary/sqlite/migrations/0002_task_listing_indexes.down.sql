@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_tasks_status;
+DROP INDEX IF EXISTS idx_tasks_course_id;
+DROP INDEX IF EXISTS idx_tasks_due_date;
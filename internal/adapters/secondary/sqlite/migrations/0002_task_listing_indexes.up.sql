@@ -0,0 +1,4 @@
+-- Indexes backing the filtered and sorted task listings of GET /api/tasks.
+CREATE INDEX IF NOT EXISTS idx_tasks_due_date ON tasks (due_date, id);
+CREATE INDEX IF NOT EXISTS idx_tasks_course_id ON tasks (course_id);
+CREATE INDEX IF NOT EXISTS idx_tasks_status ON tasks (status);
@@ -0,0 +1,3 @@
+DELETE FROM tasks WHERE parent_id IS NOT NULL;
+DROP INDEX IF EXISTS idx_tasks_parent_id;
+ALTER TABLE tasks DROP COLUMN parent_id;
@@ -0,0 +1,3 @@
+-- Subtasks reference their parent task; top-level tasks keep parent_id NULL.
+ALTER TABLE tasks ADD COLUMN parent_id INTEGER;
+CREATE INDEX idx_tasks_parent_id ON tasks (parent_id);
@@ -0,0 +1,15 @@
+-- Recurrence rules; every occurrence of a series references its rule.
+CREATE TABLE task_recurrences (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	frequency TEXT NOT NULL CHECK (frequency IN ('daily', 'weekly', 'monthly')),
+	interval INTEGER NOT NULL DEFAULT 1 CHECK (interval >= 1),
+	by_weekday TEXT NOT NULL DEFAULT '',
+	until DATETIME,
+	count INTEGER NOT NULL DEFAULT 0,
+	exdates TEXT NOT NULL DEFAULT '',
+	created_at DATETIME NOT NULL,
+	updated_at DATETIME NOT NULL
+);
+
+ALTER TABLE tasks ADD COLUMN recurrence_id INTEGER;
+CREATE INDEX idx_tasks_recurrence_id ON tasks (recurrence_id);
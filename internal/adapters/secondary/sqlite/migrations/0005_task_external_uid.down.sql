@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_tasks_external_uid;
+ALTER TABLE tasks DROP COLUMN external_uid;
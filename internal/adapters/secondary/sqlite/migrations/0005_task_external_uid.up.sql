@@ -0,0 +1,3 @@
+-- Calendar UID of imported tasks, so re-importing a calendar updates them in place.
+ALTER TABLE tasks ADD COLUMN external_uid TEXT;
+CREATE UNIQUE INDEX idx_tasks_external_uid ON tasks (external_uid) WHERE external_uid IS NOT NULL;
@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS idx_tasks_external_uid;
+CREATE UNIQUE INDEX idx_tasks_external_uid ON tasks (external_uid) WHERE external_uid IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_tasks_owner_id;
+DROP INDEX IF EXISTS idx_courses_owner_id;
+
+ALTER TABLE task_recurrences DROP COLUMN owner_id;
+ALTER TABLE tasks DROP COLUMN owner_id;
+ALTER TABLE courses DROP COLUMN owner_id;
+
+DROP TABLE IF EXISTS sessions;
+DROP TABLE IF EXISTS users;
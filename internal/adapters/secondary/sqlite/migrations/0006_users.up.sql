@@ -0,0 +1,28 @@
+-- User accounts and browser sessions. Existing courses, tasks and recurrence
+-- rules keep a NULL owner until the first user registers and claims them.
+CREATE TABLE users (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	email TEXT NOT NULL UNIQUE COLLATE NOCASE,
+	name TEXT NOT NULL,
+	password_hash TEXT NOT NULL,
+	created_at DATETIME NOT NULL,
+	updated_at DATETIME NOT NULL
+);
+
+CREATE TABLE sessions (
+	token_hash TEXT PRIMARY KEY,
+	user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	expires_at DATETIME NOT NULL,
+	created_at DATETIME NOT NULL
+);
+CREATE INDEX idx_sessions_user_id ON sessions (user_id);
+
+ALTER TABLE courses ADD COLUMN owner_id INTEGER;
+ALTER TABLE tasks ADD COLUMN owner_id INTEGER;
+ALTER TABLE task_recurrences ADD COLUMN owner_id INTEGER;
+
+CREATE INDEX idx_courses_owner_id ON courses (owner_id, name);
+CREATE INDEX idx_tasks_owner_id ON tasks (owner_id, due_date);
+
+DROP INDEX idx_tasks_external_uid;
+CREATE UNIQUE INDEX idx_tasks_external_uid ON tasks (owner_id, external_uid) WHERE external_uid IS NOT NULL;
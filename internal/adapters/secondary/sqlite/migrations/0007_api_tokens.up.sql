@@ -0,0 +1,13 @@
+-- Personal API tokens. Only the SHA-256 hash of a token is stored; scopes are
+-- kept as a space-separated list.
+CREATE TABLE api_tokens (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	name TEXT NOT NULL,
+	token_hash TEXT NOT NULL UNIQUE,
+	scopes TEXT NOT NULL,
+	last_used_at DATETIME,
+	expires_at DATETIME,
+	created_at DATETIME NOT NULL
+);
+CREATE INDEX idx_api_tokens_user_id ON api_tokens (user_id);
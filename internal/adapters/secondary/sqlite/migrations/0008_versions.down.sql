@@ -0,0 +1,2 @@
+ALTER TABLE courses DROP COLUMN version;
+ALTER TABLE tasks DROP COLUMN version;
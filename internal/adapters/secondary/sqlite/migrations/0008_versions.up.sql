@@ -0,0 +1,4 @@
+-- Row versions for optimistic concurrency: every update increments the version and only
+-- succeeds if the row still has the version the writer read.
+ALTER TABLE tasks ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE courses ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,35 @@
+-- Restores the previous tasks table, where a task without a course stores 0. The course
+-- reference is left without a foreign key, since 0 never matches a course.
+CREATE TABLE tasks_old (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	title TEXT NOT NULL,
+	description TEXT,
+	due_date DATETIME NOT NULL,
+	priority INTEGER NOT NULL CHECK (priority BETWEEN 1 AND 5),
+	status TEXT NOT NULL CHECK (status IN ('pending', 'in_progress', 'completed')),
+	course_id INTEGER,
+	created_at DATETIME NOT NULL,
+	updated_at DATETIME NOT NULL,
+	parent_id INTEGER,
+	recurrence_id INTEGER,
+	external_uid TEXT,
+	owner_id INTEGER,
+	version INTEGER NOT NULL DEFAULT 1
+);
+
+INSERT INTO tasks_old (id, title, description, due_date, priority, status, course_id, created_at, updated_at,
+	parent_id, recurrence_id, external_uid, owner_id, version)
+SELECT id, title, description, due_date, priority, status, COALESCE(course_id, 0), created_at, updated_at,
+	parent_id, recurrence_id, external_uid, owner_id, version
+FROM tasks;
+
+DROP TABLE tasks;
+ALTER TABLE tasks_old RENAME TO tasks;
+
+CREATE INDEX idx_tasks_due_date ON tasks (due_date, id);
+CREATE INDEX idx_tasks_course_id ON tasks (course_id);
+CREATE INDEX idx_tasks_status ON tasks (status);
+CREATE INDEX idx_tasks_parent_id ON tasks (parent_id);
+CREATE INDEX idx_tasks_recurrence_id ON tasks (recurrence_id);
+CREATE INDEX idx_tasks_owner_id ON tasks (owner_id, due_date);
+CREATE UNIQUE INDEX idx_tasks_external_uid ON tasks (owner_id, external_uid) WHERE external_uid IS NOT NULL;
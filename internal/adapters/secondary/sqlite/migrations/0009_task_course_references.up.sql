@@ -0,0 +1,38 @@
+-- Tasks without a course store NULL instead of 0, and every course reference must point to an
+-- existing course. SQLite cannot alter a column, so the tasks table is rebuilt; references to
+-- missing courses are cleared on the way. Foreign keys are suspended by the migrator while this
+-- runs and checked before it commits.
+CREATE TABLE tasks_new (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	title TEXT NOT NULL,
+	description TEXT,
+	due_date DATETIME NOT NULL,
+	priority INTEGER NOT NULL CHECK (priority BETWEEN 1 AND 5),
+	status TEXT NOT NULL CHECK (status IN ('pending', 'in_progress', 'completed')),
+	course_id INTEGER REFERENCES courses(id),
+	created_at DATETIME NOT NULL,
+	updated_at DATETIME NOT NULL,
+	parent_id INTEGER,
+	recurrence_id INTEGER,
+	external_uid TEXT,
+	owner_id INTEGER,
+	version INTEGER NOT NULL DEFAULT 1
+);
+
+INSERT INTO tasks_new (id, title, description, due_date, priority, status, course_id, created_at, updated_at,
+	parent_id, recurrence_id, external_uid, owner_id, version)
+SELECT id, title, description, due_date, priority, status,
+	CASE WHEN course_id IN (SELECT id FROM courses) THEN course_id END,
+	created_at, updated_at, parent_id, recurrence_id, external_uid, owner_id, version
+FROM tasks;
+
+DROP TABLE tasks;
+ALTER TABLE tasks_new RENAME TO tasks;
+
+CREATE INDEX idx_tasks_due_date ON tasks (due_date, id);
+CREATE INDEX idx_tasks_course_id ON tasks (course_id);
+CREATE INDEX idx_tasks_status ON tasks (status);
+CREATE INDEX idx_tasks_parent_id ON tasks (parent_id);
+CREATE INDEX idx_tasks_recurrence_id ON tasks (recurrence_id);
+CREATE INDEX idx_tasks_owner_id ON tasks (owner_id, due_date);
+CREATE UNIQUE INDEX idx_tasks_external_uid ON tasks (owner_id, external_uid) WHERE external_uid IS NOT NULL;
@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS courses_fts_after_rename;
+DROP TRIGGER IF EXISTS tasks_fts_after_delete;
+DROP TRIGGER IF EXISTS tasks_fts_after_update;
+DROP TRIGGER IF EXISTS tasks_fts_after_insert;
+DROP TABLE IF EXISTS tasks_fts;
@@ -0,0 +1,44 @@
+-- Full-text index of tasks. Each row shares its rowid with the task it indexes and also holds
+-- the name of the task's course; tags are filled in once tasks can be tagged. Triggers keep the
+-- index in sync with tasks and courses.
+CREATE VIRTUAL TABLE tasks_fts USING fts5(
+	title,
+	description,
+	course_name,
+	tags,
+	tokenize = 'unicode61 remove_diacritics 2'
+);
+
+INSERT INTO tasks_fts (rowid, title, description, course_name, tags)
+SELECT t.id, t.title, COALESCE(t.description, ''), COALESCE(c.name, ''), ''
+FROM tasks t
+LEFT JOIN courses c ON c.id = t.course_id;
+
+CREATE TRIGGER tasks_fts_after_insert AFTER INSERT ON tasks BEGIN
+	INSERT INTO tasks_fts (rowid, title, description, course_name, tags)
+	VALUES (
+		new.id,
+		new.title,
+		COALESCE(new.description, ''),
+		COALESCE((SELECT name FROM courses WHERE id = new.course_id), ''),
+		''
+	);
+END;
+
+CREATE TRIGGER tasks_fts_after_update AFTER UPDATE OF title, description, course_id ON tasks BEGIN
+	UPDATE tasks_fts
+	SET title = new.title,
+		description = COALESCE(new.description, ''),
+		course_name = COALESCE((SELECT name FROM courses WHERE id = new.course_id), '')
+	WHERE rowid = new.id;
+END;
+
+CREATE TRIGGER tasks_fts_after_delete AFTER DELETE ON tasks BEGIN
+	DELETE FROM tasks_fts WHERE rowid = old.id;
+END;
+
+CREATE TRIGGER courses_fts_after_rename AFTER UPDATE OF name ON courses BEGIN
+	UPDATE tasks_fts
+	SET course_name = new.name
+	WHERE rowid IN (SELECT id FROM tasks WHERE course_id = new.id);
+END;
@@ -0,0 +1,8 @@
+DROP TRIGGER IF EXISTS tags_fts_after_rename;
+DROP TRIGGER IF EXISTS task_tags_fts_after_delete;
+DROP TRIGGER IF EXISTS task_tags_fts_after_insert;
+
+UPDATE tasks_fts SET tags = '';
+
+DROP TABLE IF EXISTS task_tags;
+DROP TABLE IF EXISTS tags;
@@ -0,0 +1,47 @@
+-- Tags label tasks across courses ("exam", "reading", ...). Tag names are unique per owner,
+-- ignoring case, and a task can carry any number of tags through task_tags.
+CREATE TABLE tags (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	owner_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	name TEXT NOT NULL COLLATE NOCASE,
+	created_at DATETIME NOT NULL,
+	UNIQUE (owner_id, name)
+);
+
+CREATE TABLE task_tags (
+	task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+	tag_id INTEGER NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+	PRIMARY KEY (task_id, tag_id)
+);
+CREATE INDEX idx_task_tags_tag_id ON task_tags (tag_id);
+
+-- Keep the tags column of the full-text index in sync with the tags of each task
+CREATE TRIGGER task_tags_fts_after_insert AFTER INSERT ON task_tags BEGIN
+	UPDATE tasks_fts
+	SET tags = COALESCE((
+		SELECT group_concat(g.name, ' ')
+		FROM task_tags tt JOIN tags g ON g.id = tt.tag_id
+		WHERE tt.task_id = new.task_id
+	), '')
+	WHERE rowid = new.task_id;
+END;
+
+CREATE TRIGGER task_tags_fts_after_delete AFTER DELETE ON task_tags BEGIN
+	UPDATE tasks_fts
+	SET tags = COALESCE((
+		SELECT group_concat(g.name, ' ')
+		FROM task_tags tt JOIN tags g ON g.id = tt.tag_id
+		WHERE tt.task_id = old.task_id
+	), '')
+	WHERE rowid = old.task_id;
+END;
+
+CREATE TRIGGER tags_fts_after_rename AFTER UPDATE OF name ON tags BEGIN
+	UPDATE tasks_fts
+	SET tags = COALESCE((
+		SELECT group_concat(g.name, ' ')
+		FROM task_tags tt JOIN tags g ON g.id = tt.tag_id
+		WHERE tt.task_id = tasks_fts.rowid
+	), '')
+	WHERE rowid IN (SELECT task_id FROM task_tags WHERE tag_id = new.id);
+END;
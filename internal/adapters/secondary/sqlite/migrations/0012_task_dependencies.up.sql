@@ -0,0 +1,10 @@
+-- A dependency records that a task cannot be completed before its prerequisite is. Both tasks
+-- belong to the same owner; the task service keeps the dependency graph free of cycles.
+CREATE TABLE task_dependencies (
+	task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+	depends_on_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+	created_at DATETIME NOT NULL,
+	PRIMARY KEY (task_id, depends_on_id),
+	CHECK (task_id <> depends_on_id)
+);
+CREATE INDEX idx_task_dependencies_depends_on_id ON task_dependencies (depends_on_id);
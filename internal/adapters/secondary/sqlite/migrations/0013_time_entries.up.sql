@@ -0,0 +1,14 @@
+-- Time entries log the time spent on a task. A running timer is an entry without an end;
+-- each user has at most one running timer.
+CREATE TABLE time_entries (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	owner_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+	started_at DATETIME NOT NULL,
+	ended_at DATETIME,
+	note TEXT NOT NULL DEFAULT '',
+	created_at DATETIME NOT NULL,
+	CHECK (ended_at IS NULL OR ended_at >= started_at)
+);
+CREATE INDEX idx_time_entries_task_id ON time_entries (task_id);
+CREATE UNIQUE INDEX idx_time_entries_running ON time_entries (owner_id) WHERE ended_at IS NULL;
@@ -0,0 +1,2 @@
+-- Estimated effort of a task in hours; 0 means the task has no estimate.
+ALTER TABLE tasks ADD COLUMN estimated_hours REAL NOT NULL DEFAULT 0 CHECK (estimated_hours >= 0);
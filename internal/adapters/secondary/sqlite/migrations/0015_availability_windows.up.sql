@@ -0,0 +1,12 @@
+-- Availability windows are the weekly time slots (UTC) a user is free to study, used by the planner.
+-- Start and end are minutes since midnight.
+CREATE TABLE availability_windows (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	owner_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	weekday INTEGER NOT NULL CHECK (weekday BETWEEN 0 AND 6),
+	start_minute INTEGER NOT NULL,
+	end_minute INTEGER NOT NULL,
+	created_at DATETIME NOT NULL,
+	CHECK (start_minute >= 0 AND start_minute < end_minute AND end_minute <= 1440)
+);
+CREATE INDEX idx_availability_windows_owner_id ON availability_windows (owner_id, weekday);
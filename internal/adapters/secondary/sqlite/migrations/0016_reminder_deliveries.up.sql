@@ -0,0 +1,16 @@
+-- Reminder deliveries record the reminders sent, or being retried, on every channel, so that
+-- restarts neither re-send nor drop them. A reminder is identified by its task, rule and the
+-- time it was scheduled for, which moves with the due date of the task.
+CREATE TABLE reminder_deliveries (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	owner_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+	rule TEXT NOT NULL,
+	scheduled_at DATETIME NOT NULL,
+	channel TEXT NOT NULL,
+	status TEXT NOT NULL CHECK (status IN ('sent', 'failed')),
+	attempts INTEGER NOT NULL DEFAULT 0,
+	last_error TEXT NOT NULL DEFAULT '',
+	updated_at DATETIME NOT NULL,
+	UNIQUE (task_id, rule, scheduled_at, channel)
+);
@@ -0,0 +1,14 @@
+-- The outbox records every task and course event in the same transaction as the change it
+-- reports; the dispatcher hands the events to their subscribers and removes them.
+CREATE TABLE outbox (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	event_id TEXT NOT NULL UNIQUE,
+	event_type TEXT NOT NULL,
+	owner_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	payload TEXT NOT NULL,
+	attempts INTEGER NOT NULL DEFAULT 0,
+	next_attempt_at DATETIME NOT NULL,
+	last_error TEXT NOT NULL DEFAULT '',
+	created_at DATETIME NOT NULL
+);
+CREATE INDEX idx_outbox_next_attempt_at ON outbox (next_attempt_at, id);
@@ -0,0 +1,15 @@
+-- Task history records every change of a task with its field-level diff and the state of the
+-- task before the change, as JSON. Entries are kept when their task is deleted, so task_id has
+-- no foreign key.
+CREATE TABLE task_history (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	task_id INTEGER NOT NULL,
+	owner_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+	actor_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+	action TEXT NOT NULL CHECK (action IN ('updated', 'restored', 'deleted')),
+	version INTEGER NOT NULL,
+	changes TEXT NOT NULL,
+	previous TEXT NOT NULL,
+	created_at DATETIME NOT NULL
+);
+CREATE INDEX idx_task_history_task_id ON task_history (owner_id, task_id, id);
@@ -0,0 +1,2 @@
+-- The original offsets are not kept; UTC times read the same, so nothing is reverted.
+SELECT 1;
@@ -0,0 +1,19 @@
+-- Databases created before the migration runner stored the times of tasks and courses with the
+-- local UTC offset of the server (e.g. 2024-10-01T23:59:00+02:00), while every write since
+-- stores UTC. Listings compare and page through these columns as text, which only orders
+-- correctly in a single offset, so the remaining local times are rewritten in UTC. Values
+-- SQLite cannot read as a time are left untouched.
+UPDATE tasks SET due_date = strftime('%Y-%m-%dT%H:%M:%SZ', due_date)
+WHERE due_date NOT LIKE '%Z' AND strftime('%Y-%m-%dT%H:%M:%SZ', due_date) IS NOT NULL;
+
+UPDATE tasks SET created_at = strftime('%Y-%m-%dT%H:%M:%SZ', created_at)
+WHERE created_at NOT LIKE '%Z' AND strftime('%Y-%m-%dT%H:%M:%SZ', created_at) IS NOT NULL;
+
+UPDATE tasks SET updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', updated_at)
+WHERE updated_at NOT LIKE '%Z' AND strftime('%Y-%m-%dT%H:%M:%SZ', updated_at) IS NOT NULL;
+
+UPDATE courses SET created_at = strftime('%Y-%m-%dT%H:%M:%SZ', created_at)
+WHERE created_at NOT LIKE '%Z' AND strftime('%Y-%m-%dT%H:%M:%SZ', created_at) IS NOT NULL;
+
+UPDATE courses SET updated_at = strftime('%Y-%m-%dT%H:%M:%SZ', updated_at)
+WHERE updated_at NOT LIKE '%Z' AND strftime('%Y-%m-%dT%H:%M:%SZ', updated_at) IS NOT NULL;
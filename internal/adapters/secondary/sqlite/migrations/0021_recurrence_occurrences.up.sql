@@ -0,0 +1,6 @@
+-- Number of occurrences a series has generated, including the ones deleted since; a rule with a
+-- count stops once it is reached. Existing series start from the occurrences they still hold.
+ALTER TABLE task_recurrences ADD COLUMN occurrences INTEGER NOT NULL DEFAULT 0;
+
+UPDATE task_recurrences
+SET occurrences = (SELECT COUNT(*) FROM tasks WHERE tasks.recurrence_id = task_recurrences.id);
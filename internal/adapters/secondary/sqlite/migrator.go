@@ -0,0 +1,448 @@
+// Package sqlite provides implementations of the repository interfaces using SQLite as the storage backend.
+package sqlite
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// migrationFiles holds the versioned schema scripts, named <version>_<name>.<up|down>.sql
+//
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// Errors returned by the Migrator when the database and the embedded migrations disagree
+var (
+	// ErrChecksumMismatch indicates that an applied migration was modified after it ran
+	ErrChecksumMismatch = errors.New("applied migration does not match its embedded script")
+
+	// ErrUnknownMigration indicates that the database contains a migration this binary doesn't know
+	ErrUnknownMigration = errors.New("database contains an unknown migration")
+
+	// ErrNoDownMigration indicates that a migration cannot be reverted
+	ErrNoDownMigration = errors.New("migration has no down script")
+
+	// ErrForeignKeyViolation indicates that a migration would leave rows referencing missing records
+	ErrForeignKeyViolation = errors.New("migration violates a foreign key")
+)
+
+// Migration is a single versioned schema change with its forward and backward scripts.
+type Migration struct {
+	// Version orders the migrations; it is the numeric prefix of the file name
+	Version int
+
+	// Name is the descriptive part of the file name
+	Name string
+
+	// Up is the SQL script applying the change
+	Up string
+
+	// Down is the SQL script reverting the change (may be empty)
+	Down string
+
+	// Checksum is the SHA-256 of the Up script, recorded when the migration is applied
+	Checksum string
+}
+
+// MigrationState describes how an embedded migration relates to the database
+type MigrationState string
+
+// Migration state constants reported by Migrator.Status
+const (
+	// MigrationPending indicates a migration that hasn't been applied yet
+	MigrationPending MigrationState = "pending"
+
+	// MigrationApplied indicates a migration applied with a matching checksum
+	MigrationApplied MigrationState = "applied"
+
+	// MigrationModified indicates a migration whose script changed after it was applied
+	MigrationModified MigrationState = "modified"
+
+	// MigrationUnknown indicates a migration recorded in the database but missing from the binary
+	MigrationUnknown MigrationState = "unknown"
+)
+
+// MigrationStatus reports the state of one migration.
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	State     MigrationState
+	AppliedAt time.Time
+}
+
+// appliedMigration is a row of the schema_migrations table
+type appliedMigration struct {
+	version   int
+	name      string
+	checksum  string
+	appliedAt time.Time
+}
+
+// Migrator applies and reverts the embedded schema migrations, tracking them in schema_migrations.
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+// NewMigrator creates a new instance of Migrator loaded with the embedded migrations.
+func NewMigrator(db *sql.DB) (*Migrator, error) {
+	migrations, err := loadMigrations(migrationFiles)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Up applies every pending migration in version order, each in its own transaction.
+// It refuses to run if an applied migration was modified or is unknown to this binary.
+// Returns the migrations that were applied.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	applied, err := m.verify(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+		if err := m.apply(ctx, migration); err != nil {
+			return done, err
+		}
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+// Down reverts the given number of most recently applied migrations, newest first.
+// Returns the migrations that were reverted.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	applied, err := m.verify(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+		migration := m.migrations[i]
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+		if migration.Down == "" {
+			return done, fmt.Errorf("%w: %04d_%s", ErrNoDownMigration, migration.Version, migration.Name)
+		}
+		if err := m.revert(ctx, migration); err != nil {
+			return done, err
+		}
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+// Status reports the state of every embedded migration, followed by any unknown applied ones.
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[int]bool, len(m.migrations))
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		known[migration.Version] = true
+		status := MigrationStatus{Version: migration.Version, Name: migration.Name, State: MigrationPending}
+		if row, ok := applied[migration.Version]; ok {
+			status.State = MigrationApplied
+			status.AppliedAt = row.appliedAt
+			if row.checksum != migration.Checksum {
+				status.State = MigrationModified
+			}
+		}
+		statuses = append(statuses, status)
+	}
+
+	for _, row := range applied {
+		if !known[row.version] {
+			statuses = append(statuses, MigrationStatus{
+				Version:   row.version,
+				Name:      row.name,
+				State:     MigrationUnknown,
+				AppliedAt: row.appliedAt,
+			})
+		}
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+
+	return statuses, nil
+}
+
+// verify loads the applied migrations and checks them against the embedded ones.
+func (m *Migrator) verify(ctx context.Context) (map[int]appliedMigration, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]Migration, len(m.migrations))
+	for _, migration := range m.migrations {
+		byVersion[migration.Version] = migration
+	}
+
+	for _, row := range applied {
+		migration, ok := byVersion[row.version]
+		if !ok {
+			return nil, fmt.Errorf("%w: %04d_%s", ErrUnknownMigration, row.version, row.name)
+		}
+		if row.checksum != migration.Checksum {
+			return nil, fmt.Errorf("%w: %04d_%s", ErrChecksumMismatch, migration.Version, migration.Name)
+		}
+	}
+
+	return applied, nil
+}
+
+// applied ensures the schema_migrations table exists and returns its rows keyed by version.
+func (m *Migrator) applied(ctx context.Context) (map[int]appliedMigration, error) {
+	_, err := m.db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			name TEXT NOT NULL,
+			checksum TEXT NOT NULL,
+			applied_at DATETIME NOT NULL
+		)
+	`)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := m.db.QueryContext(ctx, "SELECT version, name, checksum, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := make(map[int]appliedMigration)
+	for rows.Next() {
+		var row appliedMigration
+		var appliedAt string
+		if err := rows.Scan(&row.version, &row.name, &row.checksum, &appliedAt); err != nil {
+			return nil, err
+		}
+		row.appliedAt, _ = time.Parse(time.RFC3339, appliedAt)
+		applied[row.version] = row
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return applied, nil
+}
+
+// apply runs the Up script of a migration and records it, atomically.
+func (m *Migrator) apply(ctx context.Context, migration Migration) error {
+	return m.inTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, migration.Up); err != nil {
+			return fmt.Errorf("applying migration %04d_%s: %w", migration.Version, migration.Name, err)
+		}
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO schema_migrations (version, name, checksum, applied_at)
+			VALUES (?, ?, ?, ?)
+		`, migration.Version, migration.Name, migration.Checksum, time.Now().UTC().Format(time.RFC3339))
+		return err
+	})
+}
+
+// revert runs the Down script of a migration and removes its record, atomically.
+func (m *Migrator) revert(ctx context.Context, migration Migration) error {
+	return m.inTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, migration.Down); err != nil {
+			return fmt.Errorf("reverting migration %04d_%s: %w", migration.Version, migration.Name, err)
+		}
+		_, err := tx.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = ?", migration.Version)
+		return err
+	})
+}
+
+// inTx runs fn inside a transaction, committing on success and rolling back on error.
+// Foreign key enforcement is suspended on the connection while fn runs, since rebuilding a
+// table means dropping it while other rows still reference it; the foreign keys are then
+// checked before committing, so a migration cannot leave dangling references. Violations that
+// already existed before fn ran are not the migration's doing and are ignored: databases
+// created before the migration runner stored 0 for a task without a course, which only
+// 0009_task_course_references clears.
+func (m *Migrator) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// The pragma has no effect inside a transaction, so it is toggled around it
+	var foreignKeys bool
+	if err := conn.QueryRowContext(ctx, "PRAGMA foreign_keys").Scan(&foreignKeys); err != nil {
+		return err
+	}
+	if foreignKeys {
+		if _, err := conn.ExecContext(ctx, "PRAGMA foreign_keys = OFF"); err != nil {
+			return err
+		}
+		defer conn.ExecContext(context.Background(), "PRAGMA foreign_keys = ON")
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	existing, err := foreignKeyViolations(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := checkForeignKeys(ctx, tx, existing); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// foreignKeyViolation is a row of PRAGMA foreign_key_check
+type foreignKeyViolation struct {
+	table      string
+	rowID      int64
+	parent     string
+	constraint int
+}
+
+// foreignKeyViolations returns every row currently violating a foreign key constraint.
+func foreignKeyViolations(ctx context.Context, tx *sql.Tx) (map[foreignKeyViolation]bool, error) {
+	rows, err := tx.QueryContext(ctx, "PRAGMA foreign_key_check")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	violations := make(map[foreignKeyViolation]bool)
+	for rows.Next() {
+		var violation foreignKeyViolation
+		var rowID sql.NullInt64
+		if err := rows.Scan(&violation.table, &rowID, &violation.parent, &violation.constraint); err != nil {
+			return nil, err
+		}
+		violation.rowID = rowID.Int64
+		violations[violation] = true
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return violations, nil
+}
+
+// checkForeignKeys reports the first row violating a foreign key constraint that isn't in existing, if any.
+func checkForeignKeys(ctx context.Context, tx *sql.Tx, existing map[foreignKeyViolation]bool) error {
+	violations, err := foreignKeyViolations(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	var introduced []foreignKeyViolation
+	for violation := range violations {
+		if !existing[violation] {
+			introduced = append(introduced, violation)
+		}
+	}
+	if len(introduced) == 0 {
+		return nil
+	}
+
+	sort.Slice(introduced, func(i, j int) bool {
+		if introduced[i].table != introduced[j].table {
+			return introduced[i].table < introduced[j].table
+		}
+		return introduced[i].rowID < introduced[j].rowID
+	})
+	first := introduced[0]
+	return fmt.Errorf("%w: row %d of %s references a missing %s", ErrForeignKeyViolation, first.rowID, first.table, first.parent)
+}
+
+// loadMigrations reads and pairs the up/down scripts of the given file system, ordered by version.
+func loadMigrations(files fs.FS) ([]Migration, error) {
+	names, err := fs.Glob(files, "migrations/*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, name := range names {
+		base := path.Base(name)
+		direction := ""
+		switch {
+		case strings.HasSuffix(base, ".up.sql"):
+			direction = "up"
+		case strings.HasSuffix(base, ".down.sql"):
+			direction = "down"
+		default:
+			return nil, fmt.Errorf("migration %s must end in .up.sql or .down.sql", base)
+		}
+
+		stem := strings.TrimSuffix(base, "."+direction+".sql")
+		prefix, label, ok := strings.Cut(stem, "_")
+		if !ok {
+			return nil, fmt.Errorf("migration %s must be named <version>_<name>", base)
+		}
+		version, err := strconv.Atoi(prefix)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s has an invalid version: %w", base, err)
+		}
+
+		content, err := fs.ReadFile(files, name)
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: label}
+			byVersion[version] = migration
+		}
+		if migration.Name != label {
+			return nil, fmt.Errorf("migration version %d is used by both %s and %s", version, migration.Name, label)
+		}
+		if direction == "up" {
+			migration.Up = string(content)
+			sum := sha256.Sum256(content)
+			migration.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("migration %04d_%s has no up script", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
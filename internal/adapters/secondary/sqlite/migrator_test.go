@@ -0,0 +1,241 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+// baselineSchema is the schema created by createDatabaseSchema before the migration runner existed
+const baselineSchema = `
+	CREATE TABLE IF NOT EXISTS courses (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL,
+		professor TEXT NOT NULL,
+		created_at DATETIME NOT NULL,
+		updated_at DATETIME NOT NULL
+	);
+	CREATE TABLE IF NOT EXISTS tasks (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		title TEXT NOT NULL,
+		description TEXT,
+		due_date DATETIME NOT NULL,
+		priority INTEGER NOT NULL CHECK (priority BETWEEN 1 AND 5),
+		status TEXT NOT NULL CHECK (status IN ('pending', 'in_progress', 'completed')),
+		course_id INTEGER,
+		created_at DATETIME NOT NULL,
+		updated_at DATETIME NOT NULL,
+		FOREIGN KEY (course_id) REFERENCES courses(id)
+	);
+`
+
+// openTestDB opens a new file database with the same connection settings as the application
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	return openTestDBAt(t, filepath.Join(t.TempDir(), "test.db"))
+}
+
+// openTestDBAt opens the given file database with the same connection settings as the application
+func openTestDBAt(t *testing.T, dbPath string) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", "file:"+dbPath+"?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_txlock=immediate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// openMigratedTestDB opens a new file database with every migration applied and two users, 1 and 2
+func openMigratedTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db := openTestDB(t)
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(`
+		INSERT INTO users (id, email, name, password_hash, created_at, updated_at) VALUES
+			(1, 'ada@example.com', 'Ada', '', '2030-01-01T00:00:00Z', '2030-01-01T00:00:00Z'),
+			(2, 'alan@example.com', 'Alan', '', '2030-01-01T00:00:00Z', '2030-01-01T00:00:00Z')
+	`); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestMigratorUpgradesBaselineDatabase(t *testing.T) {
+	ctx := context.Background()
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+
+	// The baseline opened the database without enforcing foreign keys, saved 0 for a task
+	// without a course and never checked that the course existed
+	baseline, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := baseline.Exec(baselineSchema); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := baseline.Exec(`
+		INSERT INTO courses (id, name, professor, created_at, updated_at)
+		VALUES (1, 'Algorithms', 'Knuth', '2024-09-01T10:00:00+02:00', '2024-09-01T10:00:00+02:00');
+		INSERT INTO tasks (id, title, description, due_date, priority, status, course_id, created_at, updated_at) VALUES
+			(1, 'Essay', '', '2024-10-01T23:59:00+02:00', 3, 'pending', 0, '2024-09-01T10:00:00+02:00', '2024-09-01T10:00:00+02:00'),
+			(2, 'Problem set', '', '2024-10-02T12:00:00-03:00', 2, 'in_progress', 1, '2024-09-01T10:00:00+02:00', '2024-09-01T10:00:00+02:00'),
+			(3, 'Lab report', '', '2024-10-03T08:00:00Z', 5, 'completed', 42, '2024-09-01T10:00:00+02:00', '2024-09-01T10:00:00+02:00');
+	`); err != nil {
+		t.Fatal(err)
+	}
+	baseline.Close()
+
+	db := openTestDBAt(t, dbPath)
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	applied, err := migrator.Up(ctx)
+	if err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+	if len(applied) != len(migrator.migrations) {
+		t.Fatalf("Up() applied %d migrations, want %d", len(applied), len(migrator.migrations))
+	}
+
+	rows, err := db.Query("SELECT id, course_id FROM tasks ORDER BY id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	want := map[int64]sql.NullInt64{
+		1: {},
+		2: {Int64: 1, Valid: true},
+		3: {},
+	}
+	got := make(map[int64]sql.NullInt64)
+	for rows.Next() {
+		var id int64
+		var courseID sql.NullInt64
+		if err := rows.Scan(&id, &courseID); err != nil {
+			t.Fatal(err)
+		}
+		got[id] = courseID
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d tasks after upgrade, want %d", len(got), len(want))
+	}
+	for id, courseID := range want {
+		if got[id] != courseID {
+			t.Errorf("task %d course_id = %v, want %v", id, got[id], courseID)
+		}
+	}
+
+	// Times stored with the local offset are rewritten in UTC, so they compare correctly as text
+	wantDueDates := map[int64]string{
+		1: "2024-10-01T21:59:00Z",
+		2: "2024-10-02T15:00:00Z",
+		3: "2024-10-03T08:00:00Z",
+	}
+	for id, want := range wantDueDates {
+		var dueDate, createdAt string
+		if err := db.QueryRow("SELECT due_date, created_at FROM tasks WHERE id = ?", id).Scan(&dueDate, &createdAt); err != nil {
+			t.Fatal(err)
+		}
+		if dueDate != want {
+			t.Errorf("task %d due_date = %s, want %s", id, dueDate, want)
+		}
+		if createdAt != "2024-09-01T08:00:00Z" {
+			t.Errorf("task %d created_at = %s, want 2024-09-01T08:00:00Z", id, createdAt)
+		}
+	}
+
+	var violations int
+	if err := db.QueryRow("SELECT COUNT(*) FROM pragma_foreign_key_check").Scan(&violations); err != nil {
+		t.Fatal(err)
+	}
+	if violations != 0 {
+		t.Errorf("got %d foreign key violations after upgrade, want 0", violations)
+	}
+
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, status := range statuses {
+		if status.State != MigrationApplied {
+			t.Errorf("migration %04d_%s is %s, want %s", status.Version, status.Name, status.State, MigrationApplied)
+		}
+	}
+}
+
+func TestMigratorDownAndUpAgain(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+
+	reverted, err := migrator.Down(ctx, len(migrator.migrations))
+	if err != nil {
+		t.Fatalf("Down() error = %v", err)
+	}
+	if len(reverted) != len(migrator.migrations) {
+		t.Fatalf("Down() reverted %d migrations, want %d", len(reverted), len(migrator.migrations))
+	}
+
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatalf("Up() after Down() error = %v", err)
+	}
+}
+
+func TestMigratorRejectsIntroducedForeignKeyViolation(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+
+	migrator := &Migrator{db: db, migrations: []Migration{
+		{
+			Version:  1,
+			Name:     "parents",
+			Up:       "CREATE TABLE parents (id INTEGER PRIMARY KEY); CREATE TABLE children (id INTEGER PRIMARY KEY, parent_id INTEGER REFERENCES parents(id));",
+			Checksum: "1",
+		},
+		{
+			Version:  2,
+			Name:     "orphan",
+			Up:       "INSERT INTO children (id, parent_id) VALUES (1, 7);",
+			Checksum: "2",
+		},
+	}}
+
+	applied, err := migrator.Up(ctx)
+	if !errors.Is(err, ErrForeignKeyViolation) {
+		t.Fatalf("Up() error = %v, want %v", err, ErrForeignKeyViolation)
+	}
+	if len(applied) != 1 {
+		t.Fatalf("Up() applied %d migrations, want 1", len(applied))
+	}
+
+	var children int
+	if err := db.QueryRow("SELECT COUNT(*) FROM children").Scan(&children); err != nil {
+		t.Fatal(err)
+	}
+	if children != 0 {
+		t.Errorf("got %d children after the failed migration, want 0", children)
+	}
+}
@@ -0,0 +1,124 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"uni-task-manager/internal/domain/models"
+)
+
+// outboxColumns lists the columns selected for every outbox query, in scanOutboxMessage order
+const outboxColumns = "id, event_id, event_type, owner_id, payload, attempts, next_attempt_at, last_error, created_at"
+
+// OutboxRepository implements output.OutboxRepository interface using SQLite as the storage backend.
+// Messages are added in the transaction of the unit of work running in the context, if any.
+type OutboxRepository struct {
+	db *sql.DB
+}
+
+// NewOutboxRepository creates a new instance of OutboxRepository with the provided database connection.
+func NewOutboxRepository(db *sql.DB) *OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+// Add persists a new message in the outbox.
+// It sets the ID field of the message with the generated ID.
+func (r *OutboxRepository) Add(ctx context.Context, message *models.OutboxMessage) error {
+	result, err := conn(ctx, r.db).ExecContext(ctx, `
+		INSERT INTO outbox (event_id, event_type, owner_id, payload, attempts, next_attempt_at, last_error, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`,
+		message.EventID,
+		string(message.EventType),
+		message.OwnerID,
+		message.Payload,
+		message.Attempts,
+		message.NextAttemptAt.UTC().Format(time.RFC3339),
+		message.LastError,
+		message.CreatedAt.UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	message.ID = id
+	return nil
+}
+
+// GetDue retrieves the messages to dispatch by now, in the order they were added.
+func (r *OutboxRepository) GetDue(ctx context.Context, now time.Time, limit int) ([]models.OutboxMessage, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT `+outboxColumns+`
+		FROM outbox
+		WHERE next_attempt_at <= ?
+		ORDER BY id ASC
+		LIMIT ?
+	`, now.UTC().Format(time.RFC3339), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []models.OutboxMessage
+	for rows.Next() {
+		message, err := scanOutboxMessage(rows)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, *message)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+// Update stores the attempts, next attempt and error of a message whose dispatch failed.
+func (r *OutboxRepository) Update(ctx context.Context, message *models.OutboxMessage) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `
+		UPDATE outbox
+		SET attempts = ?, next_attempt_at = ?, last_error = ?
+		WHERE id = ?
+	`, message.Attempts, message.NextAttemptAt.UTC().Format(time.RFC3339), message.LastError, message.ID)
+	return err
+}
+
+// Delete removes a message from the outbox by its ID.
+func (r *OutboxRepository) Delete(ctx context.Context, id int64) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, "DELETE FROM outbox WHERE id = ?", id)
+	return err
+}
+
+// scanOutboxMessage maps a single row selected with outboxColumns to a domain OutboxMessage object.
+func scanOutboxMessage(row rowScanner) (*models.OutboxMessage, error) {
+	var message models.OutboxMessage
+	var eventType, nextAttemptAt, createdAt string
+
+	if err := row.Scan(
+		&message.ID,
+		&message.EventID,
+		&eventType,
+		&message.OwnerID,
+		&message.Payload,
+		&message.Attempts,
+		&nextAttemptAt,
+		&message.LastError,
+		&createdAt,
+	); err != nil {
+		return nil, err
+	}
+
+	message.EventType = models.EventType(eventType)
+	message.NextAttemptAt, _ = time.Parse(time.RFC3339, nextAttemptAt)
+	message.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+
+	return &message, nil
+}
@@ -0,0 +1,183 @@
+// Package sqlite provides implementations of the repository interfaces using SQLite as the storage backend.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+	"time"
+
+	"uni-task-manager/internal/domain/models"
+)
+
+// exDateLayout is the day granularity used to store recurrence exception dates
+const exDateLayout = "2006-01-02"
+
+// RecurrenceRepository implements output.RecurrenceRepository interface using SQLite as the storage backend.
+// Weekdays and exception dates are stored as comma-separated lists.
+type RecurrenceRepository struct {
+	db *sql.DB
+}
+
+// NewRecurrenceRepository creates a new instance of RecurrenceRepository with the provided database connection.
+func NewRecurrenceRepository(db *sql.DB) *RecurrenceRepository {
+	return &RecurrenceRepository{db: db}
+}
+
+// GetByID retrieves a specific recurrence rule of the owner by its ID from the database.
+// Returns nil if the owner has no rule with the given ID.
+func (r *RecurrenceRepository) GetByID(ctx context.Context, ownerID, id int64) (*models.Recurrence, error) {
+	var rule models.Recurrence
+	var frequency, byWeekday, exDates, createdAt, updatedAt string
+	var until sql.NullString
+
+	err := conn(ctx, r.db).QueryRowContext(ctx, `
+		SELECT id, owner_id, frequency, interval, by_weekday, until, count, occurrences, exdates, created_at, updated_at
+		FROM task_recurrences
+		WHERE id = ? AND owner_id = ?
+	`, id, ownerID).Scan(
+		&rule.ID,
+		&rule.OwnerID,
+		&frequency,
+		&rule.Interval,
+		&byWeekday,
+		&until,
+		&rule.Count,
+		&rule.Occurrences,
+		&exDates,
+		&createdAt,
+		&updatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rule.Frequency = models.RecurrenceFrequency(frequency)
+	rule.ByWeekday = decodeWeekdays(byWeekday)
+	rule.ExDates = decodeExDates(exDates)
+	if until.Valid {
+		rule.Until, _ = time.Parse(time.RFC3339, until.String)
+	}
+	rule.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	rule.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
+
+	return &rule, nil
+}
+
+// Create persists a new recurrence rule in the database.
+// It sets the ID field of the rule with the generated ID.
+func (r *RecurrenceRepository) Create(ctx context.Context, rule *models.Recurrence) error {
+	result, err := conn(ctx, r.db).ExecContext(ctx, `
+		INSERT INTO task_recurrences (owner_id, frequency, interval, by_weekday, until, count, occurrences, exdates, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`,
+		rule.OwnerID,
+		string(rule.Frequency),
+		rule.Interval,
+		encodeWeekdays(rule.ByWeekday),
+		nullableTime(rule.Until),
+		rule.Count,
+		rule.Occurrences,
+		encodeExDates(rule.ExDates),
+		rule.CreatedAt.UTC().Format(time.RFC3339),
+		rule.UpdatedAt.UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	rule.ID = id
+	return nil
+}
+
+// Update modifies an existing recurrence rule in the database.
+// All fields except CreatedAt can be updated.
+func (r *RecurrenceRepository) Update(ctx context.Context, rule *models.Recurrence) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `
+		UPDATE task_recurrences
+		SET frequency = ?, interval = ?, by_weekday = ?, until = ?, count = ?, occurrences = ?, exdates = ?, updated_at = ?
+		WHERE id = ? AND owner_id = ?
+	`,
+		string(rule.Frequency),
+		rule.Interval,
+		encodeWeekdays(rule.ByWeekday),
+		nullableTime(rule.Until),
+		rule.Count,
+		rule.Occurrences,
+		encodeExDates(rule.ExDates),
+		rule.UpdatedAt.UTC().Format(time.RFC3339),
+		rule.ID,
+		rule.OwnerID,
+	)
+
+	return err
+}
+
+// Delete removes a recurrence rule of the owner and detaches the tasks of its series, atomically.
+func (r *RecurrenceRepository) Delete(ctx context.Context, ownerID, id int64) error {
+	return inTx(ctx, r.db, func(tx dbtx) error {
+		if _, err := tx.ExecContext(ctx, "UPDATE tasks SET recurrence_id = NULL WHERE recurrence_id = ? AND owner_id = ?", id, ownerID); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, "DELETE FROM task_recurrences WHERE id = ? AND owner_id = ?", id, ownerID)
+		return err
+	})
+}
+
+// nullableTime maps the zero time used by the domain for "not set" to a SQL NULL.
+func nullableTime(t time.Time) sql.NullString {
+	if t.IsZero() {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: t.UTC().Format(time.RFC3339), Valid: true}
+}
+
+// encodeWeekdays stores weekdays as a comma-separated list of their numbers (Sunday = 0).
+func encodeWeekdays(days []time.Weekday) string {
+	parts := make([]string, len(days))
+	for i, day := range days {
+		parts[i] = strconv.Itoa(int(day))
+	}
+	return strings.Join(parts, ",")
+}
+
+// decodeWeekdays parses a list produced by encodeWeekdays.
+func decodeWeekdays(value string) []time.Weekday {
+	var days []time.Weekday
+	for _, part := range strings.Split(value, ",") {
+		if n, err := strconv.Atoi(part); err == nil {
+			days = append(days, time.Weekday(n))
+		}
+	}
+	return days
+}
+
+// encodeExDates stores exception dates as a comma-separated list of days.
+func encodeExDates(dates []time.Time) string {
+	parts := make([]string, len(dates))
+	for i, date := range dates {
+		parts[i] = date.UTC().Format(exDateLayout)
+	}
+	return strings.Join(parts, ",")
+}
+
+// decodeExDates parses a list produced by encodeExDates.
+func decodeExDates(value string) []time.Time {
+	var dates []time.Time
+	for _, part := range strings.Split(value, ",") {
+		if date, err := time.Parse(exDateLayout, part); err == nil {
+			dates = append(dates, date)
+		}
+	}
+	return dates
+}
@@ -0,0 +1,102 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"uni-task-manager/internal/domain/models"
+)
+
+// reminderColumns lists the columns selected for every reminder delivery query, in scanReminderDelivery order
+const reminderColumns = "id, owner_id, task_id, rule, scheduled_at, channel, status, attempts, last_error, updated_at"
+
+// ReminderRepository implements output.ReminderRepository interface using SQLite as the storage backend.
+type ReminderRepository struct {
+	db *sql.DB
+}
+
+// NewReminderRepository creates a new instance of ReminderRepository with the provided database connection.
+func NewReminderRepository(db *sql.DB) *ReminderRepository {
+	return &ReminderRepository{db: db}
+}
+
+// GetByTask retrieves the deliveries of the reminders about a task.
+func (r *ReminderRepository) GetByTask(ctx context.Context, taskID int64) ([]models.ReminderDelivery, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT `+reminderColumns+`
+		FROM reminder_deliveries
+		WHERE task_id = ?
+		ORDER BY scheduled_at ASC, id ASC
+	`, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []models.ReminderDelivery
+	for rows.Next() {
+		delivery, err := scanReminderDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, *delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// Save inserts the delivery of a reminder or, if the reminder was already attempted on the channel,
+// updates its status, attempts and error. It sets the ID field of new deliveries.
+func (r *ReminderRepository) Save(ctx context.Context, delivery *models.ReminderDelivery) error {
+	row := conn(ctx, r.db).QueryRowContext(ctx, `
+		INSERT INTO reminder_deliveries (owner_id, task_id, rule, scheduled_at, channel, status, attempts, last_error, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (task_id, rule, scheduled_at, channel) DO UPDATE
+		SET status = excluded.status, attempts = excluded.attempts, last_error = excluded.last_error,
+			updated_at = excluded.updated_at
+		RETURNING id
+	`,
+		delivery.OwnerID,
+		delivery.TaskID,
+		delivery.Rule,
+		delivery.ScheduledAt.UTC().Format(time.RFC3339),
+		delivery.Channel,
+		string(delivery.Status),
+		delivery.Attempts,
+		delivery.LastError,
+		delivery.UpdatedAt.UTC().Format(time.RFC3339),
+	)
+	return row.Scan(&delivery.ID)
+}
+
+// scanReminderDelivery maps a single row selected with reminderColumns to a domain ReminderDelivery object.
+func scanReminderDelivery(row rowScanner) (*models.ReminderDelivery, error) {
+	var delivery models.ReminderDelivery
+	var status, scheduledAt, updatedAt string
+
+	if err := row.Scan(
+		&delivery.ID,
+		&delivery.OwnerID,
+		&delivery.TaskID,
+		&delivery.Rule,
+		&scheduledAt,
+		&delivery.Channel,
+		&status,
+		&delivery.Attempts,
+		&delivery.LastError,
+		&updatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	delivery.Status = models.DeliveryStatus(status)
+	delivery.ScheduledAt, _ = time.Parse(time.RFC3339, scheduledAt)
+	delivery.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
+
+	return &delivery, nil
+}
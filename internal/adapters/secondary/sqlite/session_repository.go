@@ -0,0 +1,70 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"uni-task-manager/internal/domain/models"
+)
+
+// SessionRepository implements output.SessionRepository interface using SQLite as the storage backend.
+// Only the hash of a session token is stored, never the token itself.
+type SessionRepository struct {
+	db *sql.DB
+}
+
+// NewSessionRepository creates a new instance of SessionRepository with the provided database connection.
+func NewSessionRepository(db *sql.DB) *SessionRepository {
+	return &SessionRepository{db: db}
+}
+
+// GetByTokenHash retrieves a session by the hash of its token.
+// Returns nil if no session is found with the given hash.
+func (r *SessionRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*models.Session, error) {
+	var session models.Session
+	var expiresAt, createdAt string
+
+	err := conn(ctx, r.db).QueryRowContext(ctx, `
+		SELECT token_hash, user_id, expires_at, created_at
+		FROM sessions
+		WHERE token_hash = ?
+	`, tokenHash).Scan(
+		&session.TokenHash,
+		&session.UserID,
+		&expiresAt,
+		&createdAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	session.ExpiresAt, _ = time.Parse(time.RFC3339, expiresAt)
+	session.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+
+	return &session, nil
+}
+
+// Create persists a new session in the database.
+func (r *SessionRepository) Create(ctx context.Context, session *models.Session) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `
+		INSERT INTO sessions (token_hash, user_id, expires_at, created_at)
+		VALUES (?, ?, ?, ?)
+	`,
+		session.TokenHash,
+		session.UserID,
+		session.ExpiresAt.UTC().Format(time.RFC3339),
+		session.CreatedAt.UTC().Format(time.RFC3339),
+	)
+
+	return err
+}
+
+// Delete removes a session from the database by the hash of its token.
+func (r *SessionRepository) Delete(ctx context.Context, tokenHash string) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, "DELETE FROM sessions WHERE token_hash = ?", tokenHash)
+	return err
+}
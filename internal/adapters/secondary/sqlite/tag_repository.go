@@ -0,0 +1,249 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"uni-task-manager/internal/domain/models"
+)
+
+// tagColumns lists the columns selected for every tag query, in scanTag order
+const tagColumns = "id, owner_id, name, created_at"
+
+// TagRepository implements output.TagRepository interface using SQLite as the storage backend.
+// Tags live in the tags table and are attached to tasks through the task_tags join table.
+type TagRepository struct {
+	db *sql.DB
+}
+
+// NewTagRepository creates a new instance of TagRepository with the provided database connection.
+func NewTagRepository(db *sql.DB) *TagRepository {
+	return &TagRepository{db: db}
+}
+
+// GetAll retrieves all tags of the owner from the database, ordered by name.
+func (r *TagRepository) GetAll(ctx context.Context, ownerID int64) ([]models.Tag, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT `+tagColumns+`
+		FROM tags
+		WHERE owner_id = ?
+		ORDER BY name ASC
+	`, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	return scanTags(rows)
+}
+
+// GetByID retrieves a specific tag of the owner by its ID from the database.
+// Returns nil if the owner has no tag with the given ID.
+func (r *TagRepository) GetByID(ctx context.Context, ownerID, id int64) (*models.Tag, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, `
+		SELECT `+tagColumns+`
+		FROM tags
+		WHERE id = ? AND owner_id = ?
+	`, id, ownerID)
+
+	tag, err := scanTag(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+// GetByName retrieves a tag of the owner by its name, compared case-insensitively.
+// Returns nil if the owner has no tag with the given name.
+func (r *TagRepository) GetByName(ctx context.Context, ownerID int64, name string) (*models.Tag, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, `
+		SELECT `+tagColumns+`
+		FROM tags
+		WHERE name = ? AND owner_id = ?
+	`, name, ownerID)
+
+	tag, err := scanTag(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+// Create persists a new tag in the database.
+// It sets the ID field of the tag object with the generated ID.
+func (r *TagRepository) Create(ctx context.Context, tag *models.Tag) error {
+	result, err := conn(ctx, r.db).ExecContext(ctx, `
+		INSERT INTO tags (owner_id, name, created_at)
+		VALUES (?, ?, ?)
+	`,
+		tag.OwnerID,
+		tag.Name,
+		tag.CreatedAt.UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	tag.ID = id
+	return nil
+}
+
+// Update renames an existing tag of its owner in the database.
+func (r *TagRepository) Update(ctx context.Context, tag *models.Tag) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `
+		UPDATE tags
+		SET name = ?
+		WHERE id = ? AND owner_id = ?
+	`, tag.Name, tag.ID, tag.OwnerID)
+	return err
+}
+
+// Delete removes a tag of the owner from the database by its ID.
+// The foreign keys of task_tags remove the tag from every task carrying it.
+func (r *TagRepository) Delete(ctx context.Context, ownerID, id int64) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, "DELETE FROM tags WHERE id = ? AND owner_id = ?", id, ownerID)
+	return err
+}
+
+// GetByTaskID retrieves the tags of a specific task of the owner, ordered by name.
+func (r *TagRepository) GetByTaskID(ctx context.Context, ownerID, taskID int64) ([]models.Tag, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT `+qualifiedColumns("g", tagColumns)+`
+		FROM tags g
+		JOIN task_tags tt ON tt.tag_id = g.id
+		WHERE tt.task_id = ? AND g.owner_id = ?
+		ORDER BY g.name ASC
+	`, taskID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	return scanTags(rows)
+}
+
+// GetAllByTask retrieves the tags of every tagged task of the owner in a single query,
+// keyed by task ID and ordered by name.
+func (r *TagRepository) GetAllByTask(ctx context.Context, ownerID int64) (map[int64][]models.Tag, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT `+qualifiedColumns("g", tagColumns)+`, tt.task_id
+		FROM tags g
+		JOIN task_tags tt ON tt.tag_id = g.id
+		WHERE g.owner_id = ?
+		ORDER BY g.name ASC
+	`, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	return scanTaskTags(rows)
+}
+
+// GetByTaskIDs retrieves the tags of the given tasks of the owner in a single query,
+// keyed by task ID and ordered by name. Untagged tasks are left out of the result.
+func (r *TagRepository) GetByTaskIDs(ctx context.Context, ownerID int64, taskIDs []int64) (map[int64][]models.Tag, error) {
+	if len(taskIDs) == 0 {
+		return make(map[int64][]models.Tag), nil
+	}
+
+	args := []interface{}{ownerID}
+	for _, id := range taskIDs {
+		args = append(args, id)
+	}
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT `+qualifiedColumns("g", tagColumns)+`, tt.task_id
+		FROM tags g
+		JOIN task_tags tt ON tt.tag_id = g.id
+		WHERE g.owner_id = ? AND tt.task_id IN (`+placeholders(len(taskIDs))+`)
+		ORDER BY g.name ASC
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanTaskTags(rows)
+}
+
+// SetTaskTags replaces the tags of a task with the given tags, atomically.
+func (r *TagRepository) SetTaskTags(ctx context.Context, taskID int64, tagIDs []int64) error {
+	return inTx(ctx, r.db, func(tx dbtx) error {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM task_tags WHERE task_id = ?", taskID); err != nil {
+			return err
+		}
+		for _, tagID := range tagIDs {
+			if _, err := tx.ExecContext(ctx, "INSERT OR IGNORE INTO task_tags (task_id, tag_id) VALUES (?, ?)", taskID, tagID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// scanTags maps the rows of a query selecting tagColumns to domain Tag objects and closes them.
+func scanTags(rows *sql.Rows) ([]models.Tag, error) {
+	defer rows.Close()
+
+	var tags []models.Tag
+	for rows.Next() {
+		tag, err := scanTag(rows)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, *tag)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+// scanTaskTags maps the rows of a query selecting tagColumns followed by the task ID to the tags
+// of each task, keyed by task ID, and closes them.
+func scanTaskTags(rows *sql.Rows) (map[int64][]models.Tag, error) {
+	defer rows.Close()
+
+	tags := make(map[int64][]models.Tag)
+	for rows.Next() {
+		var taskID int64
+		tag, err := scanTag(scannerWithExtras{rows, []interface{}{&taskID}})
+		if err != nil {
+			return nil, err
+		}
+		tags[taskID] = append(tags[taskID], *tag)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+// scanTag maps a single row selected with tagColumns to a domain Tag object.
+func scanTag(row rowScanner) (*models.Tag, error) {
+	var tag models.Tag
+	var createdAt string
+
+	if err := row.Scan(
+		&tag.ID,
+		&tag.OwnerID,
+		&tag.Name,
+		&createdAt,
+	); err != nil {
+		return nil, err
+	}
+
+	tag.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+
+	return &tag, nil
+}
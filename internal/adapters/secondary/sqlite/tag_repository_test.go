@@ -0,0 +1,57 @@
+package sqlite
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestTagRepositoryGetByTaskIDs(t *testing.T) {
+	ctx := context.Background()
+	db := openMigratedTestDB(t)
+	if _, err := db.Exec(`
+		INSERT INTO tasks (id, owner_id, title, description, due_date, priority, status, created_at, updated_at) VALUES
+			(1, 1, 'Essay', '', '2030-01-01T09:00:00Z', 3, 'pending', '2030-01-01T00:00:00Z', '2030-01-01T00:00:00Z'),
+			(2, 1, 'Lab', '', '2030-01-01T09:00:00Z', 3, 'pending', '2030-01-01T00:00:00Z', '2030-01-01T00:00:00Z'),
+			(3, 1, 'Quiz', '', '2030-01-01T09:00:00Z', 3, 'pending', '2030-01-01T00:00:00Z', '2030-01-01T00:00:00Z'),
+			(4, 2, 'Thesis', '', '2030-01-01T09:00:00Z', 3, 'pending', '2030-01-01T00:00:00Z', '2030-01-01T00:00:00Z');
+		INSERT INTO tags (id, owner_id, name, created_at) VALUES
+			(1, 1, 'writing', '2030-01-01T00:00:00Z'),
+			(2, 1, 'exam', '2030-01-01T00:00:00Z'),
+			(3, 2, 'research', '2030-01-01T00:00:00Z');
+		INSERT INTO task_tags (task_id, tag_id) VALUES (1, 1), (1, 2), (2, 2), (3, 1), (4, 3);
+	`); err != nil {
+		t.Fatal(err)
+	}
+	repo := NewTagRepository(db)
+
+	tests := []struct {
+		name    string
+		ownerID int64
+		taskIDs []int64
+		want    map[int64][]string
+	}{
+		{"only the given tasks", 1, []int64{1, 2}, map[int64][]string{1: {"exam", "writing"}, 2: {"exam"}}},
+		{"untagged and unknown tasks are left out", 1, []int64{2, 99}, map[int64][]string{2: {"exam"}}},
+		{"tasks of another owner are left out", 1, []int64{3, 4}, map[int64][]string{3: {"writing"}}},
+		{"no tasks", 1, nil, map[int64][]string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tags, err := repo.GetByTaskIDs(ctx, tt.ownerID, tt.taskIDs)
+			if err != nil {
+				t.Fatalf("GetByTaskIDs() error = %v", err)
+			}
+			got := make(map[int64][]string)
+			for taskID, tagged := range tags {
+				for _, tag := range tagged {
+					got[taskID] = append(got[taskID], tag.Name)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetByTaskIDs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"uni-task-manager/internal/domain/models"
+)
+
+// taskHistoryColumns lists the columns selected for every task history query, in scanTaskHistoryEntry order
+const taskHistoryColumns = "h.id, h.task_id, h.owner_id, COALESCE(h.actor_id, 0), COALESCE(u.email, ''), h.action, " +
+	"h.version, h.changes, h.previous, h.created_at"
+
+// TaskHistoryRepository implements output.TaskHistoryRepository interface using SQLite as the storage backend.
+// The field changes and the previous state of the task are stored as JSON.
+type TaskHistoryRepository struct {
+	db *sql.DB
+}
+
+// NewTaskHistoryRepository creates a new instance of TaskHistoryRepository with the provided database connection.
+func NewTaskHistoryRepository(db *sql.DB) *TaskHistoryRepository {
+	return &TaskHistoryRepository{db: db}
+}
+
+// fieldChangeJSON is the stored form of a field change
+type fieldChangeJSON struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// taskStateJSON is the stored form of the state of a task before a change
+type taskStateJSON struct {
+	Title          string    `json:"title"`
+	Description    string    `json:"description"`
+	DueDate        time.Time `json:"due_date"`
+	Priority       int       `json:"priority"`
+	Status         string    `json:"status"`
+	EstimatedHours float64   `json:"estimated_hours"`
+	CourseID       int64     `json:"course_id"`
+	ParentID       int64     `json:"parent_id"`
+	RecurrenceID   int64     `json:"recurrence_id"`
+	Version        int64     `json:"version"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// GetByTaskID retrieves the history of a task of the owner, newest first.
+func (r *TaskHistoryRepository) GetByTaskID(ctx context.Context, ownerID, taskID int64) ([]models.TaskHistoryEntry, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT `+taskHistoryColumns+`
+		FROM task_history h
+		LEFT JOIN users u ON u.id = h.actor_id
+		WHERE h.task_id = ? AND h.owner_id = ?
+		ORDER BY h.id DESC
+	`, taskID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []models.TaskHistoryEntry
+	for rows.Next() {
+		entry, err := scanTaskHistoryEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, *entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// GetByID retrieves a specific history entry of the owner by its ID from the database.
+// Returns nil if the owner has no history entry with the given ID.
+func (r *TaskHistoryRepository) GetByID(ctx context.Context, ownerID, id int64) (*models.TaskHistoryEntry, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, `
+		SELECT `+taskHistoryColumns+`
+		FROM task_history h
+		LEFT JOIN users u ON u.id = h.actor_id
+		WHERE h.id = ? AND h.owner_id = ?
+	`, id, ownerID)
+
+	entry, err := scanTaskHistoryEntry(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// Add persists a new history entry in the database.
+// It sets the ID field of the entry with the generated ID.
+func (r *TaskHistoryRepository) Add(ctx context.Context, entry *models.TaskHistoryEntry) error {
+	changes := make([]fieldChangeJSON, len(entry.Changes))
+	for i, change := range entry.Changes {
+		changes[i] = fieldChangeJSON(change)
+	}
+	encodedChanges, err := json.Marshal(changes)
+	if err != nil {
+		return err
+	}
+
+	task := entry.Previous
+	encodedPrevious, err := json.Marshal(taskStateJSON{
+		Title:          task.Title,
+		Description:    task.Description,
+		DueDate:        task.DueDate.UTC(),
+		Priority:       task.Priority,
+		Status:         string(task.Status),
+		EstimatedHours: task.EstimatedHours,
+		CourseID:       task.CourseID,
+		ParentID:       task.ParentID,
+		RecurrenceID:   task.RecurrenceID,
+		Version:        task.Version,
+		CreatedAt:      task.CreatedAt.UTC(),
+		UpdatedAt:      task.UpdatedAt.UTC(),
+	})
+	if err != nil {
+		return err
+	}
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, `
+		INSERT INTO task_history (task_id, owner_id, actor_id, action, version, changes, previous, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`,
+		entry.TaskID,
+		entry.OwnerID,
+		nullableID(entry.ActorID),
+		string(entry.Action),
+		entry.Version,
+		string(encodedChanges),
+		string(encodedPrevious),
+		entry.CreatedAt.UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	entry.ID = id
+	return nil
+}
+
+// scanTaskHistoryEntry maps a single row selected with taskHistoryColumns to a domain TaskHistoryEntry object.
+func scanTaskHistoryEntry(row rowScanner) (*models.TaskHistoryEntry, error) {
+	var entry models.TaskHistoryEntry
+	var action, changes, previous, createdAt string
+
+	if err := row.Scan(
+		&entry.ID,
+		&entry.TaskID,
+		&entry.OwnerID,
+		&entry.ActorID,
+		&entry.ActorEmail,
+		&action,
+		&entry.Version,
+		&changes,
+		&previous,
+		&createdAt,
+	); err != nil {
+		return nil, err
+	}
+
+	var decodedChanges []fieldChangeJSON
+	if err := json.Unmarshal([]byte(changes), &decodedChanges); err != nil {
+		return nil, err
+	}
+	for _, change := range decodedChanges {
+		entry.Changes = append(entry.Changes, models.FieldChange(change))
+	}
+
+	var state taskStateJSON
+	if err := json.Unmarshal([]byte(previous), &state); err != nil {
+		return nil, err
+	}
+	entry.Previous = models.Task{
+		ID:             entry.TaskID,
+		OwnerID:        entry.OwnerID,
+		Title:          state.Title,
+		Description:    state.Description,
+		DueDate:        state.DueDate,
+		Priority:       state.Priority,
+		Status:         models.TaskStatus(state.Status),
+		EstimatedHours: state.EstimatedHours,
+		CourseID:       state.CourseID,
+		ParentID:       state.ParentID,
+		RecurrenceID:   state.RecurrenceID,
+		Version:        state.Version,
+		CreatedAt:      state.CreatedAt,
+		UpdatedAt:      state.UpdatedAt,
+	}
+
+	entry.Action = models.TaskChangeAction(action)
+	entry.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+
+	return &entry, nil
+}
@@ -4,11 +4,22 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"strings"
 	"time"
 
 	"uni-task-manager/internal/domain/models"
+	"uni-task-manager/internal/ports/output"
 )
 
+// taskColumns lists the columns selected for every task query, in scanTask order
+const taskColumns = "id, owner_id, title, description, due_date, priority, status, estimated_hours, course_id, parent_id, recurrence_id, external_uid, version, created_at, updated_at"
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
 // TaskRepository implements output.TaskRepository interface using SQLite as the storage backend.
 // It handles all task-related database operations and mapping between domain models and database rows.
 type TaskRepository struct {
@@ -20,77 +31,106 @@ func NewTaskRepository(db *sql.DB) *TaskRepository {
 	return &TaskRepository{db: db}
 }
 
-// GetAll retrieves all tasks from the database, ordered by due date.
+// GetAll retrieves all tasks of the owner from the database, ordered by due date.
 // It maps the database rows to domain Task objects.
-func (r *TaskRepository) GetAll(ctx context.Context) ([]models.Task, error) {
-	rows, err := r.db.QueryContext(ctx, `
-		SELECT id, title, description, due_date, priority, status, course_id, created_at, updated_at
+func (r *TaskRepository) GetAll(ctx context.Context, ownerID int64) ([]models.Task, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT `+taskColumns+`
 		FROM tasks
+		WHERE owner_id = ?
 		ORDER BY due_date ASC
-	`)
+	`, ownerID)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
+	return scanTasks(rows)
+}
 
-	var tasks []models.Task
-	for rows.Next() {
-		var task models.Task
-		var dueDate, createdAt, updatedAt string
-		var status string
-
-		if err := rows.Scan(
-			&task.ID,
-			&task.Title,
-			&task.Description,
-			&dueDate,
-			&task.Priority,
-			&status,
-			&task.CourseID,
-			&createdAt,
-			&updatedAt,
-		); err != nil {
-			return nil, err
-		}
+// GetUnfinishedDueBefore retrieves the tasks of every owner that aren't completed and are due
+// before the given time, ordered by due date. Tasks without a due date, stored empty or as the
+// zero time, are left out.
+func (r *TaskRepository) GetUnfinishedDueBefore(ctx context.Context, before time.Time) ([]models.Task, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT `+taskColumns+`
+		FROM tasks
+		WHERE status != ? AND due_date > ? AND due_date < ?
+		ORDER BY due_date ASC, id ASC
+	`, string(models.TaskStatusCompleted), time.Time{}.Format(time.RFC3339), before.UTC().Format(time.RFC3339))
+	if err != nil {
+		return nil, err
+	}
+	return scanTasks(rows)
+}
 
-		task.Status = models.TaskStatus(status)
-		task.DueDate, _ = time.Parse(time.RFC3339, dueDate)
-		task.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
-		task.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
+// List retrieves the tasks matching the query, filtered, ordered and limited by SQLite.
+// Pagination is keyset based: when query.After is set, only tasks positioned after it are returned.
+func (r *TaskRepository) List(ctx context.Context, ownerID int64, query models.TaskQuery) ([]models.Task, error) {
+	conditions := []string{"owner_id = ?"}
+	args := []interface{}{ownerID}
 
-		tasks = append(tasks, task)
+	if query.Status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, string(query.Status))
+	}
+	if query.CourseID != 0 {
+		conditions = append(conditions, "course_id = ?")
+		args = append(args, query.CourseID)
+	}
+	if query.Tag != "" {
+		conditions = append(conditions, "id IN (SELECT tt.task_id FROM task_tags tt JOIN tags g ON g.id = tt.tag_id WHERE g.name = ?)")
+		args = append(args, query.Tag)
+	}
+	if query.PriorityMin != 0 {
+		conditions = append(conditions, "priority >= ?")
+		args = append(args, query.PriorityMin)
+	}
+	if !query.DueBefore.IsZero() {
+		// Tasks without a due date, stored empty or as the zero time, sort before any date
+		conditions = append(conditions, "due_date > ? AND due_date < ?")
+		args = append(args, time.Time{}.Format(time.RFC3339), query.DueBefore.UTC().Format(time.RFC3339))
+	}
+	if !query.DueAfter.IsZero() {
+		conditions = append(conditions, "due_date > ?")
+		args = append(args, query.DueAfter.UTC().Format(time.RFC3339))
 	}
 
-	if err := rows.Err(); err != nil {
+	sortColumn, err := taskSortColumn(query.Sort)
+	if err != nil {
 		return nil, err
 	}
+	direction, comparison := "ASC", ">"
+	if query.Descending {
+		direction, comparison = "DESC", "<"
+	}
+	if query.After != nil {
+		conditions = append(conditions, fmt.Sprintf("(%s, id) %s (?, ?)", sortColumn, comparison))
+		args = append(args, query.After.Value, query.After.ID)
+	}
 
-	return tasks, nil
-}
+	sqlQuery := "SELECT " + taskColumns + " FROM tasks WHERE " + strings.Join(conditions, " AND ")
+	sqlQuery += fmt.Sprintf(" ORDER BY %s %s, id %s", sortColumn, direction, direction)
+	if query.Limit > 0 {
+		sqlQuery += " LIMIT ?"
+		args = append(args, query.Limit)
+	}
 
-// GetByID retrieves a specific task by its ID from the database.
-// Returns nil if no task is found with the given ID.
-func (r *TaskRepository) GetByID(ctx context.Context, id int64) (*models.Task, error) {
-	var task models.Task
-	var dueDate, createdAt, updatedAt string
-	var status string
+	rows, err := conn(ctx, r.db).QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanTasks(rows)
+}
 
-	err := r.db.QueryRowContext(ctx, `
-		SELECT id, title, description, due_date, priority, status, course_id, created_at, updated_at
+// GetByID retrieves a specific task of the owner by its ID from the database.
+// Returns nil if the owner has no task with the given ID.
+func (r *TaskRepository) GetByID(ctx context.Context, ownerID, id int64) (*models.Task, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, `
+		SELECT `+taskColumns+`
 		FROM tasks
-		WHERE id = ?
-	`, id).Scan(
-		&task.ID,
-		&task.Title,
-		&task.Description,
-		&dueDate,
-		&task.Priority,
-		&status,
-		&task.CourseID,
-		&createdAt,
-		&updatedAt,
-	)
+		WHERE id = ? AND owner_id = ?
+	`, id, ownerID)
 
+	task, err := scanTask(row)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -98,29 +138,29 @@ func (r *TaskRepository) GetByID(ctx context.Context, id int64) (*models.Task, e
 		return nil, err
 	}
 
-	task.Status = models.TaskStatus(status)
-	task.DueDate, _ = time.Parse(time.RFC3339, dueDate)
-	task.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
-	task.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
-
-	return &task, nil
+	return task, nil
 }
 
 // Create persists a new task in the database.
-// It sets the ID field of the task object with the generated ID.
+// It sets the ID field of the task object with the generated ID and its initial version.
 func (r *TaskRepository) Create(ctx context.Context, task *models.Task) error {
-	result, err := r.db.ExecContext(ctx, `
-		INSERT INTO tasks (title, description, due_date, priority, status, course_id, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	result, err := conn(ctx, r.db).ExecContext(ctx, `
+		INSERT INTO tasks (owner_id, title, description, due_date, priority, status, estimated_hours, course_id, parent_id, recurrence_id, external_uid, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`,
+		task.OwnerID,
 		task.Title,
 		task.Description,
-		task.DueDate.Format(time.RFC3339),
+		task.DueDate.UTC().Format(time.RFC3339),
 		task.Priority,
 		string(task.Status),
-		task.CourseID,
-		task.CreatedAt.Format(time.RFC3339),
-		task.UpdatedAt.Format(time.RFC3339),
+		task.EstimatedHours,
+		nullableID(task.CourseID),
+		nullableID(task.ParentID),
+		nullableID(task.RecurrenceID),
+		nullableString(task.ExternalUID),
+		task.CreatedAt.UTC().Format(time.RFC3339),
+		task.UpdatedAt.UTC().Format(time.RFC3339),
 	)
 	if err != nil {
 		return err
@@ -132,76 +172,205 @@ func (r *TaskRepository) Create(ctx context.Context, task *models.Task) error {
 	}
 
 	task.ID = id
+	task.Version = 1
 	return nil
 }
 
 // Update modifies an existing task in the database.
-// All fields except CreatedAt can be updated.
+// All fields except CreatedAt can be updated. The row is only written if its version is still
+// task.Version, which makes the check and the write a single atomic statement.
 func (r *TaskRepository) Update(ctx context.Context, task *models.Task) error {
-	_, err := r.db.ExecContext(ctx, `
+	result, err := conn(ctx, r.db).ExecContext(ctx, `
 		UPDATE tasks
-		SET title = ?, description = ?, due_date = ?, priority = ?, status = ?, course_id = ?, updated_at = ?
-		WHERE id = ?
+		SET title = ?, description = ?, due_date = ?, priority = ?, status = ?, estimated_hours = ?, course_id = ?, recurrence_id = ?,
+			updated_at = ?, version = version + 1
+		WHERE id = ? AND owner_id = ? AND version = ?
 	`,
 		task.Title,
 		task.Description,
-		task.DueDate.Format(time.RFC3339),
+		task.DueDate.UTC().Format(time.RFC3339),
 		task.Priority,
 		string(task.Status),
-		task.CourseID,
-		task.UpdatedAt.Format(time.RFC3339),
+		task.EstimatedHours,
+		nullableID(task.CourseID),
+		nullableID(task.RecurrenceID),
+		task.UpdatedAt.UTC().Format(time.RFC3339),
 		task.ID,
+		task.OwnerID,
+		task.Version,
 	)
+	if err != nil {
+		return err
+	}
 
-	return err
+	if err := checkVersionedUpdate(result); err != nil {
+		return err
+	}
+	task.Version++
+	return nil
 }
 
-// Delete removes a task from the database by its ID.
-func (r *TaskRepository) Delete(ctx context.Context, id int64) error {
-	_, err := r.db.ExecContext(ctx, "DELETE FROM tasks WHERE id = ?", id)
-	return err
+// Delete removes a task of the owner and its subtasks from the database by its ID.
+// The task is only deleted if its version is still the given one, which makes the check and the
+// deletion a single atomic statement; its subtasks are deleted with it in the same transaction.
+func (r *TaskRepository) Delete(ctx context.Context, ownerID, id, version int64) error {
+	return inTx(ctx, r.db, func(tx dbtx) error {
+		result, err := tx.ExecContext(ctx, "DELETE FROM tasks WHERE id = ? AND owner_id = ? AND version = ?", id, ownerID, version)
+		if err != nil {
+			return err
+		}
+		if err := checkVersionedDelete(ctx, tx, result, "tasks", id); err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, "DELETE FROM tasks WHERE parent_id = ? AND owner_id = ?", id, ownerID)
+		return err
+	})
+}
+
+// GetSubtasks retrieves the subtasks of a specific task.
+// Subtasks are ordered by due date.
+func (r *TaskRepository) GetSubtasks(ctx context.Context, ownerID, parentID int64) ([]models.Task, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT `+taskColumns+`
+		FROM tasks
+		WHERE parent_id = ? AND owner_id = ?
+		ORDER BY due_date ASC, id ASC
+	`, parentID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	return scanTasks(rows)
+}
+
+// GetByExternalUID retrieves a task by the calendar UID it was imported from.
+// Returns nil if no task was imported with the given UID.
+func (r *TaskRepository) GetByExternalUID(ctx context.Context, ownerID int64, uid string) (*models.Task, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, `
+		SELECT `+taskColumns+`
+		FROM tasks
+		WHERE external_uid = ? AND owner_id = ?
+	`, uid, ownerID)
+
+	task, err := scanTask(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// GetByRecurrenceID retrieves all tasks of the series generated by a recurrence rule.
+// Tasks are ordered by due date.
+func (r *TaskRepository) GetByRecurrenceID(ctx context.Context, ownerID, recurrenceID int64) ([]models.Task, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT `+taskColumns+`
+		FROM tasks
+		WHERE recurrence_id = ? AND owner_id = ?
+		ORDER BY due_date ASC, id ASC
+	`, recurrenceID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	return scanTasks(rows)
+}
+
+// CountSubtasks counts the total and completed subtasks of every task that has any.
+// The result is keyed by parent task ID; Percent is left for the domain layer to compute.
+func (r *TaskRepository) CountSubtasks(ctx context.Context, ownerID int64) (map[int64]models.TaskProgress, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT parent_id, COUNT(*), SUM(CASE WHEN status = 'completed' THEN 1 ELSE 0 END)
+		FROM tasks
+		WHERE parent_id IS NOT NULL AND owner_id = ?
+		GROUP BY parent_id
+	`, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[int64]models.TaskProgress)
+	for rows.Next() {
+		var parentID int64
+		var progress models.TaskProgress
+		if err := rows.Scan(&parentID, &progress.Total, &progress.Completed); err != nil {
+			return nil, err
+		}
+		counts[parentID] = progress
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
 }
 
 // GetByCourseID retrieves all tasks associated with a specific course.
 // Tasks are ordered by due date.
-func (r *TaskRepository) GetByCourseID(ctx context.Context, courseID int64) ([]models.Task, error) {
-	rows, err := r.db.QueryContext(ctx, `
-		SELECT id, title, description, due_date, priority, status, course_id, created_at, updated_at
+func (r *TaskRepository) GetByCourseID(ctx context.Context, ownerID, courseID int64) ([]models.Task, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT `+taskColumns+`
 		FROM tasks
-		WHERE course_id = ?
+		WHERE course_id = ? AND owner_id = ?
 		ORDER BY due_date ASC
-	`, courseID)
+	`, courseID, ownerID)
 	if err != nil {
 		return nil, err
 	}
+	return scanTasks(rows)
+}
+
+// DeleteByCourseID removes the tasks of a course, and the subtasks of those tasks, from the database.
+func (r *TaskRepository) DeleteByCourseID(ctx context.Context, ownerID, courseID int64) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `
+		DELETE FROM tasks
+		WHERE owner_id = ?
+			AND (course_id = ? OR parent_id IN (SELECT id FROM tasks WHERE course_id = ? AND owner_id = ?))
+	`, ownerID, courseID, courseID, ownerID)
+	return err
+}
+
+// DetachCourse clears the course of every task associated with it.
+// The version of the detached tasks is incremented, since their content changed.
+func (r *TaskRepository) DetachCourse(ctx context.Context, ownerID, courseID int64, updatedAt time.Time) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `
+		UPDATE tasks
+		SET course_id = NULL, updated_at = ?, version = version + 1
+		WHERE course_id = ? AND owner_id = ?
+	`, updatedAt.UTC().Format(time.RFC3339), courseID, ownerID)
+	return err
+}
+
+// taskSortColumn maps a domain sort field onto its database column.
+// An empty field falls back to the due date ordering.
+func taskSortColumn(field models.TaskSortField) (string, error) {
+	switch field {
+	case "", models.TaskSortDueDate:
+		return "due_date", nil
+	case models.TaskSortPriority:
+		return "priority", nil
+	case models.TaskSortCreatedAt:
+		return "created_at", nil
+	default:
+		return "", fmt.Errorf("unsupported task sort field %q", field)
+	}
+}
+
+// scanTasks maps every remaining row to a domain Task object and closes the rows.
+func scanTasks(rows *sql.Rows) ([]models.Task, error) {
 	defer rows.Close()
 
 	var tasks []models.Task
 	for rows.Next() {
-		var task models.Task
-		var dueDate, createdAt, updatedAt string
-		var status string
-
-		if err := rows.Scan(
-			&task.ID,
-			&task.Title,
-			&task.Description,
-			&dueDate,
-			&task.Priority,
-			&status,
-			&task.CourseID,
-			&createdAt,
-			&updatedAt,
-		); err != nil {
+		task, err := scanTask(rows)
+		if err != nil {
 			return nil, err
 		}
-
-		task.Status = models.TaskStatus(status)
-		task.DueDate, _ = time.Parse(time.RFC3339, dueDate)
-		task.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
-		task.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
-
-		tasks = append(tasks, task)
+		tasks = append(tasks, *task)
 	}
 
 	if err := rows.Err(); err != nil {
@@ -210,3 +379,83 @@ func (r *TaskRepository) GetByCourseID(ctx context.Context, courseID int64) ([]m
 
 	return tasks, nil
 }
+
+// scanTask maps a single row selected with taskColumns to a domain Task object.
+func scanTask(row rowScanner) (*models.Task, error) {
+	var task models.Task
+	var dueDate, createdAt, updatedAt string
+	var status string
+	var courseID, parentID, recurrenceID sql.NullInt64
+	var externalUID sql.NullString
+
+	if err := row.Scan(
+		&task.ID,
+		&task.OwnerID,
+		&task.Title,
+		&task.Description,
+		&dueDate,
+		&task.Priority,
+		&status,
+		&task.EstimatedHours,
+		&courseID,
+		&parentID,
+		&recurrenceID,
+		&externalUID,
+		&task.Version,
+		&createdAt,
+		&updatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	task.Status = models.TaskStatus(status)
+	task.CourseID = courseID.Int64
+	task.ParentID = parentID.Int64
+	task.RecurrenceID = recurrenceID.Int64
+	task.ExternalUID = externalUID.String
+	task.DueDate, _ = time.Parse(time.RFC3339, dueDate)
+	task.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	task.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
+
+	return &task, nil
+}
+
+// checkVersionedUpdate reports a version conflict when a versioned UPDATE matched no row.
+func checkVersionedUpdate(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return output.ErrVersionConflict
+	}
+	return nil
+}
+
+// checkVersionedDelete returns output.ErrVersionConflict if a versioned delete affected no row
+// while the row still exists, with another version. A row that is already gone isn't a conflict.
+func checkVersionedDelete(ctx context.Context, db dbtx, result sql.Result, table string, id int64) error {
+	affected, err := result.RowsAffected()
+	if err != nil || affected > 0 {
+		return err
+	}
+
+	var exists bool
+	if err := db.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM "+table+" WHERE id = ?)", id).Scan(&exists); err != nil {
+		return err
+	}
+	if exists {
+		return output.ErrVersionConflict
+	}
+	return nil
+}
+
+// nullableString maps the empty string used by the domain for "not set" to a SQL NULL.
+func nullableString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
+
+// nullableID maps the zero ID used by the domain for "no reference" to a SQL NULL.
+func nullableID(id int64) sql.NullInt64 {
+	return sql.NullInt64{Int64: id, Valid: id != 0}
+}
@@ -0,0 +1,76 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"uni-task-manager/internal/domain/models"
+	"uni-task-manager/internal/ports/output"
+)
+
+func TestTaskRepositoryDeleteChecksVersion(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		id        int64
+		version   int64
+		wantErr   error
+		wantTasks int
+	}{
+		{"current version deletes the task and its subtasks", 1, 2, nil, 1},
+		{"stale version keeps everything", 1, 1, output.ErrVersionConflict, 3},
+		{"missing task isn't a conflict", 9, 1, nil, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := openMigratedTestDB(t)
+			if _, err := db.Exec(`
+				INSERT INTO tasks (id, owner_id, title, description, due_date, priority, status, parent_id, version, created_at, updated_at) VALUES
+					(1, 1, 'Essay', '', '2030-01-01T09:00:00Z', 3, 'pending', NULL, 2, '2030-01-01T00:00:00Z', '2030-01-01T00:00:00Z'),
+					(2, 1, 'Outline', '', '2030-01-01T09:00:00Z', 3, 'pending', 1, 1, '2030-01-01T00:00:00Z', '2030-01-01T00:00:00Z'),
+					(3, 1, 'Lab', '', '2030-01-01T09:00:00Z', 3, 'pending', NULL, 1, '2030-01-01T00:00:00Z', '2030-01-01T00:00:00Z');
+			`); err != nil {
+				t.Fatal(err)
+			}
+
+			err := NewTaskRepository(db).Delete(ctx, 1, tt.id, tt.version)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Delete() error = %v, want %v", err, tt.wantErr)
+			}
+
+			var tasks int
+			if err := db.QueryRow("SELECT COUNT(*) FROM tasks").Scan(&tasks); err != nil {
+				t.Fatal(err)
+			}
+			if tasks != tt.wantTasks {
+				t.Errorf("got %d tasks after Delete(), want %d", tasks, tt.wantTasks)
+			}
+		})
+	}
+}
+
+func TestTaskRepositoryListDueBeforeSkipsUndatedTasks(t *testing.T) {
+	db := openMigratedTestDB(t)
+	if _, err := db.Exec(`
+		INSERT INTO tasks (id, owner_id, title, description, due_date, priority, status, version, created_at, updated_at) VALUES
+			(1, 1, 'Essay', '', '2030-01-01T09:00:00Z', 3, 'pending', 1, '2030-01-01T00:00:00Z', '2030-01-01T00:00:00Z'),
+			(2, 1, 'Reading', '', '0001-01-01T00:00:00Z', 3, 'pending', 1, '2030-01-01T00:00:00Z', '2030-01-01T00:00:00Z'),
+			(3, 1, 'Notes', '', '', 3, 'pending', 1, '2030-01-01T00:00:00Z', '2030-01-01T00:00:00Z'),
+			(4, 1, 'Exam', '', '2030-02-01T09:00:00Z', 3, 'pending', 1, '2030-01-01T00:00:00Z', '2030-01-01T00:00:00Z');
+	`); err != nil {
+		t.Fatal(err)
+	}
+
+	query := models.TaskQuery{DueBefore: time.Date(2030, 1, 15, 0, 0, 0, 0, time.UTC), Limit: 10}
+	tasks, err := NewTaskRepository(db).List(context.Background(), 1, query)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].ID != 1 {
+		t.Errorf("List() due before %v = %+v, want only task 1", query.DueBefore, tasks)
+	}
+}
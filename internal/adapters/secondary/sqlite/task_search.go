@@ -0,0 +1,130 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"uni-task-manager/internal/domain/models"
+)
+
+// Markers delimiting the matching terms in the snippets produced by the tasks_fts index.
+// Control characters don't occur in task text, so they cannot be confused with it.
+const (
+	snippetMatchStart = "\x02"
+	snippetMatchEnd   = "\x03"
+)
+
+// Search retrieves the tasks matching the full-text query through the tasks_fts index.
+// Every word of the query must match, the last one as a prefix so results show up while typing.
+// Results are ranked with bm25, weighting title matches over tags, course name and description.
+func (r *TaskRepository) Search(ctx context.Context, ownerID int64, query string, limit int) ([]models.TaskSearchResult, error) {
+	match := ftsQuery(query)
+	if match == "" {
+		return nil, nil
+	}
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT `+qualifiedColumns("t", taskColumns)+`,
+			snippet(tasks_fts, -1, ?, ?, '…', 12),
+			bm25(tasks_fts, 10.0, 2.0, 4.0, 6.0) AS score
+		FROM tasks_fts
+		JOIN tasks t ON t.id = tasks_fts.rowid
+		WHERE tasks_fts MATCH ? AND t.owner_id = ?
+		ORDER BY score ASC, t.id ASC
+		LIMIT ?
+	`, snippetMatchStart, snippetMatchEnd, match, ownerID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []models.TaskSearchResult
+	for rows.Next() {
+		var snippet string
+		var score float64
+		task, err := scanTask(scannerWithExtras{rows, []interface{}{&snippet, &score}})
+		if err != nil {
+			return nil, err
+		}
+		// bm25 scores are negative, better matches being more negative
+		results = append(results, models.TaskSearchResult{
+			Task:    *task,
+			Snippet: parseSnippet(snippet),
+			Score:   -score,
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// scannerWithExtras scans a row followed by additional columns, such as a task with its search rank.
+type scannerWithExtras struct {
+	row    rowScanner
+	extras []interface{}
+}
+
+// Scan implements rowScanner, appending the extra destinations to the given ones.
+func (s scannerWithExtras) Scan(dest ...interface{}) error {
+	return s.row.Scan(append(dest, s.extras...)...)
+}
+
+// ftsQuery turns free text into an FTS5 query matching every word of it. Words are quoted, so
+// punctuation and FTS5 operators in the input are searched literally instead of being parsed.
+func ftsQuery(text string) string {
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) == 0 {
+		return ""
+	}
+
+	terms := make([]string, len(words))
+	for i, word := range words {
+		terms[i] = `"` + word + `"`
+	}
+	terms[len(terms)-1] += "*"
+	return strings.Join(terms, " ")
+}
+
+// parseSnippet splits a snippet produced with the match markers into its parts.
+func parseSnippet(snippet string) []models.SnippetPart {
+	var parts []models.SnippetPart
+	for snippet != "" {
+		start := strings.Index(snippet, snippetMatchStart)
+		if start < 0 {
+			parts = append(parts, models.SnippetPart{Text: snippet})
+			break
+		}
+		if start > 0 {
+			parts = append(parts, models.SnippetPart{Text: snippet[:start]})
+		}
+		snippet = snippet[start+len(snippetMatchStart):]
+
+		end := strings.Index(snippet, snippetMatchEnd)
+		if end < 0 {
+			end = len(snippet)
+		}
+		parts = append(parts, models.SnippetPart{Text: snippet[:end], Match: true})
+		snippet = strings.TrimPrefix(snippet[end:], snippetMatchEnd)
+	}
+	return parts
+}
+
+// qualifiedColumns prefixes every column of a comma-separated list with a table alias.
+func qualifiedColumns(alias, columns string) string {
+	names := strings.Split(columns, ", ")
+	for i, name := range names {
+		names[i] = alias + "." + name
+	}
+	return strings.Join(names, ", ")
+}
+
+// placeholders returns a comma-separated list of n query parameters, for an IN clause.
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
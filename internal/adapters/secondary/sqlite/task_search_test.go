@@ -0,0 +1,29 @@
+package sqlite
+
+import "testing"
+
+func TestFTSQuery(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"single word is a prefix", "lab", `"lab"*`},
+		{"every word must match", "lab report", `"lab" "report"*`},
+		{"punctuation separates words", "c++ (exam)", `"c" "exam"*`},
+		{"operators are quoted", "essay OR NOT quiz", `"essay" "OR" "NOT" "quiz"*`},
+		{"quotes cannot escape", `"draft*`, `"draft"*`},
+		{"letters beyond ASCII", "Übung café", `"Übung" "café"*`},
+		{"digits are kept", "week 12", `"week" "12"*`},
+		{"nothing searchable", " -*- ", ""},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ftsQuery(tt.text); got != tt.want {
+				t.Errorf("ftsQuery(%q) = %s, want %s", tt.text, got, tt.want)
+			}
+		})
+	}
+}
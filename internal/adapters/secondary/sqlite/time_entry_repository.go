@@ -0,0 +1,217 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"uni-task-manager/internal/domain/models"
+)
+
+// timeEntryColumns lists the columns selected for every time entry query, in scanTimeEntry order
+const timeEntryColumns = "id, owner_id, task_id, started_at, ended_at, note, created_at"
+
+// timeEntrySeconds computes the length in seconds of a time entry, counting a running timer
+// until the time bound to the placeholder
+const timeEntrySeconds = "strftime('%s', COALESCE(e.ended_at, ?)) - strftime('%s', e.started_at)"
+
+// TimeEntryRepository implements output.TimeEntryRepository interface using SQLite as the storage backend.
+// A running timer is stored as an entry whose ended_at is NULL.
+type TimeEntryRepository struct {
+	db *sql.DB
+}
+
+// NewTimeEntryRepository creates a new instance of TimeEntryRepository with the provided database connection.
+func NewTimeEntryRepository(db *sql.DB) *TimeEntryRepository {
+	return &TimeEntryRepository{db: db}
+}
+
+// GetByTaskID retrieves the time entries of a specific task, latest first.
+func (r *TimeEntryRepository) GetByTaskID(ctx context.Context, ownerID, taskID int64) ([]models.TimeEntry, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT `+timeEntryColumns+`
+		FROM time_entries
+		WHERE task_id = ? AND owner_id = ?
+		ORDER BY started_at DESC, id DESC
+	`, taskID, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []models.TimeEntry
+	for rows.Next() {
+		entry, err := scanTimeEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, *entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// GetByID retrieves a specific time entry of the owner by its ID from the database.
+// Returns nil if the owner has no time entry with the given ID.
+func (r *TimeEntryRepository) GetByID(ctx context.Context, ownerID, id int64) (*models.TimeEntry, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, `
+		SELECT `+timeEntryColumns+`
+		FROM time_entries
+		WHERE id = ? AND owner_id = ?
+	`, id, ownerID)
+
+	entry, err := scanTimeEntry(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// GetRunning retrieves the time entry of the owner that has no end yet.
+// Returns nil if no timer is running.
+func (r *TimeEntryRepository) GetRunning(ctx context.Context, ownerID int64) (*models.TimeEntry, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, `
+		SELECT `+timeEntryColumns+`
+		FROM time_entries
+		WHERE ended_at IS NULL AND owner_id = ?
+	`, ownerID)
+
+	entry, err := scanTimeEntry(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// Create persists a new time entry in the database.
+// It sets the ID field of the entry with the generated ID.
+func (r *TimeEntryRepository) Create(ctx context.Context, entry *models.TimeEntry) error {
+	result, err := conn(ctx, r.db).ExecContext(ctx, `
+		INSERT INTO time_entries (owner_id, task_id, started_at, ended_at, note, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`,
+		entry.OwnerID,
+		entry.TaskID,
+		entry.StartedAt.UTC().Format(time.RFC3339),
+		nullableTime(entry.EndedAt),
+		entry.Note,
+		entry.CreatedAt.UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	entry.ID = id
+	return nil
+}
+
+// Update stores the end and the note of an existing time entry, e.g. when its timer is stopped.
+func (r *TimeEntryRepository) Update(ctx context.Context, entry *models.TimeEntry) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `
+		UPDATE time_entries
+		SET ended_at = ?, note = ?
+		WHERE id = ? AND owner_id = ?
+	`, nullableTime(entry.EndedAt), entry.Note, entry.ID, entry.OwnerID)
+	return err
+}
+
+// Delete removes a time entry of the owner from the database by its ID.
+func (r *TimeEntryRepository) Delete(ctx context.Context, ownerID, id int64) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, "DELETE FROM time_entries WHERE id = ? AND owner_id = ?", id, ownerID)
+	return err
+}
+
+// SumByTask totals the logged time of every task that has time entries.
+// Running timers count until now.
+func (r *TimeEntryRepository) SumByTask(ctx context.Context, ownerID int64, now time.Time) (map[int64]time.Duration, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT e.task_id, SUM(`+timeEntrySeconds+`)
+		FROM time_entries e
+		WHERE e.owner_id = ?
+		GROUP BY e.task_id
+	`, now.UTC().Format(time.RFC3339), ownerID)
+	if err != nil {
+		return nil, err
+	}
+	return scanDurationSums(rows)
+}
+
+// SumByCourse totals the logged time of the tasks of every course that has time entries.
+// Running timers count until now.
+func (r *TimeEntryRepository) SumByCourse(ctx context.Context, ownerID int64, now time.Time) (map[int64]time.Duration, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT t.course_id, SUM(`+timeEntrySeconds+`)
+		FROM time_entries e
+		JOIN tasks t ON t.id = e.task_id
+		WHERE e.owner_id = ? AND t.course_id IS NOT NULL
+		GROUP BY t.course_id
+	`, now.UTC().Format(time.RFC3339), ownerID)
+	if err != nil {
+		return nil, err
+	}
+	return scanDurationSums(rows)
+}
+
+// scanDurationSums maps rows of IDs and numbers of seconds to durations keyed by ID and closes the rows.
+func scanDurationSums(rows *sql.Rows) (map[int64]time.Duration, error) {
+	defer rows.Close()
+
+	sums := make(map[int64]time.Duration)
+	for rows.Next() {
+		var id, seconds int64
+		if err := rows.Scan(&id, &seconds); err != nil {
+			return nil, err
+		}
+		sums[id] = time.Duration(seconds) * time.Second
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return sums, nil
+}
+
+// scanTimeEntry maps a single row selected with timeEntryColumns to a domain TimeEntry object.
+func scanTimeEntry(row rowScanner) (*models.TimeEntry, error) {
+	var entry models.TimeEntry
+	var startedAt, createdAt string
+	var endedAt sql.NullString
+
+	if err := row.Scan(
+		&entry.ID,
+		&entry.OwnerID,
+		&entry.TaskID,
+		&startedAt,
+		&endedAt,
+		&entry.Note,
+		&createdAt,
+	); err != nil {
+		return nil, err
+	}
+
+	entry.StartedAt, _ = time.Parse(time.RFC3339, startedAt)
+	if endedAt.Valid {
+		entry.EndedAt, _ = time.Parse(time.RFC3339, endedAt.String)
+	}
+	entry.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+
+	return &entry, nil
+}
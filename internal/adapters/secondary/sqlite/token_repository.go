@@ -0,0 +1,163 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"uni-task-manager/internal/domain/models"
+)
+
+// apiTokenColumns lists the columns selected for every API token query, in scanAPIToken order
+const apiTokenColumns = "id, user_id, name, token_hash, scopes, last_used_at, expires_at, created_at"
+
+// APITokenRepository implements output.APITokenRepository interface using SQLite as the storage backend.
+// Only the hash of a token is stored, never the secret itself.
+type APITokenRepository struct {
+	db *sql.DB
+}
+
+// NewAPITokenRepository creates a new instance of APITokenRepository with the provided database connection.
+func NewAPITokenRepository(db *sql.DB) *APITokenRepository {
+	return &APITokenRepository{db: db}
+}
+
+// GetByID retrieves a specific token of the user by its ID from the database.
+// Returns nil if the user has no token with the given ID.
+func (r *APITokenRepository) GetByID(ctx context.Context, userID, id int64) (*models.APIToken, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, "SELECT "+apiTokenColumns+" FROM api_tokens WHERE id = ? AND user_id = ?", id, userID)
+
+	token, err := scanAPIToken(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// GetByTokenHash retrieves a token by the hash of its secret.
+// Returns nil if no token is found with the given hash.
+func (r *APITokenRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*models.APIToken, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, "SELECT "+apiTokenColumns+" FROM api_tokens WHERE token_hash = ?", tokenHash)
+
+	token, err := scanAPIToken(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// GetByUserID retrieves all tokens of a user from the database, newest first.
+func (r *APITokenRepository) GetByUserID(ctx context.Context, userID int64) ([]models.APIToken, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT `+apiTokenColumns+`
+		FROM api_tokens
+		WHERE user_id = ?
+		ORDER BY created_at DESC, id DESC
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tokens []models.APIToken
+	for rows.Next() {
+		token, err := scanAPIToken(rows)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, *token)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// Create persists a new token in the database.
+// It sets the ID field of the token object with the generated ID.
+func (r *APITokenRepository) Create(ctx context.Context, token *models.APIToken) error {
+	scopes := make([]string, len(token.Scopes))
+	for i, scope := range token.Scopes {
+		scopes[i] = string(scope)
+	}
+
+	result, err := conn(ctx, r.db).ExecContext(ctx, `
+		INSERT INTO api_tokens (user_id, name, token_hash, scopes, last_used_at, expires_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`,
+		token.UserID,
+		token.Name,
+		token.TokenHash,
+		strings.Join(scopes, " "),
+		nullableTime(token.LastUsedAt),
+		nullableTime(token.ExpiresAt),
+		token.CreatedAt.UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	token.ID = id
+	return nil
+}
+
+// Delete removes a token of the user from the database by its ID.
+func (r *APITokenRepository) Delete(ctx context.Context, userID, id int64) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, "DELETE FROM api_tokens WHERE id = ? AND user_id = ?", id, userID)
+	return err
+}
+
+// UpdateLastUsed records the moment the token last authenticated a request.
+func (r *APITokenRepository) UpdateLastUsed(ctx context.Context, id int64, usedAt time.Time) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, "UPDATE api_tokens SET last_used_at = ? WHERE id = ?", usedAt.UTC().Format(time.RFC3339), id)
+	return err
+}
+
+// scanAPIToken maps a single row selected with apiTokenColumns to a domain APIToken object.
+func scanAPIToken(row rowScanner) (*models.APIToken, error) {
+	var token models.APIToken
+	var scopes, createdAt string
+	var lastUsedAt, expiresAt sql.NullString
+
+	if err := row.Scan(
+		&token.ID,
+		&token.UserID,
+		&token.Name,
+		&token.TokenHash,
+		&scopes,
+		&lastUsedAt,
+		&expiresAt,
+		&createdAt,
+	); err != nil {
+		return nil, err
+	}
+
+	for _, scope := range strings.Fields(scopes) {
+		token.Scopes = append(token.Scopes, models.TokenScope(scope))
+	}
+	if lastUsedAt.Valid {
+		token.LastUsedAt, _ = time.Parse(time.RFC3339, lastUsedAt.String)
+	}
+	if expiresAt.Valid {
+		token.ExpiresAt, _ = time.Parse(time.RFC3339, expiresAt.String)
+	}
+	token.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+
+	return &token, nil
+}
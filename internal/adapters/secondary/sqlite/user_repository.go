@@ -0,0 +1,121 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"uni-task-manager/internal/domain/models"
+)
+
+// userColumns lists the columns selected for every user query, in scanUser order
+const userColumns = "id, email, name, password_hash, created_at, updated_at"
+
+// UserRepository implements output.UserRepository interface using SQLite as the storage backend.
+type UserRepository struct {
+	db *sql.DB
+}
+
+// NewUserRepository creates a new instance of UserRepository with the provided database connection.
+func NewUserRepository(db *sql.DB) *UserRepository {
+	return &UserRepository{db: db}
+}
+
+// GetByID retrieves a specific user by its ID from the database.
+// Returns nil if no user is found with the given ID.
+func (r *UserRepository) GetByID(ctx context.Context, id int64) (*models.User, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, "SELECT "+userColumns+" FROM users WHERE id = ?", id)
+
+	user, err := scanUser(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// GetByEmail retrieves a user by email address; the column collation makes the comparison case-insensitive.
+// Returns nil if no user is found with the given email address.
+func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, "SELECT "+userColumns+" FROM users WHERE email = ?", email)
+
+	user, err := scanUser(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// Count returns the number of registered users.
+func (r *UserRepository) Count(ctx context.Context) (int, error) {
+	var count int
+	err := conn(ctx, r.db).QueryRowContext(ctx, "SELECT COUNT(*) FROM users").Scan(&count)
+	return count, err
+}
+
+// Create persists a new user in the database.
+// It sets the ID field of the user object with the generated ID.
+func (r *UserRepository) Create(ctx context.Context, user *models.User) error {
+	result, err := conn(ctx, r.db).ExecContext(ctx, `
+		INSERT INTO users (email, name, password_hash, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+	`,
+		user.Email,
+		user.Name,
+		user.PasswordHash,
+		user.CreatedAt.UTC().Format(time.RFC3339),
+		user.UpdatedAt.UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	user.ID = id
+	return nil
+}
+
+// ClaimUnowned assigns every course, task and recurrence rule without an owner to the user, atomically.
+func (r *UserRepository) ClaimUnowned(ctx context.Context, userID int64) error {
+	return inTx(ctx, r.db, func(tx dbtx) error {
+		for _, table := range []string{"courses", "tasks", "task_recurrences"} {
+			if _, err := tx.ExecContext(ctx, "UPDATE "+table+" SET owner_id = ? WHERE owner_id IS NULL", userID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// scanUser maps a single row selected with userColumns to a domain User object.
+func scanUser(row rowScanner) (*models.User, error) {
+	var user models.User
+	var createdAt, updatedAt string
+
+	if err := row.Scan(
+		&user.ID,
+		&user.Email,
+		&user.Name,
+		&user.PasswordHash,
+		&createdAt,
+		&updatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	user.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	user.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
+
+	return &user, nil
+}
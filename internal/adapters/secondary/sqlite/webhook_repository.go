@@ -0,0 +1,292 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"uni-task-manager/internal/domain/models"
+)
+
+// webhookColumns lists the columns selected for every webhook query, in scanWebhook order
+const webhookColumns = "id, owner_id, url, secret, events, active, created_at, updated_at"
+
+// webhookDeliveryColumns lists the columns selected for every webhook delivery query, in scanWebhookDelivery order
+const webhookDeliveryColumns = "id, webhook_id, owner_id, event_id, event_type, payload, status, attempts, " +
+	"next_attempt_at, response_status, last_error, created_at, updated_at"
+
+// WebhookRepository implements output.WebhookRepository interface using SQLite as the storage backend.
+// The subscribed events of a webhook are stored as a comma-separated list.
+type WebhookRepository struct {
+	db *sql.DB
+}
+
+// NewWebhookRepository creates a new instance of WebhookRepository with the provided database connection.
+func NewWebhookRepository(db *sql.DB) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+// GetAll retrieves all webhooks of the owner, in creation order.
+func (r *WebhookRepository) GetAll(ctx context.Context, ownerID int64) ([]models.Webhook, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT `+webhookColumns+`
+		FROM webhooks
+		WHERE owner_id = ?
+		ORDER BY id ASC
+	`, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var webhooks []models.Webhook
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, *webhook)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+// GetByID retrieves a specific webhook of the owner by its ID from the database.
+// Returns nil if the owner has no webhook with the given ID.
+func (r *WebhookRepository) GetByID(ctx context.Context, ownerID, id int64) (*models.Webhook, error) {
+	row := conn(ctx, r.db).QueryRowContext(ctx, `
+		SELECT `+webhookColumns+`
+		FROM webhooks
+		WHERE id = ? AND owner_id = ?
+	`, id, ownerID)
+
+	webhook, err := scanWebhook(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+// Create persists a new webhook in the database.
+// It sets the ID field of the webhook with the generated ID.
+func (r *WebhookRepository) Create(ctx context.Context, webhook *models.Webhook) error {
+	result, err := conn(ctx, r.db).ExecContext(ctx, `
+		INSERT INTO webhooks (owner_id, url, secret, events, active, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`,
+		webhook.OwnerID,
+		webhook.URL,
+		webhook.Secret,
+		strings.Join(webhook.Events, ","),
+		webhook.Active,
+		webhook.CreatedAt.UTC().Format(time.RFC3339),
+		webhook.UpdatedAt.UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	webhook.ID = id
+	return nil
+}
+
+// Update stores the URL, events and active flag of an existing webhook; the secret never changes.
+func (r *WebhookRepository) Update(ctx context.Context, webhook *models.Webhook) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `
+		UPDATE webhooks
+		SET url = ?, events = ?, active = ?, updated_at = ?
+		WHERE id = ? AND owner_id = ?
+	`,
+		webhook.URL,
+		strings.Join(webhook.Events, ","),
+		webhook.Active,
+		webhook.UpdatedAt.UTC().Format(time.RFC3339),
+		webhook.ID,
+		webhook.OwnerID,
+	)
+	return err
+}
+
+// Delete removes a webhook of the owner from the database by its ID; its deliveries cascade.
+func (r *WebhookRepository) Delete(ctx context.Context, ownerID, id int64) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, "DELETE FROM webhooks WHERE id = ? AND owner_id = ?", id, ownerID)
+	return err
+}
+
+// CreateDelivery persists a new webhook delivery in the database.
+// It sets the ID field of the delivery with the generated ID.
+func (r *WebhookRepository) CreateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	result, err := conn(ctx, r.db).ExecContext(ctx, `
+		INSERT INTO webhook_deliveries (webhook_id, owner_id, event_id, event_type, payload, status, attempts,
+			next_attempt_at, response_status, last_error, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`,
+		delivery.WebhookID,
+		delivery.OwnerID,
+		delivery.EventID,
+		string(delivery.EventType),
+		delivery.Payload,
+		string(delivery.Status),
+		delivery.Attempts,
+		nullableTime(delivery.NextAttemptAt),
+		delivery.ResponseStatus,
+		delivery.LastError,
+		delivery.CreatedAt.UTC().Format(time.RFC3339),
+		delivery.UpdatedAt.UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	delivery.ID = id
+	return nil
+}
+
+// UpdateDelivery stores the outcome of an attempt to deliver an event.
+func (r *WebhookRepository) UpdateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	_, err := conn(ctx, r.db).ExecContext(ctx, `
+		UPDATE webhook_deliveries
+		SET status = ?, attempts = ?, next_attempt_at = ?, response_status = ?, last_error = ?, updated_at = ?
+		WHERE id = ?
+	`,
+		string(delivery.Status),
+		delivery.Attempts,
+		nullableTime(delivery.NextAttemptAt),
+		delivery.ResponseStatus,
+		delivery.LastError,
+		delivery.UpdatedAt.UTC().Format(time.RFC3339),
+		delivery.ID,
+	)
+	return err
+}
+
+// GetDeliveries retrieves the latest deliveries to a webhook of the owner, newest first.
+func (r *WebhookRepository) GetDeliveries(ctx context.Context, ownerID, webhookID int64, limit int) ([]models.WebhookDelivery, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT `+webhookDeliveryColumns+`
+		FROM webhook_deliveries
+		WHERE webhook_id = ? AND owner_id = ?
+		ORDER BY id DESC
+		LIMIT ?
+	`, webhookID, ownerID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanWebhookDeliveries(rows)
+}
+
+// GetDueDeliveries retrieves the pending deliveries of every owner to attempt by now, oldest first.
+func (r *WebhookRepository) GetDueDeliveries(ctx context.Context, now time.Time, limit int) ([]models.WebhookDelivery, error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, `
+		SELECT `+webhookDeliveryColumns+`
+		FROM webhook_deliveries
+		WHERE status = 'pending' AND next_attempt_at <= ?
+		ORDER BY next_attempt_at ASC, id ASC
+		LIMIT ?
+	`, now.UTC().Format(time.RFC3339), limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanWebhookDeliveries(rows)
+}
+
+// scanWebhookDeliveries maps rows selected with webhookDeliveryColumns to domain objects and closes the rows.
+func scanWebhookDeliveries(rows *sql.Rows) ([]models.WebhookDelivery, error) {
+	defer rows.Close()
+
+	var deliveries []models.WebhookDelivery
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, *delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// scanWebhook maps a single row selected with webhookColumns to a domain Webhook object.
+func scanWebhook(row rowScanner) (*models.Webhook, error) {
+	var webhook models.Webhook
+	var events, createdAt, updatedAt string
+
+	if err := row.Scan(
+		&webhook.ID,
+		&webhook.OwnerID,
+		&webhook.URL,
+		&webhook.Secret,
+		&events,
+		&webhook.Active,
+		&createdAt,
+		&updatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if events != "" {
+		webhook.Events = strings.Split(events, ",")
+	}
+	webhook.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	webhook.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
+
+	return &webhook, nil
+}
+
+// scanWebhookDelivery maps a single row selected with webhookDeliveryColumns to a domain WebhookDelivery object.
+func scanWebhookDelivery(row rowScanner) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	var eventType, status, createdAt, updatedAt string
+	var nextAttemptAt sql.NullString
+
+	if err := row.Scan(
+		&delivery.ID,
+		&delivery.WebhookID,
+		&delivery.OwnerID,
+		&delivery.EventID,
+		&eventType,
+		&delivery.Payload,
+		&status,
+		&delivery.Attempts,
+		&nextAttemptAt,
+		&delivery.ResponseStatus,
+		&delivery.LastError,
+		&createdAt,
+		&updatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	delivery.EventType = models.EventType(eventType)
+	delivery.Status = models.WebhookDeliveryStatus(status)
+	if nextAttemptAt.Valid {
+		delivery.NextAttemptAt, _ = time.Parse(time.RFC3339, nextAttemptAt.String)
+	}
+	delivery.CreatedAt, _ = time.Parse(time.RFC3339, createdAt)
+	delivery.UpdatedAt, _ = time.Parse(time.RFC3339, updatedAt)
+
+	return &delivery, nil
+}
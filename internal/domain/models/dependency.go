@@ -0,0 +1,14 @@
+package models
+
+// TaskDependencies describes the place of a task in the dependency graph: the tasks it waits
+// for and the tasks waiting for it.
+type TaskDependencies struct {
+	// Prerequisites are the tasks that must be completed before the task, ordered by due date
+	Prerequisites []Task
+
+	// Dependents are the tasks that can't be completed before the task, ordered by due date
+	Dependents []Task
+
+	// Blocked reports whether the task is unfinished and waits for an unfinished prerequisite
+	Blocked bool
+}
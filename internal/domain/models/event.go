@@ -0,0 +1,104 @@
+package models
+
+import "time"
+
+// EventType names a kind of domain event, e.g. "task.created".
+type EventType string
+
+const (
+	// EventTaskCreated is published when a task is created, including occurrences of recurring tasks
+	EventTaskCreated EventType = "task.created"
+
+	// EventTaskUpdated is published whenever a task is changed
+	EventTaskUpdated EventType = "task.updated"
+
+	// EventTaskStatusChanged is published, besides EventTaskUpdated, when a change moves a task to another status
+	EventTaskStatusChanged EventType = "task.status_changed"
+
+	// EventTaskDeleted is published when a task is deleted
+	EventTaskDeleted EventType = "task.deleted"
+
+	// EventCourseCreated is published when a course is created
+	EventCourseCreated EventType = "course.created"
+
+	// EventCourseUpdated is published whenever a course is changed
+	EventCourseUpdated EventType = "course.updated"
+
+	// EventCourseDeleted is published when a course is deleted
+	EventCourseDeleted EventType = "course.deleted"
+)
+
+// EventTypes lists every event type that is published
+var EventTypes = []EventType{
+	EventTaskCreated,
+	EventTaskUpdated,
+	EventTaskStatusChanged,
+	EventTaskDeleted,
+	EventCourseCreated,
+	EventCourseUpdated,
+	EventCourseDeleted,
+}
+
+// Event is something that happened to a task or course of a user, as told to subscribers.
+type Event struct {
+	// ID uniquely identifies the event, so subscribers can ignore duplicates
+	ID string
+
+	// Type is the kind of event
+	Type EventType
+
+	// OwnerID references the user whose task or course the event is about
+	OwnerID int64
+
+	// OccurredAt is when the event happened
+	OccurredAt time.Time
+
+	// Task is the state of the task after the event, or before it for deletions (nil for course events)
+	Task *Task
+
+	// PreviousStatus is the status the task had before a status change
+	PreviousStatus TaskStatus
+
+	// Course is the state of the course after the event, or before it for deletions (nil for task events)
+	Course *Course
+}
+
+// OutboxMessage is an event recorded in the outbox in the same transaction as the change it
+// reports, waiting to be handed to the event subscribers.
+type OutboxMessage struct {
+	// ID uniquely identifies the message and orders the messages
+	ID int64
+
+	// EventID identifies the recorded event
+	EventID string
+
+	// EventType is the kind of the recorded event
+	EventType EventType
+
+	// OwnerID references the user whose task or course the event is about
+	OwnerID int64
+
+	// Payload is the JSON representation of the event, as posted to webhooks
+	Payload string
+
+	// Attempts counts the failed attempts to dispatch the message
+	Attempts int
+
+	// NextAttemptAt is when the message is dispatched next
+	NextAttemptAt time.Time
+
+	// LastError describes why the last attempt failed
+	LastError string
+
+	// CreatedAt tracks when the event was recorded
+	CreatedAt time.Time
+}
+
+// OutboxRun summarizes a pass of the outbox dispatcher.
+type OutboxRun struct {
+	// Dispatched counts the messages handled by every subscriber and removed from the outbox
+	Dispatched int
+
+	// Retrying counts the messages whose dispatch failed and will be retried
+	Retrying int
+}
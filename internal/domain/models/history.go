@@ -0,0 +1,64 @@
+package models
+
+import "time"
+
+// TaskChangeAction is the kind of change recorded in the history of a task.
+type TaskChangeAction string
+
+const (
+	// TaskChangeUpdated records an edit of the task
+	TaskChangeUpdated TaskChangeAction = "updated"
+
+	// TaskChangeRestored records an edit bringing back the state of the task before an earlier change
+	TaskChangeRestored TaskChangeAction = "restored"
+
+	// TaskChangeDeleted records the deletion of the task
+	TaskChangeDeleted TaskChangeAction = "deleted"
+)
+
+// FieldChange is the change of a single field of a task, with both values formatted as text
+// (times in RFC 3339, empty for no value).
+type FieldChange struct {
+	// Field is the name of the changed field, as in the API (e.g. "due_date")
+	Field string
+
+	// Old is the value before the change
+	Old string
+
+	// New is the value after the change
+	New string
+}
+
+// TaskHistoryEntry records a change of a task: who made it and when, what changed, and the
+// state of the task before the change, which restoring the entry brings back.
+type TaskHistoryEntry struct {
+	// ID uniquely identifies the entry and orders the entries
+	ID int64
+
+	// TaskID references the changed task; it is kept once the task is deleted
+	TaskID int64
+
+	// OwnerID references the user the task belongs to
+	OwnerID int64
+
+	// ActorID references the user who made the change
+	ActorID int64
+
+	// ActorEmail is the email of the user who made the change (empty if the account is gone)
+	ActorEmail string
+
+	// Action is the kind of change
+	Action TaskChangeAction
+
+	// Version is the version of the task before the change
+	Version int64
+
+	// Changes lists the changed fields; it is empty for deletions
+	Changes []FieldChange
+
+	// Previous is the state of the task before the change
+	Previous Task
+
+	// CreatedAt is when the change was made
+	CreatedAt time.Time
+}
@@ -0,0 +1,91 @@
+package models
+
+import "time"
+
+// CalendarItem is a deadline read from an external calendar (a VEVENT or VTODO entry),
+// decoupled from the calendar file format.
+type CalendarItem struct {
+	// UID is the globally unique identifier of the entry, used to recognize re-imports
+	UID string
+
+	// Summary is the one-line title of the entry
+	Summary string
+
+	// Description provides the detailed text of the entry
+	Description string
+
+	// Categories lists the categories the entry is filed under
+	Categories []string
+
+	// Organizer is the display name of the organizer, typically the professor
+	Organizer string
+
+	// Due is the deadline of the entry
+	Due time.Time
+
+	// Priority is the entry's priority on the task scale (1-5), 0 if unspecified
+	Priority int
+
+	// Status is the entry's status on the task scale, empty if unspecified
+	Status TaskStatus
+
+	// Cancelled reports whether the entry was cancelled by its organizer
+	Cancelled bool
+}
+
+// CourseMatchMode selects how imported calendar items are assigned to courses
+type CourseMatchMode string
+
+// Course match mode constants define where the course name of an imported item comes from
+const (
+	// CourseMatchCategory uses the first category of the item as the course name
+	CourseMatchCategory CourseMatchMode = "category"
+
+	// CourseMatchPrefix uses the part of the summary before the separator as the course name
+	CourseMatchPrefix CourseMatchMode = "prefix"
+
+	// CourseMatchNone doesn't derive a course from the item
+	CourseMatchNone CourseMatchMode = "none"
+)
+
+// ImportOptions configures how calendar items are mapped onto tasks and courses.
+type ImportOptions struct {
+	// CourseMatch selects where course names come from (defaults to CourseMatchPrefix)
+	CourseMatch CourseMatchMode
+
+	// PrefixSeparator separates the course name from the title in prefix mode (defaults to ":")
+	PrefixSeparator string
+
+	// CourseID assigns every imported task to this course, overriding CourseMatch (optional)
+	CourseID int64
+}
+
+// ImportResult summarizes the outcome of a calendar import.
+type ImportResult struct {
+	// Created is the number of new tasks
+	Created int
+
+	// Updated is the number of previously imported tasks that changed
+	Updated int
+
+	// Unchanged is the number of previously imported tasks that were already up to date
+	Unchanged int
+
+	// CoursesCreated is the number of courses created for unknown course names
+	CoursesCreated int
+
+	// Skipped lists the items that could not be imported
+	Skipped []ImportSkip
+}
+
+// ImportSkip describes a calendar item that was not imported and why.
+type ImportSkip struct {
+	// UID identifies the skipped item
+	UID string
+
+	// Summary is the title of the skipped item
+	Summary string
+
+	// Reason explains why the item was skipped
+	Reason string
+}
@@ -0,0 +1,88 @@
+package models
+
+import "time"
+
+// AvailabilityWindow is a weekly time slot in which a user is free to study.
+// Windows repeat every week on their weekday; times are offsets from midnight UTC.
+type AvailabilityWindow struct {
+	// ID uniquely identifies the window
+	ID int64
+
+	// OwnerID references the user the window belongs to
+	OwnerID int64
+
+	// Weekday is the day of the week the window recurs on
+	Weekday time.Weekday
+
+	// Start is the time of day the window opens, as an offset from midnight
+	Start time.Duration
+
+	// End is the time of day the window closes, as an offset from midnight (at most 24 hours)
+	End time.Duration
+
+	// CreatedAt tracks when the window was created
+	CreatedAt time.Time
+}
+
+// StudyPlan is a day-by-day schedule of study sessions for the open estimated tasks of a user.
+type StudyPlan struct {
+	// From is the first day of the plan (midnight UTC)
+	From time.Time
+
+	// To is the last day of the plan (midnight UTC)
+	To time.Time
+
+	// MaxHoursPerDay is the most hours of study planned on a single day
+	MaxHoursPerDay float64
+
+	// Days holds the sessions planned on every day of the plan, in order
+	Days []PlanDay
+
+	// Unscheduled holds the tasks whose remaining effort doesn't fit in the availability before they are due
+	Unscheduled []UnscheduledTask
+}
+
+// PlanDay is the study planned on a single day.
+type PlanDay struct {
+	// Date is the day (midnight UTC)
+	Date time.Time
+
+	// Hours is the number of hours of study planned on the day
+	Hours float64
+
+	// Sessions holds the sessions of the day, in chronological order
+	Sessions []StudySession
+}
+
+// StudySession is a block of time planned to work on a task.
+type StudySession struct {
+	// TaskID references the task to work on
+	TaskID int64
+
+	// Title is the title of the task
+	Title string
+
+	// CourseID references the course of the task (zero if none)
+	CourseID int64
+
+	// Start is when the session starts
+	Start time.Time
+
+	// End is when the session ends
+	End time.Time
+}
+
+// UnscheduledTask is a task whose remaining effort couldn't be planned before its due date.
+type UnscheduledTask struct {
+	// TaskID references the task
+	TaskID int64
+
+	// Title is the title of the task
+	Title string
+
+	// DueDate is when the task is due
+	DueDate time.Time
+
+	// Hours is the number of hours of remaining effort left unplanned
+	Hours float64
+}
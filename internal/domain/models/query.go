@@ -0,0 +1,75 @@
+package models
+
+import "time"
+
+// TaskSortField identifies the attribute used to order task listings
+type TaskSortField string
+
+// Task sort field constants define the supported orderings of a task listing
+const (
+	// TaskSortDueDate orders tasks by their due date
+	TaskSortDueDate TaskSortField = "due_date"
+
+	// TaskSortPriority orders tasks by their priority
+	TaskSortPriority TaskSortField = "priority"
+
+	// TaskSortCreatedAt orders tasks by their creation time
+	TaskSortCreatedAt TaskSortField = "created_at"
+)
+
+// TaskQuery describes the filtering, ordering and pagination criteria for a task listing.
+// Zero values mean "no constraint" for every filter field.
+type TaskQuery struct {
+	// Status restricts the listing to tasks in the given state
+	Status TaskStatus
+
+	// CourseID restricts the listing to tasks of the given course
+	CourseID int64
+
+	// Tag restricts the listing to tasks carrying the tag with the given name
+	Tag string
+
+	// PriorityMin restricts the listing to tasks with at least this priority
+	PriorityMin int
+
+	// DueBefore restricts the listing to tasks due strictly before this time; tasks without
+	// a due date are left out
+	DueBefore time.Time
+
+	// DueAfter restricts the listing to tasks due strictly after this time
+	DueAfter time.Time
+
+	// Sort selects the ordering of the listing (defaults to due date)
+	Sort TaskSortField
+
+	// Descending reverses the ordering of the listing
+	Descending bool
+
+	// Limit caps the number of tasks returned in a single page
+	Limit int
+
+	// Cursor is the opaque position returned as NextCursor by a previous page
+	Cursor string
+
+	// After is the decoded form of Cursor, set by the domain layer before querying storage
+	After *TaskCursor
+}
+
+// TaskCursor identifies the last task of a page by its sort value and ID,
+// so the next page can resume right after it (keyset pagination).
+type TaskCursor struct {
+	// Value is the sort attribute of the last task (int for priority, RFC3339 string for dates)
+	Value interface{}
+
+	// ID breaks ties between tasks sharing the same sort value
+	ID int64
+}
+
+// TaskPage is a single page of a task listing
+type TaskPage struct {
+	// Tasks contains the tasks of this page in the requested order
+	Tasks []Task
+
+	// NextCursor is the position of the following page, empty on the last page
+	NextCursor string
+}
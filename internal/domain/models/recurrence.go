@@ -0,0 +1,64 @@
+package models
+
+import "time"
+
+// RecurrenceFrequency represents the base unit at which a recurring task repeats
+type RecurrenceFrequency string
+
+// Recurrence frequency constants define the supported repetition units
+const (
+	// RecurrenceDaily repeats a task every Interval days
+	RecurrenceDaily RecurrenceFrequency = "daily"
+
+	// RecurrenceWeekly repeats a task every Interval weeks, optionally on several weekdays
+	RecurrenceWeekly RecurrenceFrequency = "weekly"
+
+	// RecurrenceMonthly repeats a task every Interval months on the same day of the month
+	RecurrenceMonthly RecurrenceFrequency = "monthly"
+)
+
+// Recurrence is an RRULE-style rule describing how a series of tasks repeats.
+// Every task of the series references the rule through Task.RecurrenceID.
+type Recurrence struct {
+	// ID uniquely identifies the recurrence rule (and therefore the series)
+	ID int64
+
+	// OwnerID references the user the series belongs to
+	OwnerID int64
+
+	// Frequency is the repetition unit (daily, weekly, monthly)
+	Frequency RecurrenceFrequency
+
+	// Interval is the number of units between occurrences (defaults to 1)
+	Interval int
+
+	// ByWeekday lists the weekdays on which a weekly series occurs (optional)
+	ByWeekday []time.Weekday
+
+	// Until is the last moment an occurrence may fall on (optional, exclusive with Count)
+	Until time.Time
+
+	// Count caps the total number of tasks in the series (optional, exclusive with Until)
+	Count int
+
+	// Occurrences is the number of tasks the series has generated, including deleted ones
+	Occurrences int
+
+	// ExDates lists days on which no occurrence is created
+	ExDates []time.Time
+
+	// CreatedAt tracks when the rule was created
+	CreatedAt time.Time
+
+	// UpdatedAt tracks the last modification time
+	UpdatedAt time.Time
+}
+
+// TaskSeries groups a recurrence rule with the tasks materialized from it.
+type TaskSeries struct {
+	// Recurrence is the rule generating the series
+	Recurrence *Recurrence
+
+	// Tasks are the occurrences created so far, ordered by due date
+	Tasks []Task
+}
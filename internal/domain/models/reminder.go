@@ -0,0 +1,101 @@
+package models
+
+import "time"
+
+// ReminderRule describes when reminders about a task are sent, relative to its due date.
+type ReminderRule struct {
+	// Name identifies the rule in the delivery state, e.g. "due-48h"
+	Name string
+
+	// Offset is when the first reminder is sent relative to the due date (negative before it)
+	Offset time.Duration
+
+	// Repeat is the interval at which the reminder is sent again (zero to send it once)
+	Repeat time.Duration
+}
+
+// Notification is a message to a user, delivered through every configured channel.
+type Notification struct {
+	// UserID references the recipient
+	UserID int64
+
+	// Email is the address of the recipient
+	Email string
+
+	// Name is the display name of the recipient
+	Name string
+
+	// TaskID references the task the notification is about
+	TaskID int64
+
+	// Title is the title of the task
+	Title string
+
+	// DueDate is when the task is due
+	DueDate time.Time
+
+	// Rule names the reminder rule that triggered the notification
+	Rule string
+
+	// Subject is a one-line summary of the notification
+	Subject string
+
+	// Body is the plain-text message
+	Body string
+
+	// ScheduledAt is when the notification was due to be sent
+	ScheduledAt time.Time
+}
+
+// DeliveryStatus is the state of a reminder on a channel.
+type DeliveryStatus string
+
+const (
+	// DeliveryStatusSent indicates that the reminder was delivered
+	DeliveryStatusSent DeliveryStatus = "sent"
+
+	// DeliveryStatusFailed indicates that the last attempt failed; it is retried until the attempts run out
+	DeliveryStatusFailed DeliveryStatus = "failed"
+)
+
+// ReminderDelivery records the delivery of a reminder on a channel.
+type ReminderDelivery struct {
+	// ID uniquely identifies the delivery
+	ID int64
+
+	// OwnerID references the user the reminder is for
+	OwnerID int64
+
+	// TaskID references the task the reminder is about
+	TaskID int64
+
+	// Rule names the reminder rule
+	Rule string
+
+	// ScheduledAt is when the reminder was due to be sent
+	ScheduledAt time.Time
+
+	// Channel names the channel the reminder is delivered through
+	Channel string
+
+	// Status is the outcome of the last attempt
+	Status DeliveryStatus
+
+	// Attempts counts the delivery attempts
+	Attempts int
+
+	// LastError describes why the last attempt failed
+	LastError string
+
+	// UpdatedAt tracks the last attempt
+	UpdatedAt time.Time
+}
+
+// ReminderRun summarizes a pass of the reminder scheduler.
+type ReminderRun struct {
+	// Sent counts the deliveries that succeeded
+	Sent int
+
+	// Failed counts the deliveries that failed and will be retried or given up on
+	Failed int
+}
@@ -0,0 +1,22 @@
+package models
+
+// TaskSearchResult is a task matching a full-text search, ranked by relevance.
+type TaskSearchResult struct {
+	// Task is the matching task
+	Task Task
+
+	// Snippet is an excerpt of the best matching text, split into matching and surrounding parts
+	Snippet []SnippetPart
+
+	// Score measures the relevance of the match; higher is more relevant
+	Score float64
+}
+
+// SnippetPart is a piece of a search snippet.
+type SnippetPart struct {
+	// Text is the content of the piece
+	Text string
+
+	// Match reports whether the piece matched a search term
+	Match bool
+}
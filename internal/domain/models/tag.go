@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// Tag is a label that categorizes tasks across courses, such as "exam" or "reading".
+// Tag names are unique per owner and stored in lower case.
+type Tag struct {
+	// ID uniquely identifies the tag
+	ID int64
+
+	// OwnerID references the user the tag belongs to
+	OwnerID int64
+
+	// Name is the label shown on tagged tasks
+	Name string
+
+	// CreatedAt tracks when the tag was created
+	CreatedAt time.Time
+}
@@ -9,6 +9,9 @@ type Task struct {
 	// ID uniquely identifies the task
 	ID int64
 
+	// OwnerID references the user the task belongs to
+	OwnerID int64
+
 	// Title is the name or short description of the task
 	Title string
 
@@ -24,9 +27,24 @@ type Task struct {
 	// Status indicates the current state of the task (pending, in progress, completed)
 	Status TaskStatus
 
+	// EstimatedHours is the expected effort to complete the task (zero if not estimated)
+	EstimatedHours float64
+
 	// CourseID references the associated course (optional)
 	CourseID int64
 
+	// ParentID references the parent task when this task is a subtask (optional)
+	ParentID int64
+
+	// RecurrenceID references the recurrence rule of the series this task belongs to (optional)
+	RecurrenceID int64
+
+	// ExternalUID is the calendar UID of an imported task, used to update it on re-import (optional)
+	ExternalUID string
+
+	// Version is incremented on every update and guards against concurrent modifications
+	Version int64
+
 	// CreatedAt tracks when the task was created
 	CreatedAt time.Time
 
@@ -49,21 +67,103 @@ const (
 	TaskStatusCompleted TaskStatus = "completed"
 )
 
+// TaskPatch describes a partial update of a task. Nil fields are left untouched;
+// a non-nil field replaces the stored value. Only the description, the estimate and the
+// course can be cleared with a zero value; the other fields are required.
+type TaskPatch struct {
+	// Title replaces the title of the task; it cannot be empty
+	Title *string
+
+	// Description replaces the description of the task
+	Description *string
+
+	// DueDate replaces the due date; it cannot be the zero time
+	DueDate *time.Time
+
+	// Priority replaces the priority of the task
+	Priority *int
+
+	// Status replaces the status of the task; it cannot be empty
+	Status *TaskStatus
+
+	// EstimatedHours replaces the estimated effort; zero removes the estimate
+	EstimatedHours *float64
+
+	// CourseID replaces the associated course; zero detaches the task from its course
+	CourseID *int64
+
+	// Version is the version of the task the patch is based on; zero skips the check
+	Version int64
+}
+
+// TaskProgress summarizes how much of a task has been completed through its subtasks.
+type TaskProgress struct {
+	// Total is the number of subtasks of the task
+	Total int
+
+	// Completed is the number of subtasks in the completed state
+	Completed int
+
+	// Percent is the completion percentage (0-100) of the task
+	Percent int
+}
+
 // Course represents a university course that can have multiple associated tasks.
 // It tracks basic course information including the professor teaching it.
 type Course struct {
 	// ID uniquely identifies the course
 	ID int64
 
+	// OwnerID references the user the course belongs to
+	OwnerID int64
+
 	// Name is the title of the course (e.g., "Computer Science 101")
 	Name string
 
 	// Professor is the name of the instructor teaching the course
 	Professor string
 
+	// Version is incremented on every update and guards against concurrent modifications
+	Version int64
+
 	// CreatedAt tracks when the course was added to the system
 	CreatedAt time.Time
 
 	// UpdatedAt tracks the last modification time
 	UpdatedAt time.Time
 }
+
+// CourseStats summarizes the progress of the tasks of a course.
+type CourseStats struct {
+	// Total is the number of tasks of the course, subtasks included
+	Total int
+
+	// Pending, InProgress and Completed count the tasks in each status
+	Pending    int
+	InProgress int
+	Completed  int
+
+	// Overdue counts the tasks past their due date that aren't completed
+	Overdue int
+
+	// Percent is the completion percentage (0-100) of the course
+	Percent int
+
+	// NextDeadline is the earliest upcoming due date of an unfinished task (zero if none)
+	NextDeadline time.Time
+}
+
+// CourseDeletePolicy decides what happens to the tasks of a course when the course is deleted
+type CourseDeletePolicy string
+
+// Course delete policy constants
+const (
+	// CourseDeleteRestrict refuses to delete a course that still has tasks
+	CourseDeleteRestrict CourseDeletePolicy = "restrict"
+
+	// CourseDeleteCascade deletes the tasks of the course together with their subtasks
+	CourseDeleteCascade CourseDeletePolicy = "cascade"
+
+	// CourseDeleteDetach keeps the tasks of the course, without a course
+	CourseDeleteDetach CourseDeletePolicy = "detach"
+)
@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+// TimeEntry records a session of work on a task, either timed with a start/stop timer
+// or logged manually afterwards.
+type TimeEntry struct {
+	// ID uniquely identifies the time entry
+	ID int64
+
+	// OwnerID references the user who logged the time
+	OwnerID int64
+
+	// TaskID references the task the time was spent on
+	TaskID int64
+
+	// StartedAt is when the session started
+	StartedAt time.Time
+
+	// EndedAt is when the session ended (zero while the timer is running)
+	EndedAt time.Time
+
+	// Duration is the length of the session; for a running timer, the time elapsed so far
+	Duration time.Duration
+
+	// Note optionally describes what was done during the session
+	Note string
+
+	// CreatedAt tracks when the entry was created
+	CreatedAt time.Time
+}
+
+// Running reports whether the entry is a timer that hasn't been stopped yet.
+func (e *TimeEntry) Running() bool {
+	return e.EndedAt.IsZero()
+}
+
+// TimeTotals aggregates the time logged by a user.
+type TimeTotals struct {
+	// Total is the time logged on all tasks
+	Total time.Duration
+
+	// ByTask is the time logged on every task that has any, keyed by task ID
+	ByTask map[int64]time.Duration
+
+	// ByCourse is the time logged on the tasks of every course that has any, keyed by course ID
+	ByCourse map[int64]time.Duration
+}
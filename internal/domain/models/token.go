@@ -0,0 +1,60 @@
+package models
+
+import "time"
+
+// TokenScope names a permission granted to an API token
+type TokenScope string
+
+// Token scope constants define the permissions an API token can be granted
+const (
+	// ScopeTasksRead allows reading tasks, courses and their related data
+	ScopeTasksRead TokenScope = "tasks:read"
+
+	// ScopeTasksWrite allows creating, changing and deleting tasks, courses and their related data
+	ScopeTasksWrite TokenScope = "tasks:write"
+)
+
+// APIToken represents a personal access token used by scripts to call the API on behalf of a user.
+type APIToken struct {
+	// ID uniquely identifies the token
+	ID int64
+
+	// UserID references the user the token acts for
+	UserID int64
+
+	// Name is the label given by the user to recognize the token
+	Name string
+
+	// Token is the secret itself; it is only set when the token is created and never stored
+	Token string
+
+	// TokenHash is the SHA-256 hash of the token, used to look the token up
+	TokenHash string
+
+	// Scopes lists the permissions granted to the token
+	Scopes []TokenScope
+
+	// LastUsedAt tracks when the token last authenticated a request (zero if never used)
+	LastUsedAt time.Time
+
+	// ExpiresAt is the moment after which the token is rejected (zero for no expiry)
+	ExpiresAt time.Time
+
+	// CreatedAt tracks when the token was created
+	CreatedAt time.Time
+}
+
+// HasScope reports whether the token was granted the given scope.
+func (t *APIToken) HasScope(scope TokenScope) bool {
+	for _, granted := range t.Scopes {
+		if granted == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Expired reports whether the token is past its expiry at the given time.
+func (t *APIToken) Expired(now time.Time) bool {
+	return !t.ExpiresAt.IsZero() && now.After(t.ExpiresAt)
+}
@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// User represents a person with an account. Every task and course belongs to exactly one user.
+type User struct {
+	// ID uniquely identifies the user
+	ID int64
+
+	// Email is the unique address the user logs in with
+	Email string
+
+	// Name is the display name of the user
+	Name string
+
+	// PasswordHash is the bcrypt hash of the user's password
+	PasswordHash string
+
+	// CreatedAt tracks when the account was registered
+	CreatedAt time.Time
+
+	// UpdatedAt tracks the last modification time
+	UpdatedAt time.Time
+}
+
+// Session represents a logged-in browser session of a user.
+type Session struct {
+	// Token is the secret sent to the browser; only its hash is ever stored
+	Token string
+
+	// TokenHash is the SHA-256 hash of the token, used to look the session up
+	TokenHash string
+
+	// UserID references the user the session belongs to
+	UserID int64
+
+	// ExpiresAt is the moment after which the session is no longer valid
+	ExpiresAt time.Time
+
+	// CreatedAt tracks when the session was opened
+	CreatedAt time.Time
+}
@@ -0,0 +1,99 @@
+package models
+
+import "time"
+
+// Webhook is a subscription of a user to events, delivered as signed HTTP POST requests to a URL.
+type Webhook struct {
+	// ID uniquely identifies the webhook
+	ID int64
+
+	// OwnerID references the user whose events are delivered
+	OwnerID int64
+
+	// URL is where the events are posted
+	URL string
+
+	// Secret is the key the payloads are signed with (HMAC-SHA256)
+	Secret string
+
+	// Events lists the subscribed event types; "task.*" and "course.*" match every task or course
+	// event and "*" every event
+	Events []string
+
+	// Active reports whether events are delivered; inactive webhooks keep their configuration
+	Active bool
+
+	// CreatedAt tracks when the webhook was created
+	CreatedAt time.Time
+
+	// UpdatedAt tracks the last modification time
+	UpdatedAt time.Time
+}
+
+// WebhookDeliveryStatus is the state of the delivery of an event to a webhook.
+type WebhookDeliveryStatus string
+
+const (
+	// WebhookDeliveryPending indicates a delivery waiting for its first attempt or a retry
+	WebhookDeliveryPending WebhookDeliveryStatus = "pending"
+
+	// WebhookDeliverySucceeded indicates a delivery the webhook acknowledged with a 2xx response
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+
+	// WebhookDeliveryFailed indicates a delivery given up on after the last retry
+	WebhookDeliveryFailed WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery is the delivery of an event to a webhook and the log of its attempts.
+type WebhookDelivery struct {
+	// ID uniquely identifies the delivery
+	ID int64
+
+	// WebhookID references the webhook the event is delivered to
+	WebhookID int64
+
+	// OwnerID references the user the webhook belongs to
+	OwnerID int64
+
+	// EventID identifies the delivered event
+	EventID string
+
+	// EventType is the kind of the delivered event
+	EventType EventType
+
+	// Payload is the JSON body posted to the webhook
+	Payload string
+
+	// Status is the state of the delivery
+	Status WebhookDeliveryStatus
+
+	// Attempts counts the delivery attempts
+	Attempts int
+
+	// NextAttemptAt is when the delivery is attempted next, while it is pending
+	NextAttemptAt time.Time
+
+	// ResponseStatus is the HTTP status of the last response (zero if none was received)
+	ResponseStatus int
+
+	// LastError describes why the last attempt failed
+	LastError string
+
+	// CreatedAt tracks when the event was queued for delivery
+	CreatedAt time.Time
+
+	// UpdatedAt tracks the last attempt
+	UpdatedAt time.Time
+}
+
+// WebhookRun summarizes a pass of the webhook dispatcher.
+type WebhookRun struct {
+	// Succeeded counts the deliveries acknowledged by their webhook
+	Succeeded int
+
+	// Retrying counts the failed attempts that will be retried
+	Retrying int
+
+	// Failed counts the deliveries given up on
+	Failed int
+}
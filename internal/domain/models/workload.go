@@ -0,0 +1,61 @@
+package models
+
+import "time"
+
+// Workload forecasts the hours of work needed to finish the estimated tasks of a user on time.
+// The remaining effort of every unfinished task is spread evenly over the days left until it is due.
+type Workload struct {
+	// From is the first day of the forecast (midnight UTC)
+	From time.Time
+
+	// To is the last day of the forecast (midnight UTC)
+	To time.Time
+
+	// WeeklyCapacity is the number of hours a week the user can work before the week is overloaded
+	WeeklyCapacity float64
+
+	// Total is the number of hours required over the whole forecast
+	Total float64
+
+	// Days holds the hours required on every day of the forecast, in order
+	Days []WorkloadDay
+
+	// Weeks holds the hours required in every week (Monday to Sunday) overlapping the forecast, in order
+	Weeks []WorkloadWeek
+}
+
+// WorkloadDay is the work required on a single day.
+type WorkloadDay struct {
+	// Date is the day (midnight UTC)
+	Date time.Time
+
+	// Hours is the number of hours of work planned on the day
+	Hours float64
+}
+
+// WorkloadWeek is the work required in a single week.
+type WorkloadWeek struct {
+	// Start is the Monday the week starts on (midnight UTC)
+	Start time.Time
+
+	// Hours is the number of hours of work planned on the days of the week within the forecast
+	Hours float64
+
+	// Overloaded reports whether the week requires more hours than the weekly capacity
+	Overloaded bool
+
+	// Tasks holds the share of every task worked on during the week, largest first
+	Tasks []WorkloadTask
+}
+
+// WorkloadTask is the share of a task in the work of a week.
+type WorkloadTask struct {
+	// TaskID references the task
+	TaskID int64
+
+	// Title is the title of the task
+	Title string
+
+	// Hours is the number of hours planned on the task during the week
+	Hours float64
+}
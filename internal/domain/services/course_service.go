@@ -19,6 +19,12 @@ var (
 
 	// ErrEmptyName indicates that the course name is empty, which is not allowed
 	ErrEmptyName = errors.New("course name cannot be empty")
+
+	// ErrCourseHasTasks indicates an attempt to delete a course that still has tasks under the restrict policy
+	ErrCourseHasTasks = errors.New("course still has tasks")
+
+	// ErrInvalidDeletePolicy indicates an unknown course delete policy
+	ErrInvalidDeletePolicy = errors.New("delete policy must be restrict, cascade or detach")
 )
 
 // Verify CourseService implements input.CourseService interface at compile time
@@ -27,55 +33,98 @@ var _ input.CourseService = (*CourseService)(nil)
 // CourseService implements the course-related business logic and orchestrates
 // interactions between the domain model and storage layer.
 type CourseService struct {
-	courseRepo output.CourseRepository
+	courseRepo  output.CourseRepository
+	taskRepo    output.TaskRepository
+	historyRepo output.TaskHistoryRepository
+	uow         output.UnitOfWork
+	events      output.EventPublisher
 }
 
 // NewCourseService creates a new instance of CourseService with the required dependencies.
-func NewCourseService(courseRepo output.CourseRepository) *CourseService {
+// Every change to a course is published as an event through the given publisher, in the
+// same unit of work as the change; the tasks it changes are recorded in their history.
+func NewCourseService(courseRepo output.CourseRepository, taskRepo output.TaskRepository, historyRepo output.TaskHistoryRepository, uow output.UnitOfWork, events output.EventPublisher) *CourseService {
 	return &CourseService{
-		courseRepo: courseRepo,
+		courseRepo:  courseRepo,
+		taskRepo:    taskRepo,
+		historyRepo: historyRepo,
+		uow:         uow,
+		events:      events,
 	}
 }
 
 // CreateCourse implements input.CourseService.CreateCourse.
 // It validates the course data before creation and sets metadata fields.
 func (s *CourseService) CreateCourse(ctx context.Context, course *models.Course) error {
+	ownerID, err := currentUserID(ctx)
+	if err != nil {
+		return err
+	}
+
 	if err := s.validateCourse(course); err != nil {
 		return err
 	}
 
+	course.OwnerID = ownerID
+
 	now := time.Now().UTC()
 	course.CreatedAt = now
 	course.UpdatedAt = now
 
-	return s.courseRepo.Create(ctx, course)
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.courseRepo.Create(ctx, course); err != nil {
+			return err
+		}
+		return s.publishCourse(ctx, models.EventCourseCreated, course)
+	})
 }
 
 // UpdateCourse implements input.CourseService.UpdateCourse.
 // It validates the updated course data and ensures the course exists before updating.
+// A non-zero course.Version must match the stored version, otherwise ErrVersionConflict is returned.
 func (s *CourseService) UpdateCourse(ctx context.Context, course *models.Course) error {
+	ownerID, err := currentUserID(ctx)
+	if err != nil {
+		return err
+	}
+
 	if err := s.validateCourse(course); err != nil {
 		return err
 	}
 
-	existing, err := s.courseRepo.GetByID(ctx, course.ID)
+	existing, err := s.courseRepo.GetByID(ctx, ownerID, course.ID)
 	if err != nil {
 		return err
 	}
 	if existing == nil {
 		return ErrCourseNotFound
 	}
+	if course.Version != 0 && course.Version != existing.Version {
+		return ErrVersionConflict
+	}
 
+	course.OwnerID = ownerID
+	course.Version = existing.Version
 	course.CreatedAt = existing.CreatedAt
 	course.UpdatedAt = time.Now().UTC()
 
-	return s.courseRepo.Update(ctx, course)
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.courseRepo.Update(ctx, course); err != nil {
+			return translateStorageError(err)
+		}
+		return s.publishCourse(ctx, models.EventCourseUpdated, course)
+	})
 }
 
 // GetCourse implements input.CourseService.GetCourse.
 // It retrieves a specific course by its ID.
 func (s *CourseService) GetCourse(ctx context.Context, id int64) (*models.Course, error) {
-	course, err := s.courseRepo.GetByID(ctx, id)
+	ownerID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	course, err := s.courseRepo.GetByID(ctx, ownerID, id)
 	if err != nil {
 		return nil, err
 	}
@@ -86,22 +135,177 @@ func (s *CourseService) GetCourse(ctx context.Context, id int64) (*models.Course
 }
 
 // GetAllCourses implements input.CourseService.GetAllCourses.
-// It retrieves all courses from the repository.
+// It retrieves all courses of the current user from the repository.
 func (s *CourseService) GetAllCourses(ctx context.Context) ([]models.Course, error) {
-	return s.courseRepo.GetAll(ctx)
+	ownerID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.courseRepo.GetAll(ctx, ownerID)
+}
+
+// GetCourseStats implements input.CourseService.GetCourseStats.
+// It counts the tasks of the course by status and finds the next deadline.
+func (s *CourseService) GetCourseStats(ctx context.Context, id int64) (*models.CourseStats, error) {
+	course, err := s.GetCourse(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.taskRepo.GetByCourseID(ctx, course.OwnerID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	stats := models.CourseStats{Total: len(tasks)}
+	for _, task := range tasks {
+		switch task.Status {
+		case models.TaskStatusCompleted:
+			stats.Completed++
+			continue
+		case models.TaskStatusInProgress:
+			stats.InProgress++
+		default:
+			stats.Pending++
+		}
+
+		if task.DueDate.IsZero() {
+			continue
+		}
+		if task.DueDate.Before(now) {
+			stats.Overdue++
+		} else if stats.NextDeadline.IsZero() || task.DueDate.Before(stats.NextDeadline) {
+			stats.NextDeadline = task.DueDate
+		}
+	}
+	if stats.Total > 0 {
+		stats.Percent = stats.Completed * 100 / stats.Total
+	}
+
+	return &stats, nil
 }
 
 // DeleteCourse implements input.CourseService.DeleteCourse.
-// It ensures the course exists before deletion.
-func (s *CourseService) DeleteCourse(ctx context.Context, id int64) error {
-	existing, err := s.courseRepo.GetByID(ctx, id)
+// It ensures the course exists, and has the expected version if one is given, then applies
+// the delete policy to the tasks of the course before deleting it. An empty policy restricts.
+// Tasks deleted or detached by the policy, including the subtasks deleted with their parent, are
+// recorded and published as deleted or updated tasks.
+// Either all of it is applied or nothing is.
+func (s *CourseService) DeleteCourse(ctx context.Context, id, version int64, policy models.CourseDeletePolicy) error {
+	ownerID, err := currentUserID(ctx)
 	if err != nil {
 		return err
 	}
-	if existing == nil {
-		return ErrCourseNotFound
+
+	// The check, the policy, the deletion, the task history and the events are applied together
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		existing, err := s.courseRepo.GetByID(ctx, ownerID, id)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			return ErrCourseNotFound
+		}
+		if version != 0 && version != existing.Version {
+			return ErrVersionConflict
+		}
+
+		tasks, err := s.taskRepo.GetByCourseID(ctx, ownerID, id)
+		if err != nil {
+			return err
+		}
+
+		var taskAction models.TaskChangeAction
+		var taskEvent models.EventType
+		now := time.Now().UTC()
+		switch policy {
+		case "", models.CourseDeleteRestrict:
+			if len(tasks) > 0 {
+				return ErrCourseHasTasks
+			}
+		case models.CourseDeleteCascade:
+			// The subtasks of the course's tasks are deleted with them, whatever their own course
+			if tasks, err = s.withSubtasks(ctx, ownerID, tasks); err != nil {
+				return err
+			}
+			if err := s.taskRepo.DeleteByCourseID(ctx, ownerID, id); err != nil {
+				return err
+			}
+			taskAction, taskEvent = models.TaskChangeDeleted, models.EventTaskDeleted
+		case models.CourseDeleteDetach:
+			if err := s.taskRepo.DetachCourse(ctx, ownerID, id, now); err != nil {
+				return err
+			}
+			taskAction, taskEvent = models.TaskChangeUpdated, models.EventTaskUpdated
+		default:
+			return ErrInvalidDeletePolicy
+		}
+
+		if err := s.courseRepo.Delete(ctx, ownerID, id, existing.Version); err != nil {
+			return translateStorageError(err)
+		}
+
+		for _, before := range tasks {
+			after := before
+			if taskAction == models.TaskChangeUpdated {
+				// Mirror the detachment on the snapshot of the task
+				after.CourseID = 0
+				after.Version++
+				after.UpdatedAt = now
+			}
+			if err := recordTaskChange(ctx, s.historyRepo, taskAction, &before, &after); err != nil {
+				return err
+			}
+
+			event, err := newEvent(taskEvent, ownerID)
+			if err != nil {
+				return err
+			}
+			event.Task = &after
+			if err := s.events.Publish(ctx, event); err != nil {
+				return err
+			}
+		}
+		return s.publishCourse(ctx, models.EventCourseDeleted, existing)
+	})
+}
+
+// withSubtasks returns the tasks followed by those of their subtasks that aren't among them already.
+func (s *CourseService) withSubtasks(ctx context.Context, ownerID int64, tasks []models.Task) ([]models.Task, error) {
+	seen := make(map[int64]bool, len(tasks))
+	for _, task := range tasks {
+		seen[task.ID] = true
+	}
+
+	all := tasks
+	for _, task := range tasks {
+		if task.ParentID != 0 {
+			continue
+		}
+		subtasks, err := s.taskRepo.GetSubtasks(ctx, ownerID, task.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, subtask := range subtasks {
+			if !seen[subtask.ID] {
+				seen[subtask.ID] = true
+				all = append(all, subtask)
+			}
+		}
+	}
+	return all, nil
+}
+
+// publishCourse publishes an event about a course, carrying a snapshot of the course.
+func (s *CourseService) publishCourse(ctx context.Context, eventType models.EventType, course *models.Course) error {
+	event, err := newEvent(eventType, course.OwnerID)
+	if err != nil {
+		return err
 	}
-	return s.courseRepo.Delete(ctx, id)
+	snapshot := *course
+	event.Course = &snapshot
+	return s.events.Publish(ctx, event)
 }
 
 // validateCourse performs validation of course data according to business rules.
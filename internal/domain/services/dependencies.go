@@ -0,0 +1,171 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"uni-task-manager/internal/domain/models"
+)
+
+// Domain-specific errors related to task dependencies
+var (
+	// ErrDependencyCycle indicates a dependency that would make a task wait for itself
+	ErrDependencyCycle = errors.New("dependency would create a cycle")
+
+	// ErrDependencyNotFound indicates that the task doesn't depend on the given task
+	ErrDependencyNotFound = errors.New("dependency not found")
+
+	// ErrTaskBlocked indicates an attempt to complete a task while some of its prerequisites are open
+	ErrTaskBlocked = errors.New("task cannot be completed while its prerequisites are open")
+)
+
+// AddDependency implements input.TaskService.AddDependency.
+// Both tasks must exist, and the prerequisite must not already depend on the task,
+// directly or through other tasks. The graph is checked and the dependency added in a single
+// unit of work, so two concurrent additions cannot close a cycle together.
+func (s *TaskService) AddDependency(ctx context.Context, taskID, dependsOnID int64) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		task, err := s.GetTask(ctx, taskID)
+		if err != nil {
+			return err
+		}
+		if _, err := s.GetTask(ctx, dependsOnID); err != nil {
+			return err
+		}
+
+		if taskID == dependsOnID {
+			return ErrDependencyCycle
+		}
+
+		graph, err := s.dependencyRepo.GetAll(ctx, task.OwnerID)
+		if err != nil {
+			return err
+		}
+		if dependsOn(graph, dependsOnID, taskID) {
+			return ErrDependencyCycle
+		}
+
+		return s.dependencyRepo.Add(ctx, taskID, dependsOnID, time.Now().UTC())
+	})
+}
+
+// RemoveDependency implements input.TaskService.RemoveDependency.
+// It ensures the task exists and depends on the given task before removing the dependency.
+func (s *TaskService) RemoveDependency(ctx context.Context, taskID, dependsOnID int64) error {
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		task, err := s.GetTask(ctx, taskID)
+		if err != nil {
+			return err
+		}
+
+		graph, err := s.dependencyRepo.GetAll(ctx, task.OwnerID)
+		if err != nil {
+			return err
+		}
+		if !containsID(graph[taskID], dependsOnID) {
+			return ErrDependencyNotFound
+		}
+
+		return s.dependencyRepo.Remove(ctx, task.OwnerID, taskID, dependsOnID)
+	})
+}
+
+// GetDependencies implements input.TaskService.GetDependencies.
+// It retrieves the prerequisites and dependents of a task and tells whether it is blocked.
+func (s *TaskService) GetDependencies(ctx context.Context, taskID int64) (*models.TaskDependencies, error) {
+	task, err := s.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	prerequisites, err := s.dependencyRepo.GetPrerequisites(ctx, task.OwnerID, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	dependents, err := s.dependencyRepo.GetDependents(ctx, task.OwnerID, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.TaskDependencies{
+		Prerequisites: prerequisites,
+		Dependents:    dependents,
+		Blocked:       task.Status != models.TaskStatusCompleted && hasOpenTask(prerequisites),
+	}, nil
+}
+
+// GetBlockedTasks implements input.TaskService.GetBlockedTasks.
+// Only blocked tasks are included in the result.
+func (s *TaskService) GetBlockedTasks(ctx context.Context, taskIDs []int64) (map[int64]bool, error) {
+	ownerID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := s.dependencyRepo.CountOpenPrerequisites(ctx, ownerID, taskIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	blocked := make(map[int64]bool, len(counts))
+	for taskID, open := range counts {
+		if open > 0 {
+			blocked[taskID] = true
+		}
+	}
+	return blocked, nil
+}
+
+// checkPrerequisites returns ErrTaskBlocked if some prerequisite of the task isn't completed yet.
+func (s *TaskService) checkPrerequisites(ctx context.Context, task *models.Task) error {
+	prerequisites, err := s.dependencyRepo.GetPrerequisites(ctx, task.OwnerID, task.ID)
+	if err != nil {
+		return err
+	}
+	if hasOpenTask(prerequisites) {
+		return ErrTaskBlocked
+	}
+	return nil
+}
+
+// dependsOn reports whether the task from reaches the task to by following the prerequisites
+// of the dependency graph, i.e. whether from transitively depends on to.
+func dependsOn(graph map[int64][]int64, from, to int64) bool {
+	visited := make(map[int64]bool)
+	pending := []int64{from}
+	for len(pending) > 0 {
+		current := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if current == to {
+			return true
+		}
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+		pending = append(pending, graph[current]...)
+	}
+	return false
+}
+
+// hasOpenTask reports whether any of the tasks isn't completed.
+func hasOpenTask(tasks []models.Task) bool {
+	for _, task := range tasks {
+		if task.Status != models.TaskStatusCompleted {
+			return true
+		}
+	}
+	return false
+}
+
+// containsID reports whether ids contains id.
+func containsID(ids []int64, id int64) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"uni-task-manager/internal/domain/models"
+	"uni-task-manager/internal/ports/output"
+)
+
+func TestDependsOn(t *testing.T) {
+	// 1 depends on 2 and 4, 2 depends on 3; 5 and 6 depend on each other
+	graph := map[int64][]int64{
+		1: {2, 4},
+		2: {3},
+		5: {6},
+		6: {5},
+	}
+
+	tests := []struct {
+		name     string
+		from, to int64
+		want     bool
+	}{
+		{"direct prerequisite", 1, 2, true},
+		{"transitive prerequisite", 1, 3, true},
+		{"reverse direction", 3, 1, false},
+		{"unrelated tasks", 2, 4, false},
+		{"task without prerequisites", 3, 2, false},
+		{"a task reaches itself", 4, 4, true},
+		{"existing cycle terminates", 5, 1, false},
+		{"within an existing cycle", 5, 6, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dependsOn(graph, tt.from, tt.to); got != tt.want {
+				t.Errorf("dependsOn(%d, %d) = %v, want %v", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
+
+// inWorkKey marks the context handed to the work of a trackedUnitOfWork
+type inWorkKey struct{}
+
+// trackedUnitOfWork runs the work without a transaction, marking its context
+type trackedUnitOfWork struct{}
+
+func (trackedUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(context.WithValue(ctx, inWorkKey{}, true))
+}
+
+// ownedTaskRepository serves any task ID as a pending task of the owner
+type ownedTaskRepository struct {
+	output.TaskRepository
+}
+
+func (ownedTaskRepository) GetByID(ctx context.Context, ownerID, id int64) (*models.Task, error) {
+	return &models.Task{ID: id, OwnerID: ownerID, Status: models.TaskStatusPending}, nil
+}
+
+// graphRepository stores a dependency graph and fails any access outside a unit of work
+type graphRepository struct {
+	output.DependencyRepository
+	graph map[int64][]int64
+}
+
+var errOutsideWork = errors.New("dependency graph accessed outside a unit of work")
+
+func (r *graphRepository) GetAll(ctx context.Context, ownerID int64) (map[int64][]int64, error) {
+	if ctx.Value(inWorkKey{}) == nil {
+		return nil, errOutsideWork
+	}
+	return r.graph, nil
+}
+
+func (r *graphRepository) Add(ctx context.Context, taskID, dependsOnID int64, createdAt time.Time) error {
+	if ctx.Value(inWorkKey{}) == nil {
+		return errOutsideWork
+	}
+	r.graph[taskID] = append(r.graph[taskID], dependsOnID)
+	return nil
+}
+
+func TestAddDependency(t *testing.T) {
+	ctx := ContextWithUserID(context.Background(), 1)
+
+	tests := []struct {
+		name              string
+		graph             map[int64][]int64
+		taskID, dependsOn int64
+		wantErr           error
+	}{
+		{"new dependency", map[int64][]int64{}, 1, 2, nil},
+		{"task on itself", map[int64][]int64{}, 1, 1, ErrDependencyCycle},
+		{"direct cycle", map[int64][]int64{2: {1}}, 1, 2, ErrDependencyCycle},
+		{"transitive cycle", map[int64][]int64{2: {3}, 3: {1}}, 1, 2, ErrDependencyCycle},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The graph is read and the dependency added in the same unit of work
+			repo := &graphRepository{graph: tt.graph}
+			service := NewTaskService(ownedTaskRepository{}, nil, nil, nil, repo, nil, trackedUnitOfWork{}, discardedEvents{})
+
+			err := service.AddDependency(ctx, tt.taskID, tt.dependsOn)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("AddDependency() error = %v, want %v", err, tt.wantErr)
+			}
+			if added := containsID(repo.graph[tt.taskID], tt.dependsOn); added != (tt.wantErr == nil) {
+				t.Errorf("dependency added = %v, want %v", added, tt.wantErr == nil)
+			}
+		})
+	}
+}
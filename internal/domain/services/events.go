@@ -0,0 +1,113 @@
+package services
+
+import (
+	"time"
+
+	"uni-task-manager/internal/domain/models"
+)
+
+// newEvent creates an event of the given type about a task or course of the owner,
+// with a random ID subscribers can deduplicate on.
+func newEvent(eventType models.EventType, ownerID int64) (models.Event, error) {
+	id, err := generateToken(12)
+	if err != nil {
+		return models.Event{}, err
+	}
+	return models.Event{
+		ID:         "evt_" + id,
+		Type:       eventType,
+		OwnerID:    ownerID,
+		OccurredAt: time.Now().UTC(),
+	}, nil
+}
+
+// eventPayload is the JSON body posted to webhooks:
+//
+//	{"id": "evt_...", "type": "task.status_changed", "occurred_at": "...", "data": {"task": {...}, "previous_status": "pending"}}
+type eventPayload struct {
+	ID         string           `json:"id"`
+	Type       models.EventType `json:"type"`
+	OccurredAt time.Time        `json:"occurred_at"`
+	Data       eventData        `json:"data"`
+}
+
+// eventData holds the task or course an event is about.
+type eventData struct {
+	Task           *taskPayload      `json:"task,omitempty"`
+	PreviousStatus models.TaskStatus `json:"previous_status,omitempty"`
+	Course         *coursePayload    `json:"course,omitempty"`
+}
+
+// taskPayload is the JSON representation of a task in event payloads.
+type taskPayload struct {
+	ID             int64             `json:"id"`
+	Title          string            `json:"title"`
+	Description    string            `json:"description"`
+	DueDate        time.Time         `json:"due_date"`
+	Priority       int               `json:"priority"`
+	Status         models.TaskStatus `json:"status"`
+	EstimatedHours float64           `json:"estimated_hours"`
+	CourseID       *int64            `json:"course_id"`
+	ParentID       *int64            `json:"parent_id"`
+	Version        int64             `json:"version"`
+	CreatedAt      time.Time         `json:"created_at"`
+	UpdatedAt      time.Time         `json:"updated_at"`
+}
+
+// coursePayload is the JSON representation of a course in event payloads.
+type coursePayload struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Professor string    `json:"professor"`
+	Version   int64     `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// newEventPayload converts an event to the JSON body posted to webhooks.
+func newEventPayload(event models.Event) eventPayload {
+	payload := eventPayload{
+		ID:         event.ID,
+		Type:       event.Type,
+		OccurredAt: event.OccurredAt,
+		Data:       eventData{PreviousStatus: event.PreviousStatus},
+	}
+
+	if task := event.Task; task != nil {
+		payload.Data.Task = &taskPayload{
+			ID:             task.ID,
+			Title:          task.Title,
+			Description:    task.Description,
+			DueDate:        task.DueDate.UTC(),
+			Priority:       task.Priority,
+			Status:         task.Status,
+			EstimatedHours: task.EstimatedHours,
+			CourseID:       optionalInt64(task.CourseID),
+			ParentID:       optionalInt64(task.ParentID),
+			Version:        task.Version,
+			CreatedAt:      task.CreatedAt.UTC(),
+			UpdatedAt:      task.UpdatedAt.UTC(),
+		}
+	}
+
+	if course := event.Course; course != nil {
+		payload.Data.Course = &coursePayload{
+			ID:        course.ID,
+			Name:      course.Name,
+			Professor: course.Professor,
+			Version:   course.Version,
+			CreatedAt: course.CreatedAt.UTC(),
+			UpdatedAt: course.UpdatedAt.UTC(),
+		}
+	}
+
+	return payload
+}
+
+// optionalInt64 returns nil for a zero reference, so it is encoded as JSON null.
+func optionalInt64(id int64) *int64 {
+	if id == 0 {
+		return nil
+	}
+	return &id
+}
@@ -0,0 +1,237 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"uni-task-manager/internal/domain/models"
+	"uni-task-manager/internal/ports/input"
+	"uni-task-manager/internal/ports/output"
+)
+
+// Domain-specific errors that can be returned by the ImportService
+var (
+	// ErrInvalidImportOptions indicates that the import configuration is invalid
+	ErrInvalidImportOptions = errors.New("invalid import options")
+)
+
+// defaultImportPriority is given to imported tasks whose calendar entry has no priority
+const defaultImportPriority = 3
+
+// Verify ImportService implements input.ImportService interface at compile time
+var _ input.ImportService = (*ImportService)(nil)
+
+// ImportService implements the import of external calendar deadlines as tasks.
+// Tasks and courses are created through their services so the regular business rules apply.
+type ImportService struct {
+	taskService   input.TaskService
+	courseService input.CourseService
+	taskRepo      output.TaskRepository
+	courseRepo    output.CourseRepository
+}
+
+// NewImportService creates a new instance of ImportService with the required dependencies.
+func NewImportService(taskService input.TaskService, courseService input.CourseService, taskRepo output.TaskRepository, courseRepo output.CourseRepository) *ImportService {
+	return &ImportService{
+		taskService:   taskService,
+		courseService: courseService,
+		taskRepo:      taskRepo,
+		courseRepo:    courseRepo,
+	}
+}
+
+// ImportCalendar implements input.ImportService.ImportCalendar.
+// Items are matched to existing tasks by UID: known items are updated in place, new ones are created.
+// Items that cannot be imported are reported in the result rather than failing the whole import.
+func (s *ImportService) ImportCalendar(ctx context.Context, items []models.CalendarItem, opts models.ImportOptions) (*models.ImportResult, error) {
+	ownerID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.validateImportOptions(ctx, &opts); err != nil {
+		return nil, err
+	}
+
+	result := &models.ImportResult{}
+	courseIDs := make(map[string]int64)
+	now := time.Now()
+
+	for _, item := range items {
+		skip := func(reason string) {
+			result.Skipped = append(result.Skipped, models.ImportSkip{UID: item.UID, Summary: item.Summary, Reason: reason})
+		}
+
+		if item.UID == "" {
+			skip("entry has no UID")
+			continue
+		}
+		if item.Due.IsZero() {
+			skip("entry has no date")
+			continue
+		}
+		if item.Cancelled {
+			skip("entry was cancelled")
+			continue
+		}
+
+		title, courseName := splitCourse(item, opts)
+		if title == "" {
+			skip("entry has no title")
+			continue
+		}
+
+		existing, err := s.taskRepo.GetByExternalUID(ctx, ownerID, item.UID)
+		if err != nil {
+			return nil, err
+		}
+		if existing == nil && item.Due.Before(now) {
+			skip("deadline has already passed")
+			continue
+		}
+
+		courseID := opts.CourseID
+		if courseID == 0 && courseName != "" {
+			courseID, err = s.resolveCourse(ctx, ownerID, courseName, item.Organizer, courseIDs, result)
+			if err != nil {
+				skip(err.Error())
+				continue
+			}
+		}
+
+		if existing != nil {
+			changed := applyCalendarItem(existing, item, title, courseID)
+			if !changed {
+				result.Unchanged++
+				continue
+			}
+			if err := s.taskService.UpdateTask(ctx, existing); err != nil {
+				skip(err.Error())
+				continue
+			}
+			result.Updated++
+			continue
+		}
+
+		task := &models.Task{
+			Title:       title,
+			Description: item.Description,
+			DueDate:     item.Due,
+			Priority:    item.Priority,
+			Status:      item.Status,
+			CourseID:    courseID,
+			ExternalUID: item.UID,
+		}
+		if task.Priority == 0 {
+			task.Priority = defaultImportPriority
+		}
+		if err := s.taskService.CreateTask(ctx, task); err != nil {
+			skip(err.Error())
+			continue
+		}
+		result.Created++
+	}
+
+	return result, nil
+}
+
+// validateImportOptions checks the import options and fills in their defaults.
+func (s *ImportService) validateImportOptions(ctx context.Context, opts *models.ImportOptions) error {
+	switch opts.CourseMatch {
+	case "":
+		opts.CourseMatch = models.CourseMatchPrefix
+	case models.CourseMatchPrefix, models.CourseMatchCategory, models.CourseMatchNone:
+	default:
+		return fmt.Errorf("%w: unknown course match mode %q", ErrInvalidImportOptions, opts.CourseMatch)
+	}
+
+	if opts.PrefixSeparator == "" {
+		opts.PrefixSeparator = ":"
+	}
+
+	if opts.CourseID != 0 {
+		if _, err := s.courseService.GetCourse(ctx, opts.CourseID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resolveCourse finds the course with the given name, creating it if needed.
+// Resolved IDs are cached per import so every item of a course maps to the same one.
+func (s *ImportService) resolveCourse(ctx context.Context, ownerID int64, name, professor string, cache map[string]int64, result *models.ImportResult) (int64, error) {
+	key := strings.ToLower(name)
+	if id, ok := cache[key]; ok {
+		return id, nil
+	}
+
+	course, err := s.courseRepo.GetByName(ctx, ownerID, name)
+	if err != nil {
+		return 0, err
+	}
+	if course == nil {
+		course = &models.Course{Name: name, Professor: professor}
+		if err := s.courseService.CreateCourse(ctx, course); err != nil {
+			return 0, err
+		}
+		result.CoursesCreated++
+	}
+
+	cache[key] = course.ID
+	return course.ID, nil
+}
+
+// splitCourse derives the task title and course name of a calendar item according to the match mode.
+func splitCourse(item models.CalendarItem, opts models.ImportOptions) (title, courseName string) {
+	title = strings.TrimSpace(item.Summary)
+	if opts.CourseID != 0 {
+		return title, ""
+	}
+
+	switch opts.CourseMatch {
+	case models.CourseMatchCategory:
+		if len(item.Categories) > 0 {
+			courseName = strings.TrimSpace(item.Categories[0])
+		}
+	case models.CourseMatchPrefix:
+		if prefix, rest, ok := strings.Cut(title, opts.PrefixSeparator); ok && strings.TrimSpace(rest) != "" {
+			courseName = strings.TrimSpace(prefix)
+			title = strings.TrimSpace(rest)
+		}
+	}
+
+	return title, courseName
+}
+
+// applyCalendarItem copies the calendar-owned fields of an item onto a previously imported task.
+// The status is left alone since it tracks the student's own progress. Reports whether anything changed.
+func applyCalendarItem(task *models.Task, item models.CalendarItem, title string, courseID int64) bool {
+	changed := false
+
+	if task.Title != title {
+		task.Title = title
+		changed = true
+	}
+	if task.Description != item.Description {
+		task.Description = item.Description
+		changed = true
+	}
+	if !task.DueDate.Equal(item.Due.Truncate(time.Second)) {
+		task.DueDate = item.Due
+		changed = true
+	}
+	if item.Priority != 0 && task.Priority != item.Priority {
+		task.Priority = item.Priority
+		changed = true
+	}
+	if courseID != 0 && task.CourseID != courseID {
+		task.CourseID = courseID
+		changed = true
+	}
+
+	return changed
+}
@@ -0,0 +1,115 @@
+// Package services implements the core business logic for the event outbox
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"uni-task-manager/internal/domain/models"
+	"uni-task-manager/internal/ports/input"
+	"uni-task-manager/internal/ports/output"
+)
+
+const (
+	// OutboxRetryDelay is the delay before an event whose dispatch failed is dispatched again;
+	// it doubles after every further failure, up to MaxOutboxRetryDelay
+	OutboxRetryDelay = 5 * time.Second
+
+	// MaxOutboxRetryDelay caps the delay between two attempts to dispatch an event
+	MaxOutboxRetryDelay = 10 * time.Minute
+
+	// outboxBatchSize caps the number of events dispatched by a single dispatcher pass
+	outboxBatchSize = 100
+)
+
+// Verify OutboxService implements the input.OutboxService and output.EventPublisher interfaces at compile time
+var (
+	_ input.OutboxService   = (*OutboxService)(nil)
+	_ output.EventPublisher = (*OutboxService)(nil)
+)
+
+// OutboxService implements the transactional outbox: published events are recorded in the
+// unit of work of the change they report, so an event exists if and only if its change was
+// committed. The dispatcher then hands every recorded event to the subscribers and removes it
+// in a single unit of work, retrying until that succeeds; subscribers thus see every event at
+// least once, and exactly once if they only write through the unit of work.
+type OutboxService struct {
+	outboxRepo  output.OutboxRepository
+	uow         output.UnitOfWork
+	subscribers []output.EventSubscriber
+}
+
+// NewOutboxService creates a new instance of OutboxService dispatching events to the given subscribers.
+func NewOutboxService(outboxRepo output.OutboxRepository, uow output.UnitOfWork, subscribers []output.EventSubscriber) *OutboxService {
+	return &OutboxService{
+		outboxRepo:  outboxRepo,
+		uow:         uow,
+		subscribers: subscribers,
+	}
+}
+
+// Publish implements output.EventPublisher.Publish.
+// It records the event in the outbox with the JSON representation posted to webhooks; called in
+// a unit of work, the event is discarded with the change it reports if the unit of work rolls back.
+func (s *OutboxService) Publish(ctx context.Context, event models.Event) error {
+	payload, err := json.Marshal(newEventPayload(event))
+	if err != nil {
+		return err
+	}
+
+	return s.outboxRepo.Add(ctx, &models.OutboxMessage{
+		EventID:       event.ID,
+		EventType:     event.Type,
+		OwnerID:       event.OwnerID,
+		Payload:       string(payload),
+		NextAttemptAt: event.OccurredAt,
+		CreatedAt:     event.OccurredAt,
+	})
+}
+
+// DispatchPending implements input.OutboxService.DispatchPending.
+// Events are dispatched in the order they were recorded, but an event whose dispatch fails is
+// retried with backoff after the events following it.
+func (s *OutboxService) DispatchPending(ctx context.Context, now time.Time) (*models.OutboxRun, error) {
+	run := &models.OutboxRun{}
+
+	messages, err := s.outboxRepo.GetDue(ctx, now, outboxBatchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, message := range messages {
+		err := s.uow.Do(ctx, func(ctx context.Context) error {
+			for _, subscriber := range s.subscribers {
+				if err := subscriber.HandleEvent(ctx, message); err != nil {
+					return err
+				}
+			}
+			return s.outboxRepo.Delete(ctx, message.ID)
+		})
+		if err == nil {
+			run.Dispatched++
+			continue
+		}
+
+		message.Attempts++
+		message.LastError = err.Error()
+		message.NextAttemptAt = now.Add(outboxRetryDelay(message.Attempts))
+		if err := s.outboxRepo.Update(ctx, &message); err != nil {
+			return nil, err
+		}
+		run.Retrying++
+	}
+
+	return run, nil
+}
+
+// outboxRetryDelay computes the delay before the next attempt to dispatch an event that failed the given number of times.
+func outboxRetryDelay(attempts int) time.Duration {
+	delay := OutboxRetryDelay
+	for i := 1; i < attempts && delay < MaxOutboxRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, MaxOutboxRetryDelay)
+}
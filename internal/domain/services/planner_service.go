@@ -0,0 +1,287 @@
+// Package services implements the core business logic for study planning
+package services
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"uni-task-manager/internal/domain/models"
+	"uni-task-manager/internal/ports/input"
+	"uni-task-manager/internal/ports/output"
+)
+
+// Domain-specific errors that can be returned by the PlannerService
+var (
+	// ErrAvailabilityNotFound indicates that the requested availability window does not exist
+	ErrAvailabilityNotFound = errors.New("availability window not found")
+
+	// ErrInvalidAvailability indicates a window with an unknown weekday or that doesn't end after it starts
+	// on the same day
+	ErrInvalidAvailability = errors.New("availability window must be on a weekday from 0 (Sunday) to 6 and end after it starts, by midnight")
+
+	// ErrAvailabilityOverlap indicates a window that overlaps another window on the same weekday
+	ErrAvailabilityOverlap = errors.New("availability window overlaps another window")
+
+	// ErrInvalidPlanQuery indicates a plan over an unsupported number of days or hours per day
+	ErrInvalidPlanQuery = errors.New("plan must span 1 to 31 days with at most 24 hours a day")
+)
+
+const (
+	// DefaultPlanDays is the number of days planned when no length is given
+	DefaultPlanDays = 7
+
+	// MaxPlanDays caps the number of days returned by a single plan
+	MaxPlanDays = 31
+
+	// DefaultMaxStudyHours is the most hours of study planned on a day when no limit is given
+	DefaultMaxStudyHours = 4
+
+	// planLookahead caps how far ahead the planner schedules work to find the tasks that don't fit
+	planLookahead = 366 * dayLength
+)
+
+// Verify PlannerService implements input.PlannerService interface at compile time
+var _ input.PlannerService = (*PlannerService)(nil)
+
+// PlannerService implements study planning: it fills the weekly availability windows of a user
+// with sessions on the open estimated tasks, the most urgent first.
+type PlannerService struct {
+	availabilityRepo output.AvailabilityRepository
+	taskRepo         output.TaskRepository
+	timeRepo         output.TimeEntryRepository
+}
+
+// NewPlannerService creates a new instance of PlannerService with the required dependencies.
+func NewPlannerService(availabilityRepo output.AvailabilityRepository, taskRepo output.TaskRepository, timeRepo output.TimeEntryRepository) *PlannerService {
+	return &PlannerService{
+		availabilityRepo: availabilityRepo,
+		taskRepo:         taskRepo,
+		timeRepo:         timeRepo,
+	}
+}
+
+// GetAvailability implements input.PlannerService.GetAvailability.
+func (s *PlannerService) GetAvailability(ctx context.Context) ([]models.AvailabilityWindow, error) {
+	ownerID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.availabilityRepo.GetAll(ctx, ownerID)
+}
+
+// AddAvailability implements input.PlannerService.AddAvailability.
+// Times are truncated to the minute; windows of the same weekday may touch but not overlap.
+func (s *PlannerService) AddAvailability(ctx context.Context, window *models.AvailabilityWindow) error {
+	ownerID, err := currentUserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	window.Start = window.Start.Truncate(time.Minute)
+	window.End = window.End.Truncate(time.Minute)
+	if window.Weekday < time.Sunday || window.Weekday > time.Saturday ||
+		window.Start < 0 || window.End <= window.Start || window.End > dayLength {
+		return ErrInvalidAvailability
+	}
+
+	windows, err := s.availabilityRepo.GetAll(ctx, ownerID)
+	if err != nil {
+		return err
+	}
+	for _, other := range windows {
+		if other.Weekday == window.Weekday && other.Start < window.End && window.Start < other.End {
+			return ErrAvailabilityOverlap
+		}
+	}
+
+	window.OwnerID = ownerID
+	window.CreatedAt = time.Now().UTC()
+	return s.availabilityRepo.Create(ctx, window)
+}
+
+// DeleteAvailability implements input.PlannerService.DeleteAvailability.
+func (s *PlannerService) DeleteAvailability(ctx context.Context, id int64) error {
+	ownerID, err := currentUserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	window, err := s.availabilityRepo.GetByID(ctx, ownerID, id)
+	if err != nil {
+		return err
+	}
+	if window == nil {
+		return ErrAvailabilityNotFound
+	}
+
+	return s.availabilityRepo.Delete(ctx, ownerID, id)
+}
+
+// plannedTask is an open task waiting to be scheduled, with the effort left to plan.
+type plannedTask struct {
+	task      models.Task
+	remaining time.Duration
+	deadline  time.Time
+	urgency   time.Time
+
+	// undated is set for tasks without a due date, which come after every task that has one
+	undated bool
+}
+
+// GetPlan implements input.PlannerService.GetPlan.
+// The plan isn't stored: it is computed from the current tasks, time entries and availability,
+// so it follows every change. Starting now, each availability window is filled with sessions on
+// the most urgent task that can still be worked on before it is due, up to the daily limit.
+// Urgency is the due date moved one day earlier per priority level above 1, so a critical task
+// due on Friday comes before a very low priority task due on Tuesday. The remaining effort of a
+// task is its estimate minus the time already logged on it; overdue tasks are planned first and
+// tasks without a due date last.
+func (s *PlannerService) GetPlan(ctx context.Context, days int, maxHours float64) (*models.StudyPlan, error) {
+	ownerID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if days == 0 {
+		days = DefaultPlanDays
+	}
+	if maxHours == 0 {
+		maxHours = DefaultMaxStudyHours
+	}
+	if days < 1 || days > MaxPlanDays || maxHours < 0 || maxHours > 24 {
+		return nil, ErrInvalidPlanQuery
+	}
+
+	windows, err := s.availabilityRepo.GetAll(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.taskRepo.GetAll(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	spent, err := s.timeRepo.SumByTask(ctx, ownerID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	today := startOfDay(now)
+	horizon := today.AddDate(0, 0, days)
+	plan := &models.StudyPlan{From: today, To: horizon.AddDate(0, 0, -1), MaxHoursPerDay: maxHours}
+	for d := today; d.Before(horizon); d = d.AddDate(0, 0, 1) {
+		plan.Days = append(plan.Days, models.PlanDay{Date: d})
+	}
+
+	var pending []*plannedTask
+	end := horizon
+	for _, task := range tasks {
+		if task.Status == models.TaskStatusCompleted || task.EstimatedHours <= 0 {
+			continue
+		}
+		remaining := (time.Duration(task.EstimatedHours*float64(time.Hour)) - spent[task.ID]).Round(time.Minute)
+		if remaining <= 0 {
+			continue
+		}
+
+		// Overdue tasks can't be late any more than they are, and tasks without a due date can't
+		// be late at all, so neither has a deadline. Tasks without a due date are the least urgent.
+		planned := &plannedTask{
+			task:      task,
+			remaining: remaining,
+			deadline:  task.DueDate.UTC(),
+			urgency:   task.DueDate.AddDate(0, 0, 1-task.Priority),
+		}
+		if task.DueDate.IsZero() {
+			planned.deadline = today.Add(planLookahead)
+			planned.undated = true
+		} else if !planned.deadline.After(now) {
+			planned.deadline = today.Add(planLookahead)
+		}
+		pending = append(pending, planned)
+		end = latest(end, startOfDay(planned.deadline).AddDate(0, 0, 1))
+	}
+	sort.SliceStable(pending, func(i, j int) bool {
+		if pending[i].undated != pending[j].undated {
+			return pending[j].undated
+		}
+		if !pending[i].urgency.Equal(pending[j].urgency) {
+			return pending[i].urgency.Before(pending[j].urgency)
+		}
+		return pending[i].task.ID < pending[j].task.ID
+	})
+
+	byWeekday := make(map[time.Weekday][]models.AvailabilityWindow)
+	for _, window := range windows {
+		byWeekday[window.Weekday] = append(byWeekday[window.Weekday], window)
+	}
+
+	limit := time.Duration(maxHours * float64(time.Hour)).Round(time.Minute)
+	end = earliest(end, today.Add(planLookahead))
+	for d := today; d.Before(end) && len(pending) > 0; d = d.AddDate(0, 0, 1) {
+		budget := limit
+		for _, window := range byWeekday[d.Weekday()] {
+			start, stop := d.Add(window.Start), d.Add(window.End)
+			if !stop.After(now) {
+				continue
+			}
+			if start.Before(now) {
+				start = now.Truncate(time.Minute).Add(time.Minute)
+			}
+
+			for start.Before(stop) && budget > 0 {
+				next := nextPlannedTask(pending, start)
+				if next < 0 {
+					break
+				}
+				planned := pending[next]
+
+				length := min(planned.remaining, stop.Sub(start), budget, planned.deadline.Sub(start))
+				if d.Before(horizon) {
+					day := &plan.Days[int(d.Sub(today)/dayLength)]
+					day.Sessions = append(day.Sessions, models.StudySession{
+						TaskID:   planned.task.ID,
+						Title:    planned.task.Title,
+						CourseID: planned.task.CourseID,
+						Start:    start,
+						End:      start.Add(length),
+					})
+					day.Hours += length.Hours()
+				}
+
+				planned.remaining -= length
+				budget -= length
+				start = start.Add(length)
+				if planned.remaining <= 0 {
+					pending = append(pending[:next], pending[next+1:]...)
+				}
+			}
+		}
+	}
+
+	for _, planned := range pending {
+		plan.Unscheduled = append(plan.Unscheduled, models.UnscheduledTask{
+			TaskID:  planned.task.ID,
+			Title:   planned.task.Title,
+			DueDate: planned.task.DueDate,
+			Hours:   planned.remaining.Hours(),
+		})
+	}
+
+	return plan, nil
+}
+
+// nextPlannedTask returns the index of the most urgent pending task that is still open at the given
+// time, or -1 if there is none. The pending tasks must be ordered by urgency.
+func nextPlannedTask(pending []*plannedTask, at time.Time) int {
+	for i, planned := range pending {
+		if planned.deadline.After(at) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"uni-task-manager/internal/domain/models"
+	"uni-task-manager/internal/ports/output"
+)
+
+// alwaysAvailable is free all day, every day
+type alwaysAvailable struct {
+	output.AvailabilityRepository
+}
+
+func (alwaysAvailable) GetAll(ctx context.Context, ownerID int64) ([]models.AvailabilityWindow, error) {
+	var windows []models.AvailabilityWindow
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		windows = append(windows, models.AvailabilityWindow{Weekday: weekday, Start: 0, End: dayLength})
+	}
+	return windows, nil
+}
+
+// listedTaskRepository serves a fixed list of tasks
+type listedTaskRepository struct {
+	output.TaskRepository
+	tasks []models.Task
+}
+
+func (r *listedTaskRepository) GetAll(ctx context.Context, ownerID int64) ([]models.Task, error) {
+	return r.tasks, nil
+}
+
+// untrackedTimeRepository has no time logged on any task
+type untrackedTimeRepository struct {
+	output.TimeEntryRepository
+}
+
+func (untrackedTimeRepository) SumByTask(ctx context.Context, ownerID int64, now time.Time) (map[int64]time.Duration, error) {
+	return nil, nil
+}
+
+func TestGetPlanPlansTasksWithoutDueDateLast(t *testing.T) {
+	ctx := ContextWithUserID(context.Background(), 1)
+	tasks := &listedTaskRepository{tasks: []models.Task{
+		{ID: 1, Title: "Reading", Priority: 5, Status: models.TaskStatusPending, EstimatedHours: 2},
+		{ID: 2, Title: "Essay", Priority: 1, Status: models.TaskStatusPending, EstimatedHours: 2,
+			DueDate: time.Now().UTC().AddDate(0, 0, 5)},
+	}}
+	service := NewPlannerService(alwaysAvailable{}, tasks, untrackedTimeRepository{})
+
+	plan, err := service.GetPlan(ctx, 7, 4)
+	if err != nil {
+		t.Fatalf("GetPlan() error = %v", err)
+	}
+	if len(plan.Unscheduled) != 0 {
+		t.Fatalf("GetPlan() left %+v unscheduled, want every task planned", plan.Unscheduled)
+	}
+
+	var order []int64
+	planned := make(map[int64]float64)
+	for _, day := range plan.Days {
+		for _, session := range day.Sessions {
+			if len(order) == 0 || order[len(order)-1] != session.TaskID {
+				order = append(order, session.TaskID)
+			}
+			planned[session.TaskID] += session.End.Sub(session.Start).Hours()
+		}
+	}
+	if len(order) != 2 || order[0] != 2 || order[1] != 1 {
+		t.Errorf("GetPlan() worked on tasks %v, want the dated task 2 before the undated task 1", order)
+	}
+	for id, hours := range planned {
+		if hours != 2 {
+			t.Errorf("GetPlan() planned %v hours on task %d, want 2", hours, id)
+		}
+	}
+}
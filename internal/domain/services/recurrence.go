@@ -0,0 +1,329 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"uni-task-manager/internal/domain/models"
+)
+
+// Domain-specific errors related to recurring tasks
+var (
+	// ErrInvalidRecurrence indicates that a recurrence rule is malformed
+	ErrInvalidRecurrence = errors.New("invalid recurrence rule")
+
+	// ErrTaskNotRecurring indicates that the task doesn't belong to a recurring series
+	ErrTaskNotRecurring = errors.New("task is not recurring")
+)
+
+// maxRecurrencePeriods bounds the search for the next occurrence of a rule,
+// so a rule whose every candidate is excluded cannot loop forever.
+const maxRecurrencePeriods = 10000
+
+// CreateRecurringTask implements input.TaskService.CreateRecurringTask.
+// The task and the rule are validated before either is saved, and saved together.
+func (s *TaskService) CreateRecurringTask(ctx context.Context, task *models.Task, rule *models.Recurrence) error {
+	return s.createTask(ctx, task, rule)
+}
+
+// SetRecurrence implements input.TaskService.SetRecurrence.
+// It creates the rule of a new series starting at the task, or replaces the rule of its existing series.
+// A task starting a series is updated, so the change is recorded in its history and published.
+func (s *TaskService) SetRecurrence(ctx context.Context, taskID int64, rule *models.Recurrence) error {
+	if err := validateRecurrence(rule); err != nil {
+		return err
+	}
+
+	existing, err := s.GetTask(ctx, taskID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	rule.OwnerID = existing.OwnerID
+	rule.UpdatedAt = now
+
+	// The rule, the task joining its series, its history and its event are saved together
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		if existing.RecurrenceID != 0 {
+			current, err := s.recurrenceRepo.GetByID(ctx, existing.OwnerID, existing.RecurrenceID)
+			if err != nil {
+				return err
+			}
+			if current != nil {
+				rule.ID = current.ID
+				rule.Occurrences = current.Occurrences
+				rule.CreatedAt = current.CreatedAt
+				return s.recurrenceRepo.Update(ctx, rule)
+			}
+		}
+
+		rule.Occurrences = 1
+		rule.CreatedAt = now
+		if err := s.recurrenceRepo.Create(ctx, rule); err != nil {
+			return err
+		}
+
+		task := *existing
+		task.RecurrenceID = rule.ID
+		task.UpdatedAt = now
+		if err := s.taskRepo.Update(ctx, &task); err != nil {
+			return translateStorageError(err)
+		}
+
+		if err := recordTaskChange(ctx, s.historyRepo, models.TaskChangeUpdated, existing, &task); err != nil {
+			return err
+		}
+		return s.publishTask(ctx, models.EventTaskUpdated, &task, "")
+	})
+}
+
+// RemoveRecurrence implements input.TaskService.RemoveRecurrence.
+// It deletes the rule of the task's series; the occurrences already created are kept, but leave
+// the series, so each of them is updated, recorded in its history and published.
+func (s *TaskService) RemoveRecurrence(ctx context.Context, taskID int64) error {
+	// The occurrences leaving the series, their history, their events and the rule deletion are saved together
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		task, err := s.GetTask(ctx, taskID)
+		if err != nil {
+			return err
+		}
+		if task.RecurrenceID == 0 {
+			return ErrTaskNotRecurring
+		}
+
+		series, err := s.taskRepo.GetByRecurrenceID(ctx, task.OwnerID, task.RecurrenceID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		for _, before := range series {
+			after := before
+			after.RecurrenceID = 0
+			after.UpdatedAt = now
+			if err := s.taskRepo.Update(ctx, &after); err != nil {
+				return translateStorageError(err)
+			}
+
+			if err := recordTaskChange(ctx, s.historyRepo, models.TaskChangeUpdated, &before, &after); err != nil {
+				return err
+			}
+			if err := s.publishTask(ctx, models.EventTaskUpdated, &after, ""); err != nil {
+				return err
+			}
+		}
+
+		return s.recurrenceRepo.Delete(ctx, task.OwnerID, task.RecurrenceID)
+	})
+}
+
+// GetSeries implements input.TaskService.GetSeries.
+// It retrieves the recurrence rule of the task together with every occurrence of its series.
+func (s *TaskService) GetSeries(ctx context.Context, taskID int64) (*models.TaskSeries, error) {
+	task, err := s.GetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if task.RecurrenceID == 0 {
+		return nil, ErrTaskNotRecurring
+	}
+
+	rule, err := s.recurrenceRepo.GetByID(ctx, task.OwnerID, task.RecurrenceID)
+	if err != nil {
+		return nil, err
+	}
+	if rule == nil {
+		return nil, ErrTaskNotRecurring
+	}
+
+	tasks, err := s.taskRepo.GetByRecurrenceID(ctx, task.OwnerID, rule.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.TaskSeries{Recurrence: rule, Tasks: tasks}, nil
+}
+
+// materializeNextOccurrence creates the task following a completed occurrence of a series.
+// Nothing is created if a later occurrence already exists, or if the rule's count or end date
+// is exhausted. The new occurrence always lies in the future, so it passes validateTask, and
+// carries the tags of the completed one.
+func (s *TaskService) materializeNextOccurrence(ctx context.Context, completed *models.Task) error {
+	rule, err := s.recurrenceRepo.GetByID(ctx, completed.OwnerID, completed.RecurrenceID)
+	if err != nil || rule == nil {
+		return err
+	}
+
+	series, err := s.taskRepo.GetByRecurrenceID(ctx, completed.OwnerID, rule.ID)
+	if err != nil {
+		return err
+	}
+	for _, task := range series {
+		if task.DueDate.After(completed.DueDate) {
+			return nil
+		}
+	}
+	// Deleted occurrences still count towards the cap, so the total is kept on the rule
+	if rule.Count > 0 && rule.Occurrences >= rule.Count {
+		return nil
+	}
+
+	// Occurrences are computed from the first task of the series, so monthly
+	// and weekly rules don't drift when an occurrence is skipped.
+	anchor := completed.DueDate
+	if len(series) > 0 {
+		anchor = series[0].DueDate
+	}
+
+	after := completed.DueDate
+	if now := time.Now(); now.After(after) {
+		after = now
+	}
+
+	due, ok := nextOccurrence(rule, anchor, after)
+	if !ok {
+		return nil
+	}
+
+	next := &models.Task{
+		Title:          completed.Title,
+		Description:    completed.Description,
+		DueDate:        due,
+		Priority:       completed.Priority,
+		Status:         models.TaskStatusPending,
+		EstimatedHours: completed.EstimatedHours,
+		CourseID:       completed.CourseID,
+		ParentID:       completed.ParentID,
+		RecurrenceID:   rule.ID,
+	}
+	if err := s.CreateTask(ctx, next); err != nil {
+		return err
+	}
+
+	rule.Occurrences++
+	rule.UpdatedAt = time.Now().UTC()
+	if err := s.recurrenceRepo.Update(ctx, rule); err != nil {
+		return err
+	}
+
+	// The new occurrence is labelled like the one it follows
+	tags, err := s.tagRepo.GetByTaskID(ctx, completed.OwnerID, completed.ID)
+	if err != nil || len(tags) == 0 {
+		return err
+	}
+	tagIDs := make([]int64, len(tags))
+	for i, tag := range tags {
+		tagIDs[i] = tag.ID
+	}
+	return s.tagRepo.SetTaskTags(ctx, next.ID, tagIDs)
+}
+
+// validateRecurrence checks a recurrence rule and fills in the default interval.
+func validateRecurrence(rule *models.Recurrence) error {
+	switch rule.Frequency {
+	case models.RecurrenceDaily, models.RecurrenceMonthly:
+		if len(rule.ByWeekday) > 0 {
+			return fmt.Errorf("%w: weekdays can only be used with a weekly frequency", ErrInvalidRecurrence)
+		}
+	case models.RecurrenceWeekly:
+	default:
+		return fmt.Errorf("%w: unknown frequency %q", ErrInvalidRecurrence, rule.Frequency)
+	}
+
+	if rule.Interval == 0 {
+		rule.Interval = 1
+	}
+	if rule.Interval < 0 {
+		return fmt.Errorf("%w: interval must be positive", ErrInvalidRecurrence)
+	}
+
+	for _, day := range rule.ByWeekday {
+		if day < time.Sunday || day > time.Saturday {
+			return fmt.Errorf("%w: invalid weekday %d", ErrInvalidRecurrence, day)
+		}
+	}
+
+	if rule.Count < 0 {
+		return fmt.Errorf("%w: count cannot be negative", ErrInvalidRecurrence)
+	}
+	if rule.Count > 0 && !rule.Until.IsZero() {
+		return fmt.Errorf("%w: count and until are mutually exclusive", ErrInvalidRecurrence)
+	}
+
+	return nil
+}
+
+// nextOccurrence returns the first occurrence of the rule strictly after the given time,
+// skipping exception dates. The anchor is the due date of the first task of the series.
+// Returns false when the rule has no further occurrence.
+func nextOccurrence(rule *models.Recurrence, anchor, after time.Time) (time.Time, bool) {
+	for period := 0; period < maxRecurrencePeriods; period++ {
+		for _, candidate := range occurrencesInPeriod(rule, anchor, period) {
+			if !candidate.After(after) || isExDate(rule, candidate) {
+				continue
+			}
+			if !rule.Until.IsZero() && candidate.After(rule.Until) {
+				return time.Time{}, false
+			}
+			return candidate, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// occurrencesInPeriod lists, in chronological order, the candidate occurrences of the
+// n-th period (day, week or month, times the interval) after the anchor.
+func occurrencesInPeriod(rule *models.Recurrence, anchor time.Time, period int) []time.Time {
+	step := period * rule.Interval
+
+	switch rule.Frequency {
+	case models.RecurrenceDaily:
+		return []time.Time{anchor.AddDate(0, 0, step)}
+
+	case models.RecurrenceMonthly:
+		// Months without the anchor's day (e.g. the 31st) are skipped, as in RFC 5545
+		candidate := anchor.AddDate(0, step, 0)
+		if candidate.Day() != anchor.Day() {
+			return nil
+		}
+		return []time.Time{candidate}
+
+	case models.RecurrenceWeekly:
+		days := rule.ByWeekday
+		if len(days) == 0 {
+			days = []time.Weekday{anchor.Weekday()}
+		}
+
+		weekStart := anchor.AddDate(0, 0, -mondayOffset(anchor.Weekday())+7*step)
+		candidates := make([]time.Time, 0, len(days))
+		for _, day := range days {
+			candidates = append(candidates, weekStart.AddDate(0, 0, mondayOffset(day)))
+		}
+		sort.Slice(candidates, func(i, j int) bool { return candidates[i].Before(candidates[j]) })
+		return candidates
+	}
+
+	return nil
+}
+
+// mondayOffset returns the number of days between Monday and the given weekday,
+// weeks being considered to start on Monday.
+func mondayOffset(day time.Weekday) int {
+	return (int(day) + 6) % 7
+}
+
+// isExDate reports whether an occurrence falls on one of the rule's exception dates.
+func isExDate(rule *models.Recurrence, occurrence time.Time) bool {
+	y, m, d := occurrence.UTC().Date()
+	for _, exDate := range rule.ExDates {
+		ey, em, ed := exDate.UTC().Date()
+		if y == ey && m == em && d == ed {
+			return true
+		}
+	}
+	return false
+}
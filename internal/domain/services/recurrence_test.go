@@ -0,0 +1,359 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"uni-task-manager/internal/domain/models"
+	"uni-task-manager/internal/ports/output"
+)
+
+func TestNextOccurrence(t *testing.T) {
+	// 2030-01-01 is a Tuesday
+	anchor := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+	day := func(month time.Month, d int) time.Time { return time.Date(2030, month, d, 9, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name   string
+		rule   models.Recurrence
+		anchor time.Time
+		after  time.Time
+		want   time.Time
+		wantOK bool
+	}{
+		{
+			name:   "daily",
+			rule:   models.Recurrence{Frequency: models.RecurrenceDaily, Interval: 1},
+			anchor: anchor, after: anchor,
+			want: day(1, 2), wantOK: true,
+		},
+		{
+			name:   "every third day",
+			rule:   models.Recurrence{Frequency: models.RecurrenceDaily, Interval: 3},
+			anchor: anchor, after: day(1, 4),
+			want: day(1, 7), wantOK: true,
+		},
+		{
+			name:   "weekly on the anchor's weekday",
+			rule:   models.Recurrence{Frequency: models.RecurrenceWeekly, Interval: 1},
+			anchor: anchor, after: anchor,
+			want: day(1, 8), wantOK: true,
+		},
+		{
+			name:   "weekly on Tuesdays and Thursdays",
+			rule:   models.Recurrence{Frequency: models.RecurrenceWeekly, Interval: 1, ByWeekday: []time.Weekday{time.Thursday, time.Tuesday}},
+			anchor: anchor, after: anchor,
+			want: day(1, 3), wantOK: true,
+		},
+		{
+			name:   "every other week on Tuesdays and Thursdays",
+			rule:   models.Recurrence{Frequency: models.RecurrenceWeekly, Interval: 2, ByWeekday: []time.Weekday{time.Tuesday, time.Thursday}},
+			anchor: anchor, after: day(1, 3),
+			want: day(1, 15), wantOK: true,
+		},
+		{
+			name:   "monthly skips months without the anchor's day",
+			rule:   models.Recurrence{Frequency: models.RecurrenceMonthly, Interval: 1},
+			anchor: day(1, 31), after: day(1, 31),
+			want: day(3, 31), wantOK: true,
+		},
+		{
+			name:   "monthly on the 30th skips February only",
+			rule:   models.Recurrence{Frequency: models.RecurrenceMonthly, Interval: 1},
+			anchor: day(1, 30), after: day(2, 1),
+			want: day(3, 30), wantOK: true,
+		},
+		{
+			name:   "exception dates are skipped",
+			rule:   models.Recurrence{Frequency: models.RecurrenceDaily, Interval: 1, ExDates: []time.Time{time.Date(2030, 1, 2, 0, 0, 0, 0, time.UTC)}},
+			anchor: anchor, after: anchor,
+			want: day(1, 3), wantOK: true,
+		},
+		{
+			name:   "until is inclusive",
+			rule:   models.Recurrence{Frequency: models.RecurrenceDaily, Interval: 1, Until: day(1, 2)},
+			anchor: anchor, after: anchor,
+			want: day(1, 2), wantOK: true,
+		},
+		{
+			name:   "until ends the series",
+			rule:   models.Recurrence{Frequency: models.RecurrenceDaily, Interval: 1, Until: time.Date(2030, 1, 1, 23, 59, 59, 0, time.UTC)},
+			anchor: anchor, after: anchor,
+			wantOK: false,
+		},
+		{
+			name: "an exception on the last day before until ends the series",
+			rule: models.Recurrence{Frequency: models.RecurrenceWeekly, Interval: 1, Until: day(1, 10),
+				ExDates: []time.Time{time.Date(2030, 1, 8, 0, 0, 0, 0, time.UTC)}},
+			anchor: anchor, after: anchor,
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := nextOccurrence(&tt.rule, tt.anchor, tt.after)
+			if ok != tt.wantOK {
+				t.Fatalf("nextOccurrence() ok = %v, want %v (got %v)", ok, tt.wantOK, got)
+			}
+			if ok && !got.Equal(tt.want) {
+				t.Errorf("nextOccurrence() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateRecurrence(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    models.Recurrence
+		wantErr bool
+	}{
+		{"daily", models.Recurrence{Frequency: models.RecurrenceDaily}, false},
+		{"weekly on weekdays", models.Recurrence{Frequency: models.RecurrenceWeekly, ByWeekday: []time.Weekday{time.Monday}}, false},
+		{"unknown frequency", models.Recurrence{Frequency: "yearly"}, true},
+		{"weekdays of a daily rule", models.Recurrence{Frequency: models.RecurrenceDaily, ByWeekday: []time.Weekday{time.Monday}}, true},
+		{"negative interval", models.Recurrence{Frequency: models.RecurrenceDaily, Interval: -1}, true},
+		{"invalid weekday", models.Recurrence{Frequency: models.RecurrenceWeekly, ByWeekday: []time.Weekday{7}}, true},
+		{"negative count", models.Recurrence{Frequency: models.RecurrenceDaily, Count: -1}, true},
+		{"count and until", models.Recurrence{Frequency: models.RecurrenceDaily, Count: 3, Until: time.Now()}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateRecurrence(&tt.rule)
+			if tt.wantErr != errors.Is(err, ErrInvalidRecurrence) {
+				t.Fatalf("validateRecurrence() = %v, want error %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && tt.rule.Interval != 1 {
+				t.Errorf("validateRecurrence() left interval %d, want the default 1", tt.rule.Interval)
+			}
+		})
+	}
+}
+
+// seriesTaskRepository serves the tasks of a single series and records the created ones
+type seriesTaskRepository struct {
+	output.TaskRepository
+	series  []models.Task
+	created []models.Task
+}
+
+func (r *seriesTaskRepository) GetByRecurrenceID(ctx context.Context, ownerID, recurrenceID int64) ([]models.Task, error) {
+	return r.series, nil
+}
+
+func (r *seriesTaskRepository) Create(ctx context.Context, task *models.Task) error {
+	task.ID = int64(100 + len(r.created))
+	r.created = append(r.created, *task)
+	return nil
+}
+
+func (r *seriesTaskRepository) GetByID(ctx context.Context, ownerID, id int64) (*models.Task, error) {
+	for _, task := range r.series {
+		if task.ID == id {
+			return &task, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *seriesTaskRepository) Update(ctx context.Context, task *models.Task) error {
+	if ctx.Value(inWorkKey{}) == nil {
+		return errOutsideWork
+	}
+	for i := range r.series {
+		if r.series[i].ID == task.ID {
+			task.Version++
+			r.series[i] = *task
+		}
+	}
+	return nil
+}
+
+// ruleRepository serves a single recurrence rule
+type ruleRepository struct {
+	output.RecurrenceRepository
+	rule    *models.Recurrence
+	deleted bool
+}
+
+func (r *ruleRepository) GetByID(ctx context.Context, ownerID, id int64) (*models.Recurrence, error) {
+	return r.rule, nil
+}
+
+func (r *ruleRepository) Update(ctx context.Context, rule *models.Recurrence) error {
+	r.rule = rule
+	return nil
+}
+
+func (r *ruleRepository) Delete(ctx context.Context, ownerID, id int64) error {
+	if ctx.Value(inWorkKey{}) == nil {
+		return errOutsideWork
+	}
+	r.deleted = true
+	return nil
+}
+
+// historyRecorder keeps the recorded history entries
+type historyRecorder struct {
+	output.TaskHistoryRepository
+	entries []models.TaskHistoryEntry
+}
+
+func (r *historyRecorder) Add(ctx context.Context, entry *models.TaskHistoryEntry) error {
+	r.entries = append(r.entries, *entry)
+	return nil
+}
+
+// eventRecorder keeps the published events
+type eventRecorder struct {
+	events []models.Event
+}
+
+func (r *eventRecorder) Publish(ctx context.Context, event models.Event) error {
+	r.events = append(r.events, event)
+	return nil
+}
+
+// untaggedRepository reports every task as untagged
+type untaggedRepository struct {
+	output.TagRepository
+}
+
+func (r *untaggedRepository) GetByTaskID(ctx context.Context, ownerID, taskID int64) ([]models.Tag, error) {
+	return nil, nil
+}
+
+// immediateUnitOfWork runs the work without a transaction
+type immediateUnitOfWork struct{}
+
+func (immediateUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// discardedEvents drops every published event
+type discardedEvents struct{}
+
+func (discardedEvents) Publish(ctx context.Context, event models.Event) error {
+	return nil
+}
+
+func TestMaterializeNextOccurrence(t *testing.T) {
+	ctx := ContextWithUserID(context.Background(), 1)
+	week := func(n int) time.Time { return time.Date(2030, 1, 1+7*n, 9, 0, 0, 0, time.UTC) }
+	occurrence := func(id int64, n int) models.Task {
+		return models.Task{ID: id, OwnerID: 1, Title: "Lab", DueDate: week(n), Priority: 3, RecurrenceID: 7, Status: models.TaskStatusCompleted}
+	}
+
+	tests := []struct {
+		name   string
+		rule   models.Recurrence
+		series []models.Task
+		want   []time.Time
+	}{
+		{
+			name:   "creates the next occurrence",
+			rule:   models.Recurrence{ID: 7, Frequency: models.RecurrenceWeekly, Interval: 1, Count: 3, Occurrences: 2},
+			series: []models.Task{occurrence(1, 0), occurrence(2, 1)},
+			want:   []time.Time{week(2)},
+		},
+		{
+			name:   "stops once count occurrences exist",
+			rule:   models.Recurrence{ID: 7, Frequency: models.RecurrenceWeekly, Interval: 1, Count: 2, Occurrences: 2},
+			series: []models.Task{occurrence(1, 0), occurrence(2, 1)},
+		},
+		{
+			name:   "counts deleted occurrences",
+			rule:   models.Recurrence{ID: 7, Frequency: models.RecurrenceWeekly, Interval: 1, Count: 3, Occurrences: 3},
+			series: []models.Task{occurrence(1, 0), occurrence(3, 2)},
+		},
+		{
+			name:   "stops after until",
+			rule:   models.Recurrence{ID: 7, Frequency: models.RecurrenceWeekly, Interval: 1, Until: week(1)},
+			series: []models.Task{occurrence(1, 0), occurrence(2, 1)},
+		},
+		{
+			name:   "skips exception dates",
+			rule:   models.Recurrence{ID: 7, Frequency: models.RecurrenceWeekly, Interval: 1, ExDates: []time.Time{week(2)}},
+			series: []models.Task{occurrence(1, 0), occurrence(2, 1)},
+			want:   []time.Time{week(3)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks := &seriesTaskRepository{series: tt.series}
+			rules := &ruleRepository{rule: &tt.rule}
+			generated := tt.rule.Occurrences
+			service := NewTaskService(tasks, nil, rules, &untaggedRepository{}, nil, nil, immediateUnitOfWork{}, discardedEvents{})
+
+			completed := tt.series[len(tt.series)-1]
+			if err := service.materializeNextOccurrence(ctx, &completed); err != nil {
+				t.Fatalf("materializeNextOccurrence() error = %v", err)
+			}
+
+			if len(tasks.created) != len(tt.want) {
+				t.Fatalf("created %d occurrences, want %d", len(tasks.created), len(tt.want))
+			}
+			for i, task := range tasks.created {
+				if !task.DueDate.Equal(tt.want[i]) {
+					t.Errorf("occurrence due %v, want %v", task.DueDate, tt.want[i])
+				}
+				if task.RecurrenceID != tt.rule.ID || task.Status != models.TaskStatusPending {
+					t.Errorf("occurrence = %+v, want a pending task of series %d", task, tt.rule.ID)
+				}
+			}
+			if want := generated + len(tt.want); rules.rule.Occurrences != want {
+				t.Errorf("rule occurrences = %d, want %d", rules.rule.Occurrences, want)
+			}
+		})
+	}
+}
+
+func TestRemoveRecurrence(t *testing.T) {
+	ctx := ContextWithUserID(context.Background(), 1)
+	tasks := &seriesTaskRepository{series: []models.Task{
+		{ID: 1, OwnerID: 1, Title: "Lab", RecurrenceID: 7, Status: models.TaskStatusCompleted, Version: 2},
+		{ID: 2, OwnerID: 1, Title: "Lab", RecurrenceID: 7, Status: models.TaskStatusPending, Version: 1},
+	}}
+	rules := &ruleRepository{rule: &models.Recurrence{ID: 7, OwnerID: 1, Frequency: models.RecurrenceWeekly, Interval: 1}}
+	history := &historyRecorder{}
+	events := &eventRecorder{}
+	service := NewTaskService(tasks, nil, rules, nil, nil, history, trackedUnitOfWork{}, events)
+
+	if err := service.RemoveRecurrence(ctx, 2); err != nil {
+		t.Fatalf("RemoveRecurrence() error = %v", err)
+	}
+
+	if !rules.deleted {
+		t.Error("RemoveRecurrence() kept the rule, want it deleted")
+	}
+	for _, task := range tasks.series {
+		if task.RecurrenceID != 0 {
+			t.Errorf("task %d recurrence_id = %d, want it to leave the series", task.ID, task.RecurrenceID)
+		}
+	}
+	if len(history.entries) != 2 {
+		t.Fatalf("recorded %d history entries, want 2", len(history.entries))
+	}
+	for _, entry := range history.entries {
+		if entry.Action != models.TaskChangeUpdated || len(entry.Changes) != 1 || entry.Changes[0].Field != "recurrence_id" {
+			t.Errorf("history entry = %+v, want an update of recurrence_id", entry)
+		}
+	}
+	if len(events.events) != 2 {
+		t.Fatalf("published %d events, want 2", len(events.events))
+	}
+	for _, event := range events.events {
+		if event.Type != models.EventTaskUpdated || event.Task.RecurrenceID != 0 {
+			t.Errorf("event = %s of %+v, want task.updated without recurrence", event.Type, event.Task)
+		}
+	}
+
+	if err := service.RemoveRecurrence(ctx, 2); !errors.Is(err, ErrTaskNotRecurring) {
+		t.Errorf("RemoveRecurrence() again error = %v, want %v", err, ErrTaskNotRecurring)
+	}
+}
@@ -0,0 +1,227 @@
+// Package services implements the core business logic for task reminders
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"uni-task-manager/internal/domain/models"
+	"uni-task-manager/internal/ports/input"
+	"uni-task-manager/internal/ports/output"
+)
+
+// MaxReminderAttempts caps the number of times the delivery of a reminder on a channel is attempted
+const MaxReminderAttempts = 5
+
+// DefaultReminderRules remind about a task two days and two hours before it is due,
+// and every day once it is overdue.
+var DefaultReminderRules = []models.ReminderRule{
+	{Name: "due-48h", Offset: -48 * time.Hour},
+	{Name: "due-2h", Offset: -2 * time.Hour},
+	{Name: "overdue", Repeat: 24 * time.Hour},
+}
+
+// Verify ReminderService implements input.ReminderService interface at compile time
+var _ input.ReminderService = (*ReminderService)(nil)
+
+// ReminderService implements reminders: it evaluates the reminder rules against the due dates of the
+// unfinished tasks and delivers the reminders that are due through every notifier, once per channel.
+type ReminderService struct {
+	reminderRepo output.ReminderRepository
+	taskRepo     output.TaskRepository
+	userRepo     output.UserRepository
+	notifiers    []output.Notifier
+	rules        []models.ReminderRule
+}
+
+// NewReminderService creates a new instance of ReminderService with the required dependencies.
+// Reminders follow the given rules, or DefaultReminderRules if there are none.
+func NewReminderService(reminderRepo output.ReminderRepository, taskRepo output.TaskRepository, userRepo output.UserRepository, notifiers []output.Notifier, rules []models.ReminderRule) *ReminderService {
+	if len(rules) == 0 {
+		rules = DefaultReminderRules
+	}
+	return &ReminderService{
+		reminderRepo: reminderRepo,
+		taskRepo:     taskRepo,
+		userRepo:     userRepo,
+		notifiers:    notifiers,
+		rules:        rules,
+	}
+}
+
+// SendDueReminders implements input.ReminderService.SendDueReminders.
+// Only the latest reminder due before a task is due is sent, so a task created shortly before
+// its deadline doesn't receive the earlier reminders all at once; likewise repeating reminders
+// missed while the scheduler wasn't running are caught up with a single reminder. Failed
+// deliveries are retried on later passes, up to MaxReminderAttempts per channel.
+func (s *ReminderService) SendDueReminders(ctx context.Context, now time.Time) (*models.ReminderRun, error) {
+	run := &models.ReminderRun{}
+	if len(s.notifiers) == 0 {
+		return run, nil
+	}
+
+	var lead time.Duration
+	for _, rule := range s.rules {
+		lead = max(lead, -rule.Offset)
+	}
+
+	tasks, err := s.taskRepo.GetUnfinishedDueBefore(ctx, now.Add(lead).Add(time.Second))
+	if err != nil {
+		return nil, err
+	}
+
+	users := make(map[int64]*models.User)
+	for _, task := range tasks {
+		reminders := s.dueReminders(task, now)
+		if len(reminders) == 0 {
+			continue
+		}
+
+		user, ok := users[task.OwnerID]
+		if !ok {
+			if user, err = s.userRepo.GetByID(ctx, task.OwnerID); err != nil {
+				return nil, err
+			}
+			users[task.OwnerID] = user
+		}
+		if user == nil {
+			continue
+		}
+
+		deliveries, err := s.reminderRepo.GetByTask(ctx, task.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, reminder := range reminders {
+			notification := newReminderNotification(user, task, reminder.rule, reminder.scheduledAt, now)
+			for _, notifier := range s.notifiers {
+				delivery := findDelivery(deliveries, reminder.rule.Name, reminder.scheduledAt, notifier.Channel())
+				if delivery == nil {
+					delivery = &models.ReminderDelivery{
+						OwnerID:     task.OwnerID,
+						TaskID:      task.ID,
+						Rule:        reminder.rule.Name,
+						ScheduledAt: reminder.scheduledAt,
+						Channel:     notifier.Channel(),
+					}
+				}
+				if delivery.Status == models.DeliveryStatusSent || delivery.Attempts >= MaxReminderAttempts {
+					continue
+				}
+
+				delivery.Attempts++
+				delivery.UpdatedAt = now
+				if err := notifier.Notify(ctx, notification); err != nil {
+					delivery.Status = models.DeliveryStatusFailed
+					delivery.LastError = err.Error()
+					run.Failed++
+				} else {
+					delivery.Status = models.DeliveryStatusSent
+					delivery.LastError = ""
+					run.Sent++
+				}
+				if err := s.reminderRepo.Save(ctx, delivery); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	return run, nil
+}
+
+// dueReminder is a reminder that is due, with the time it was scheduled for.
+type dueReminder struct {
+	rule        models.ReminderRule
+	scheduledAt time.Time
+}
+
+// dueReminders selects the reminders about a task that are due at the given time: the latest of the
+// rules before the due date while the task isn't due yet, and the latest occurrence of every other rule.
+// A task without a due date has no reminders.
+func (s *ReminderService) dueReminders(task models.Task, now time.Time) []dueReminder {
+	if task.DueDate.IsZero() {
+		return nil
+	}
+
+	var reminders []dueReminder
+	var before *dueReminder
+	for _, rule := range s.rules {
+		scheduledAt := task.DueDate.Add(rule.Offset).UTC()
+		if scheduledAt.After(now) {
+			continue
+		}
+		if rule.Repeat > 0 {
+			scheduledAt = scheduledAt.Add(now.Sub(scheduledAt) / rule.Repeat * rule.Repeat)
+		}
+
+		reminder := dueReminder{rule: rule, scheduledAt: scheduledAt}
+		if rule.Offset < 0 && rule.Repeat == 0 {
+			if now.Before(task.DueDate) && (before == nil || scheduledAt.After(before.scheduledAt)) {
+				before = &reminder
+			}
+			continue
+		}
+		reminders = append(reminders, reminder)
+	}
+	if before != nil {
+		reminders = append(reminders, *before)
+	}
+	return reminders
+}
+
+// findDelivery returns the delivery of a reminder on a channel, or nil if it wasn't attempted yet.
+func findDelivery(deliveries []models.ReminderDelivery, rule string, scheduledAt time.Time, channel string) *models.ReminderDelivery {
+	for i := range deliveries {
+		delivery := &deliveries[i]
+		if delivery.Rule == rule && delivery.ScheduledAt.Equal(scheduledAt) && delivery.Channel == channel {
+			return delivery
+		}
+	}
+	return nil
+}
+
+// newReminderNotification writes the reminder about a task to its owner.
+func newReminderNotification(user *models.User, task models.Task, rule models.ReminderRule, scheduledAt, now time.Time) models.Notification {
+	var subject string
+	if now.Before(task.DueDate) {
+		subject = fmt.Sprintf("Reminder: %q is due in %s", task.Title, describeDuration(task.DueDate.Sub(now)))
+	} else {
+		subject = fmt.Sprintf("Overdue: %q was due %s ago", task.Title, describeDuration(now.Sub(task.DueDate)))
+	}
+	body := fmt.Sprintf("Hi %s,\n\n%s.\nDue: %s\n\nMark the task as completed to stop its reminders.\n",
+		user.Name, subject, task.DueDate.UTC().Format("Mon, 02 Jan 2006 15:04 MST"))
+
+	return models.Notification{
+		UserID:      user.ID,
+		Email:       user.Email,
+		Name:        user.Name,
+		TaskID:      task.ID,
+		Title:       task.Title,
+		DueDate:     task.DueDate,
+		Rule:        rule.Name,
+		Subject:     subject,
+		Body:        body,
+		ScheduledAt: scheduledAt,
+	}
+}
+
+// describeDuration rounds a duration to whole days, hours or minutes for a reminder message.
+func describeDuration(d time.Duration) string {
+	plural := func(n int, unit string) string {
+		if n == 1 {
+			return "1 " + unit
+		}
+		return fmt.Sprintf("%d %ss", n, unit)
+	}
+	switch {
+	case d >= 48*time.Hour:
+		return plural(int(d.Round(24*time.Hour)/(24*time.Hour)), "day")
+	case d >= time.Hour:
+		return plural(int(d.Round(time.Hour)/time.Hour), "hour")
+	default:
+		return plural(max(1, int(d.Round(time.Minute)/time.Minute)), "minute")
+	}
+}
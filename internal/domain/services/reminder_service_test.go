@@ -0,0 +1,59 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"uni-task-manager/internal/domain/models"
+)
+
+func TestDueReminders(t *testing.T) {
+	now := time.Date(2024, 10, 10, 12, 0, 0, 0, time.UTC)
+	service := NewReminderService(nil, nil, nil, nil, nil)
+
+	tests := []struct {
+		name    string
+		dueDate time.Time
+		want    map[string]time.Time
+	}{
+		{
+			name:    "no due date",
+			dueDate: time.Time{},
+			want:    map[string]time.Time{},
+		},
+		{
+			name:    "far ahead",
+			dueDate: now.Add(72 * time.Hour),
+			want:    map[string]time.Time{},
+		},
+		{
+			name:    "within two days",
+			dueDate: now.Add(36 * time.Hour),
+			want:    map[string]time.Time{"due-48h": now.Add(-12 * time.Hour)},
+		},
+		{
+			name:    "within two hours keeps only the latest",
+			dueDate: now.Add(time.Hour),
+			want:    map[string]time.Time{"due-2h": now.Add(-time.Hour)},
+		},
+		{
+			name:    "overdue repeats daily",
+			dueDate: now.Add(-50 * time.Hour),
+			want:    map[string]time.Time{"overdue": now.Add(-2 * time.Hour)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := service.dueReminders(models.Task{DueDate: tt.dueDate}, now)
+			if len(got) != len(tt.want) {
+				t.Fatalf("dueReminders() = %v, want %v", got, tt.want)
+			}
+			for _, reminder := range got {
+				if want, ok := tt.want[reminder.rule.Name]; !ok || !reminder.scheduledAt.Equal(want) {
+					t.Errorf("reminder %s scheduled at %v, want %v", reminder.rule.Name, reminder.scheduledAt, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,286 @@
+// Package services implements the core business logic for tags
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"uni-task-manager/internal/domain/models"
+	"uni-task-manager/internal/ports/input"
+	"uni-task-manager/internal/ports/output"
+)
+
+// Domain-specific errors that can be returned by the TagService
+var (
+	// ErrTagNotFound indicates that the requested tag does not exist
+	ErrTagNotFound = errors.New("tag not found")
+
+	// ErrInvalidTagName indicates that a tag name is empty, too long or contains a comma
+	ErrInvalidTagName = errors.New("tag name must be 1 to 32 characters long and cannot contain commas")
+
+	// ErrTagExists indicates that the owner already has a tag with the same name
+	ErrTagExists = errors.New("a tag with this name already exists")
+)
+
+// MaxTagNameLength caps the number of characters of a tag name
+const MaxTagNameLength = 32
+
+// Verify TagService implements input.TagService interface at compile time
+var _ input.TagService = (*TagService)(nil)
+
+// TagService implements the tag-related business logic and orchestrates
+// interactions between the domain model and storage layer.
+type TagService struct {
+	tagRepo  output.TagRepository
+	taskRepo output.TaskRepository
+	uow      output.UnitOfWork
+}
+
+// NewTagService creates a new instance of TagService with the required dependencies.
+func NewTagService(tagRepo output.TagRepository, taskRepo output.TaskRepository, uow output.UnitOfWork) *TagService {
+	return &TagService{
+		tagRepo:  tagRepo,
+		taskRepo: taskRepo,
+		uow:      uow,
+	}
+}
+
+// CreateTag implements input.TagService.CreateTag.
+// It normalizes and validates the name, which must not be taken by another tag of the user.
+func (s *TagService) CreateTag(ctx context.Context, tag *models.Tag) error {
+	ownerID, err := currentUserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	tag.Name = normalizeTagName(tag.Name)
+	if err := validateTagName(tag.Name); err != nil {
+		return err
+	}
+
+	existing, err := s.tagRepo.GetByName(ctx, ownerID, tag.Name)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return ErrTagExists
+	}
+
+	tag.OwnerID = ownerID
+	tag.CreatedAt = time.Now().UTC()
+
+	return s.tagRepo.Create(ctx, tag)
+}
+
+// UpdateTag implements input.TagService.UpdateTag.
+// It renames an existing tag, provided no other tag of the user has the new name.
+func (s *TagService) UpdateTag(ctx context.Context, tag *models.Tag) error {
+	ownerID, err := currentUserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	tag.Name = normalizeTagName(tag.Name)
+	if err := validateTagName(tag.Name); err != nil {
+		return err
+	}
+
+	existing, err := s.tagRepo.GetByID(ctx, ownerID, tag.ID)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return ErrTagNotFound
+	}
+
+	other, err := s.tagRepo.GetByName(ctx, ownerID, tag.Name)
+	if err != nil {
+		return err
+	}
+	if other != nil && other.ID != tag.ID {
+		return ErrTagExists
+	}
+
+	tag.OwnerID = ownerID
+	tag.CreatedAt = existing.CreatedAt
+
+	return s.tagRepo.Update(ctx, tag)
+}
+
+// GetTag implements input.TagService.GetTag.
+// It retrieves a specific tag by its ID.
+func (s *TagService) GetTag(ctx context.Context, id int64) (*models.Tag, error) {
+	ownerID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tag, err := s.tagRepo.GetByID(ctx, ownerID, id)
+	if err != nil {
+		return nil, err
+	}
+	if tag == nil {
+		return nil, ErrTagNotFound
+	}
+	return tag, nil
+}
+
+// GetAllTags implements input.TagService.GetAllTags.
+// It retrieves all tags of the current user from the repository.
+func (s *TagService) GetAllTags(ctx context.Context) ([]models.Tag, error) {
+	ownerID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.tagRepo.GetAll(ctx, ownerID)
+}
+
+// DeleteTag implements input.TagService.DeleteTag.
+// It ensures the tag exists before deleting it; the tagged tasks are kept.
+func (s *TagService) DeleteTag(ctx context.Context, id int64) error {
+	ownerID, err := currentUserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	tag, err := s.tagRepo.GetByID(ctx, ownerID, id)
+	if err != nil {
+		return err
+	}
+	if tag == nil {
+		return ErrTagNotFound
+	}
+
+	return s.tagRepo.Delete(ctx, ownerID, id)
+}
+
+// GetTaskTags implements input.TagService.GetTaskTags.
+// It ensures the task exists before retrieving its tags.
+func (s *TagService) GetTaskTags(ctx context.Context, taskID int64) ([]models.Tag, error) {
+	ownerID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := s.taskRepo.GetByID(ctx, ownerID, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if task == nil {
+		return nil, ErrTaskNotFound
+	}
+
+	return s.tagRepo.GetByTaskID(ctx, ownerID, taskID)
+}
+
+// GetAllTaskTags implements input.TagService.GetAllTaskTags.
+// It retrieves the tags of every tagged task of the current user in one go.
+func (s *TagService) GetAllTaskTags(ctx context.Context) (map[int64][]models.Tag, error) {
+	ownerID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.tagRepo.GetAllByTask(ctx, ownerID)
+}
+
+// GetTagsOfTasks implements input.TagService.GetTagsOfTasks.
+// Only tagged tasks of the current user are included in the result.
+func (s *TagService) GetTagsOfTasks(ctx context.Context, taskIDs []int64) (map[int64][]models.Tag, error) {
+	ownerID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.tagRepo.GetByTaskIDs(ctx, ownerID, taskIDs)
+}
+
+// SetTaskTags implements input.TagService.SetTaskTags.
+// Names are normalized and deduplicated; tags the user doesn't have yet are created.
+// Every name is validated before anything is stored.
+func (s *TagService) SetTaskTags(ctx context.Context, taskID int64, names []string) ([]models.Tag, error) {
+	ownerID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	names, err = normalizeTagNames(names)
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []models.Tag
+	// The new tags and the links of the task are saved together
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		task, err := s.taskRepo.GetByID(ctx, ownerID, taskID)
+		if err != nil {
+			return err
+		}
+		if task == nil {
+			return ErrTaskNotFound
+		}
+
+		if err := tagTask(ctx, s.tagRepo, ownerID, taskID, names); err != nil {
+			return err
+		}
+		tags, err = s.tagRepo.GetByTaskID(ctx, ownerID, taskID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// tagTask replaces the tags of a task with the tags of the given normalized names,
+// creating the tags the owner doesn't have yet. It is meant to run inside a unit of work.
+func tagTask(ctx context.Context, tagRepo output.TagRepository, ownerID, taskID int64, names []string) error {
+	tagIDs := make([]int64, 0, len(names))
+	for _, name := range names {
+		tag, err := tagRepo.GetByName(ctx, ownerID, name)
+		if err != nil {
+			return err
+		}
+		if tag == nil {
+			tag = &models.Tag{OwnerID: ownerID, Name: name, CreatedAt: time.Now().UTC()}
+			if err := tagRepo.Create(ctx, tag); err != nil {
+				return err
+			}
+		}
+		tagIDs = append(tagIDs, tag.ID)
+	}
+	return tagRepo.SetTaskTags(ctx, taskID, tagIDs)
+}
+
+// normalizeTagNames normalizes and deduplicates tag names, keeping their order.
+// Returns ErrInvalidTagName if any of them is rejected.
+func normalizeTagNames(names []string) ([]string, error) {
+	var normalized []string
+	seen := make(map[string]bool)
+	for _, name := range names {
+		name = normalizeTagName(name)
+		if err := validateTagName(name); err != nil {
+			return nil, err
+		}
+		if !seen[name] {
+			seen[name] = true
+			normalized = append(normalized, name)
+		}
+	}
+	return normalized, nil
+}
+
+// normalizeTagName lower-cases a tag name and joins its words with dashes,
+// so "Group Project" and "group-project" name the same tag.
+func normalizeTagName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), "-"))
+}
+
+// validateTagName checks a normalized tag name.
+func validateTagName(name string) error {
+	if name == "" || utf8.RuneCountInString(name) > MaxTagNameLength || strings.Contains(name, ",") {
+		return ErrInvalidTagName
+	}
+	return nil
+}
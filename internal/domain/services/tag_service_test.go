@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"uni-task-manager/internal/domain/models"
+	"uni-task-manager/internal/ports/output"
+)
+
+// labelRepository keeps tags and the tags of each task in memory; both can only be written
+// inside a unit of work
+type labelRepository struct {
+	output.TagRepository
+	tags  []models.Tag
+	links map[int64][]int64
+}
+
+func (r *labelRepository) GetByName(ctx context.Context, ownerID int64, name string) (*models.Tag, error) {
+	for _, tag := range r.tags {
+		if tag.OwnerID == ownerID && tag.Name == name {
+			return &tag, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *labelRepository) Create(ctx context.Context, tag *models.Tag) error {
+	if ctx.Value(inWorkKey{}) == nil {
+		return errOutsideWork
+	}
+	tag.ID = int64(len(r.tags) + 1)
+	r.tags = append(r.tags, *tag)
+	return nil
+}
+
+func (r *labelRepository) SetTaskTags(ctx context.Context, taskID int64, tagIDs []int64) error {
+	if ctx.Value(inWorkKey{}) == nil {
+		return errOutsideWork
+	}
+	if r.links == nil {
+		r.links = make(map[int64][]int64)
+	}
+	r.links[taskID] = tagIDs
+	return nil
+}
+
+func (r *labelRepository) GetByTaskID(ctx context.Context, ownerID, taskID int64) ([]models.Tag, error) {
+	var tags []models.Tag
+	for _, id := range r.links[taskID] {
+		tags = append(tags, r.tags[id-1])
+	}
+	return tags, nil
+}
+
+// names lists the names of the tags of a task
+func (r *labelRepository) names(taskID int64) []string {
+	var names []string
+	for _, id := range r.links[taskID] {
+		names = append(names, r.tags[id-1].Name)
+	}
+	return names
+}
+
+func TestSetTaskTags(t *testing.T) {
+	ctx := ContextWithUserID(context.Background(), 1)
+	tasks := &seriesTaskRepository{series: []models.Task{{ID: 1, OwnerID: 1, Title: "Essay"}}}
+	tags := &labelRepository{tags: []models.Tag{{ID: 1, OwnerID: 1, Name: "exam"}}}
+	service := NewTagService(tags, tasks, trackedUnitOfWork{})
+
+	if _, err := service.SetTaskTags(ctx, 1, []string{"Exam", "Group Project", "a,b"}); !errors.Is(err, ErrInvalidTagName) {
+		t.Fatalf("SetTaskTags() with an invalid name error = %v, want %v", err, ErrInvalidTagName)
+	}
+	if len(tags.tags) != 1 || len(tags.links) != 0 {
+		t.Fatalf("SetTaskTags() with an invalid name stored tags %+v and links %v, want nothing stored", tags.tags, tags.links)
+	}
+
+	got, err := service.SetTaskTags(ctx, 1, []string{"Exam", "Group Project", "exam"})
+	if err != nil {
+		t.Fatalf("SetTaskTags() error = %v", err)
+	}
+	if len(got) != 2 || got[0].Name != "exam" || got[1].Name != "group-project" {
+		t.Errorf("SetTaskTags() = %+v, want exam and group-project", got)
+	}
+	if len(tags.tags) != 2 {
+		t.Errorf("SetTaskTags() left %d tags, want the existing one and group-project", len(tags.tags))
+	}
+}
+
+func TestCreateTaskWithTags(t *testing.T) {
+	ctx := ContextWithUserID(context.Background(), 1)
+	newTask := func() *models.Task {
+		return &models.Task{Title: "Essay", DueDate: time.Now().AddDate(0, 0, 7), Priority: 3}
+	}
+
+	tasks := &seriesTaskRepository{}
+	tags := &labelRepository{}
+	service := NewTaskService(tasks, nil, nil, tags, nil, nil, trackedUnitOfWork{}, discardedEvents{})
+
+	if err := service.CreateTaskWithTags(ctx, newTask(), nil, []string{"exam", ""}); !errors.Is(err, ErrInvalidTagName) {
+		t.Fatalf("CreateTaskWithTags() with an invalid name error = %v, want %v", err, ErrInvalidTagName)
+	}
+	if len(tasks.created) != 0 || len(tags.tags) != 0 {
+		t.Fatalf("CreateTaskWithTags() with an invalid name created tasks %+v and tags %+v, want none", tasks.created, tags.tags)
+	}
+
+	task := newTask()
+	if err := service.CreateTaskWithTags(ctx, task, nil, []string{"Exam", "Group Project"}); err != nil {
+		t.Fatalf("CreateTaskWithTags() error = %v", err)
+	}
+	if len(tasks.created) != 1 {
+		t.Fatalf("CreateTaskWithTags() created %d tasks, want 1", len(tasks.created))
+	}
+	if got, want := tags.names(task.ID), []string{"exam", "group-project"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("tags of the created task = %v, want %v", got, want)
+	}
+}
+
+func TestUpdateTaskWithTags(t *testing.T) {
+	ctx := ContextWithUserID(context.Background(), 1)
+	dueDate := time.Now().UTC().AddDate(0, 0, 7)
+	stored := models.Task{ID: 1, OwnerID: 1, Title: "Essay", DueDate: dueDate, Priority: 3, Status: models.TaskStatusPending, Version: 1}
+
+	tasks := &seriesTaskRepository{series: []models.Task{stored}}
+	tags := &labelRepository{}
+	service := NewTaskService(tasks, nil, nil, tags, nil, &historyRecorder{}, trackedUnitOfWork{}, discardedEvents{})
+
+	update := stored
+	update.Title = "Final essay"
+	if err := service.UpdateTaskWithTags(ctx, &update, []string{"a,b"}); !errors.Is(err, ErrInvalidTagName) {
+		t.Fatalf("UpdateTaskWithTags() with an invalid name error = %v, want %v", err, ErrInvalidTagName)
+	}
+	if tasks.series[0].Title != "Essay" {
+		t.Fatalf("UpdateTaskWithTags() with an invalid name saved %+v, want the task unchanged", tasks.series[0])
+	}
+
+	update = stored
+	update.Title = "Final essay"
+	if err := service.UpdateTaskWithTags(ctx, &update, []string{"Exam"}); err != nil {
+		t.Fatalf("UpdateTaskWithTags() error = %v", err)
+	}
+	if tasks.series[0].Title != "Final essay" {
+		t.Errorf("UpdateTaskWithTags() saved %+v, want the new title", tasks.series[0])
+	}
+	if got, want := tags.names(1), []string{"exam"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("tags of the updated task = %v, want %v", got, want)
+	}
+}
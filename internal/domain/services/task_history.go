@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"uni-task-manager/internal/domain/models"
+	"uni-task-manager/internal/ports/output"
+)
+
+// ErrHistoryEntryNotFound indicates that the requested history entry doesn't exist or belongs to another task
+var ErrHistoryEntryNotFound = errors.New("history entry not found")
+
+// GetTaskHistory implements input.TaskService.GetTaskHistory.
+// An existing task without recorded changes has an empty history.
+func (s *TaskService) GetTaskHistory(ctx context.Context, id int64) ([]models.TaskHistoryEntry, error) {
+	ownerID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.historyRepo.GetByTaskID(ctx, ownerID, id)
+	if err != nil || len(entries) > 0 {
+		return entries, err
+	}
+
+	if _, err := s.GetTask(ctx, id); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// RestoreTaskVersion implements input.TaskService.RestoreTaskVersion.
+// The restore is an update of the editable fields with the update rules, recorded in the
+// history as a restore; the parent and the series of the task are left as they are.
+func (s *TaskService) RestoreTaskVersion(ctx context.Context, id, entryID, version int64) (*models.Task, error) {
+	existing, err := s.GetTask(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := s.historyRepo.GetByID(ctx, existing.OwnerID, entryID)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil || entry.TaskID != id {
+		return nil, ErrHistoryEntryNotFound
+	}
+
+	previous := entry.Previous
+	task := *existing
+	task.Title = previous.Title
+	task.Description = previous.Description
+	task.DueDate = previous.DueDate
+	task.Priority = previous.Priority
+	task.Status = previous.Status
+	task.EstimatedHours = previous.EstimatedHours
+	task.CourseID = previous.CourseID
+	task.Version = version
+
+	if err := s.updateTask(ctx, existing, &task, models.TaskChangeRestored); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// recordTaskChange adds a change of a task made by the current user to the history.
+// Updates that change none of the recorded fields are not recorded.
+func recordTaskChange(ctx context.Context, historyRepo output.TaskHistoryRepository, action models.TaskChangeAction, before, after *models.Task) error {
+	actorID, err := currentUserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	var changes []models.FieldChange
+	if action != models.TaskChangeDeleted {
+		changes = diffTask(before, after)
+		if len(changes) == 0 {
+			return nil
+		}
+	}
+
+	return historyRepo.Add(ctx, &models.TaskHistoryEntry{
+		TaskID:    before.ID,
+		OwnerID:   before.OwnerID,
+		ActorID:   actorID,
+		Action:    action,
+		Version:   before.Version,
+		Changes:   changes,
+		Previous:  *before,
+		CreatedAt: time.Now().UTC(),
+	})
+}
+
+// diffTask lists the editable fields, and the series, whose values differ between two states of a task.
+func diffTask(before, after *models.Task) []models.FieldChange {
+	var changes []models.FieldChange
+	add := func(field, old, new string) {
+		if old != new {
+			changes = append(changes, models.FieldChange{Field: field, Old: old, New: new})
+		}
+	}
+
+	add("title", before.Title, after.Title)
+	add("description", before.Description, after.Description)
+	add("due_date", formatHistoryTime(before.DueDate), formatHistoryTime(after.DueDate))
+	add("priority", strconv.Itoa(before.Priority), strconv.Itoa(after.Priority))
+	add("status", string(before.Status), string(after.Status))
+	add("estimated_hours", formatHistoryHours(before.EstimatedHours), formatHistoryHours(after.EstimatedHours))
+	add("course_id", formatHistoryID(before.CourseID), formatHistoryID(after.CourseID))
+	add("recurrence_id", formatHistoryID(before.RecurrenceID), formatHistoryID(after.RecurrenceID))
+	return changes
+}
+
+// formatHistoryTime formats a time of a field change, empty for no time.
+func formatHistoryTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// formatHistoryHours formats an estimate of a field change, empty for no estimate.
+func formatHistoryHours(hours float64) string {
+	if hours == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(hours, 'f', -1, 64)
+}
+
+// formatHistoryID formats a reference of a field change, empty for no reference.
+func formatHistoryID(id int64) string {
+	if id == 0 {
+		return ""
+	}
+	return strconv.FormatInt(id, 10)
+}
@@ -5,8 +5,13 @@ package services
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
+	"unicode"
 
 	"uni-task-manager/internal/domain/models"
 	"uni-task-manager/internal/ports/input"
@@ -21,61 +26,161 @@ var (
 	// ErrInvalidDueDate indicates that the task's due date is in the past
 	ErrInvalidDueDate = errors.New("due date must be in the future")
 
+	// ErrInvalidEstimate indicates that the estimated effort of a task is negative or unrealistically large
+	ErrInvalidEstimate = errors.New("estimated hours must be between 0 and 1000")
+
+	// ErrInvalidTaskStatus indicates that the task status is not one of the known states
+	ErrInvalidTaskStatus = errors.New("task status must be pending, in_progress or completed")
+
+	// ErrEmptyTitle indicates a patch clearing the title of a task
+	ErrEmptyTitle = errors.New("task title cannot be empty")
+
+	// ErrDueDateRequired indicates a patch clearing the due date of a task
+	ErrDueDateRequired = errors.New("task due date cannot be removed")
+
 	// ErrTaskNotFound indicates that the requested task does not exist
 	ErrTaskNotFound = errors.New("task not found")
+
+	// ErrNestedSubtask indicates an attempt to attach a subtask to a task that is itself a subtask
+	ErrNestedSubtask = errors.New("subtasks cannot have subtasks of their own")
+
+	// ErrVersionConflict indicates that a task or course was modified since the caller read it
+	ErrVersionConflict = errors.New("the record was changed by someone else in the meantime")
+
+	// ErrInvalidTaskQuery indicates that the filters, ordering or cursor of a task listing are invalid
+	ErrInvalidTaskQuery = errors.New("invalid task query")
+
+	// ErrInvalidSearch indicates a full-text search without any searchable term
+	ErrInvalidSearch = errors.New("search query must contain at least one word")
 )
 
+// Page size limits applied to task listings
+const (
+	// DefaultTaskPageSize is used when a listing doesn't specify a limit
+	DefaultTaskPageSize = 50
+
+	// MaxTaskPageSize caps the number of tasks returned in a single page
+	MaxTaskPageSize = 200
+
+	// DefaultSearchLimit is used when a search doesn't specify a limit
+	DefaultSearchLimit = 20
+
+	// MaxSearchLimit caps the number of results of a single search
+	MaxSearchLimit = 100
+)
+
+// MaxEstimatedHours caps the estimated effort of a single task
+const MaxEstimatedHours = 1000
+
 // Verify TaskService implements input.TaskService interface at compile time
 var _ input.TaskService = (*TaskService)(nil)
 
 // TaskService implements the task-related business logic and orchestrates
 // interactions between the domain model and storage layer.
 type TaskService struct {
-	taskRepo   output.TaskRepository
-	courseRepo output.CourseRepository
+	taskRepo       output.TaskRepository
+	courseRepo     output.CourseRepository
+	recurrenceRepo output.RecurrenceRepository
+	tagRepo        output.TagRepository
+	dependencyRepo output.DependencyRepository
+	historyRepo    output.TaskHistoryRepository
+	uow            output.UnitOfWork
+	events         output.EventPublisher
 }
 
 // NewTaskService creates a new instance of TaskService with the required dependencies.
-func NewTaskService(taskRepo output.TaskRepository, courseRepo output.CourseRepository) *TaskService {
+// Every change to a task is recorded in its history and published as an event through the
+// given publisher, in the same unit of work as the change.
+func NewTaskService(taskRepo output.TaskRepository, courseRepo output.CourseRepository, recurrenceRepo output.RecurrenceRepository, tagRepo output.TagRepository, dependencyRepo output.DependencyRepository, historyRepo output.TaskHistoryRepository, uow output.UnitOfWork, events output.EventPublisher) *TaskService {
 	return &TaskService{
-		taskRepo:   taskRepo,
-		courseRepo: courseRepo,
+		taskRepo:       taskRepo,
+		courseRepo:     courseRepo,
+		recurrenceRepo: recurrenceRepo,
+		tagRepo:        tagRepo,
+		dependencyRepo: dependencyRepo,
+		historyRepo:    historyRepo,
+		uow:            uow,
+		events:         events,
 	}
 }
 
 // CreateTask implements input.TaskService.CreateTask.
 // It validates the task data and ensures any referenced course exists before creation.
 func (s *TaskService) CreateTask(ctx context.Context, task *models.Task) error {
-	if err := s.validateTask(task); err != nil {
+	return s.createTask(ctx, task, nil)
+}
+
+// createTask validates and creates a task, along with the rule of the series it starts if one is given.
+func (s *TaskService) createTask(ctx context.Context, task *models.Task, rule *models.Recurrence) error {
+	ownerID, err := currentUserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	if task.Status == "" {
+		task.Status = models.TaskStatusPending
+	}
+
+	if err := s.validateTask(task, nil); err != nil {
 		return err
 	}
+	if rule != nil {
+		if err := validateRecurrence(rule); err != nil {
+			return err
+		}
+	}
 
 	if task.CourseID != 0 {
-		// Verify course exists if specified
-		_, err := s.courseRepo.GetByID(ctx, task.CourseID)
+		// Verify course exists if specified; it must belong to the same user
+		course, err := s.courseRepo.GetByID(ctx, ownerID, task.CourseID)
 		if err != nil {
 			return err
 		}
+		if course == nil {
+			return ErrCourseNotFound
+		}
+	}
+
+	if task.ParentID != 0 {
+		if err := s.validateParent(ctx, ownerID, task.ParentID); err != nil {
+			return err
+		}
 	}
 
+	task.OwnerID = ownerID
 	now := time.Now().UTC()
 	task.CreatedAt = now
 	task.UpdatedAt = now
-	if task.Status == "" {
-		task.Status = models.TaskStatusPending
-	}
 
-	return s.taskRepo.Create(ctx, task)
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		if rule != nil {
+			rule.OwnerID = ownerID
+			rule.Occurrences = 1
+			rule.CreatedAt = now
+			rule.UpdatedAt = now
+			if err := s.recurrenceRepo.Create(ctx, rule); err != nil {
+				return err
+			}
+			task.RecurrenceID = rule.ID
+		}
+
+		if err := s.taskRepo.Create(ctx, task); err != nil {
+			return err
+		}
+		return s.publishTask(ctx, models.EventTaskCreated, task, "")
+	})
 }
 
 // UpdateTask implements input.TaskService.UpdateTask.
 // It validates the updated task data and ensures the task exists before updating.
+// Completing an occurrence of a recurring series materializes the next occurrence.
 func (s *TaskService) UpdateTask(ctx context.Context, task *models.Task) error {
-	if err := s.validateTask(task); err != nil {
+	ownerID, err := currentUserID(ctx)
+	if err != nil {
 		return err
 	}
 
-	existing, err := s.taskRepo.GetByID(ctx, task.ID)
+	existing, err := s.taskRepo.GetByID(ctx, ownerID, task.ID)
 	if err != nil {
 		return err
 	}
@@ -83,24 +188,196 @@ func (s *TaskService) UpdateTask(ctx context.Context, task *models.Task) error {
 		return ErrTaskNotFound
 	}
 
+	return s.updateTask(ctx, existing, task, models.TaskChangeUpdated)
+}
+
+// CreateTaskWithTags implements input.TaskService.CreateTaskWithTags.
+// The tag names are validated before anything is stored; the task, the rule of its series and
+// its tags are then saved together.
+func (s *TaskService) CreateTaskWithTags(ctx context.Context, task *models.Task, rule *models.Recurrence, tags []string) error {
+	names, err := normalizeTagNames(tags)
+	if err != nil {
+		return err
+	}
+
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.createTask(ctx, task, rule); err != nil {
+			return err
+		}
+		return tagTask(ctx, s.tagRepo, task.OwnerID, task.ID, names)
+	})
+}
+
+// UpdateTaskWithTags implements input.TaskService.UpdateTaskWithTags.
+// The tag names are validated before anything is stored; the task and its tags are then
+// saved together.
+func (s *TaskService) UpdateTaskWithTags(ctx context.Context, task *models.Task, tags []string) error {
+	names, err := normalizeTagNames(tags)
+	if err != nil {
+		return err
+	}
+
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.UpdateTask(ctx, task); err != nil {
+			return err
+		}
+		return tagTask(ctx, s.tagRepo, task.OwnerID, task.ID, names)
+	})
+}
+
+// PatchTask implements input.TaskService.PatchTask.
+// It applies the patch onto a copy of the stored task and saves the result with the update rules.
+func (s *TaskService) PatchTask(ctx context.Context, id int64, patch models.TaskPatch) (*models.Task, error) {
+	if err := validateTaskPatch(patch); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.GetTask(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	task := *existing
+	task.Version = patch.Version
+	mergeTaskPatch(&task, patch)
+
+	if err := s.updateTask(ctx, existing, &task, models.TaskChangeUpdated); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// SetTaskStatus implements input.TaskService.SetTaskStatus.
+// It is a patch of the status alone, so the other fields are kept as stored.
+func (s *TaskService) SetTaskStatus(ctx context.Context, id int64, status models.TaskStatus) (*models.Task, error) {
+	if status == "" {
+		return nil, ErrInvalidTaskStatus
+	}
+	return s.PatchTask(ctx, id, models.TaskPatch{Status: &status})
+}
+
+// updateTask validates and saves the new state of an existing task, recording the change in its history as the given action.
+// A non-zero task.Version must match the stored version, otherwise ErrVersionConflict is returned.
+// A task can only be completed once its prerequisites are, otherwise ErrTaskBlocked is returned.
+// Completing an occurrence of a recurring series materializes the next occurrence.
+func (s *TaskService) updateTask(ctx context.Context, existing, task *models.Task, action models.TaskChangeAction) error {
+	if task.Version != 0 && task.Version != existing.Version {
+		return ErrVersionConflict
+	}
+
+	if task.Status == "" {
+		task.Status = models.TaskStatusPending
+	}
+
+	if err := s.validateTask(task, existing); err != nil {
+		return err
+	}
+
+	if existing.Status != models.TaskStatusCompleted && task.Status == models.TaskStatusCompleted {
+		if err := s.checkPrerequisites(ctx, existing); err != nil {
+			return err
+		}
+	}
+
 	if task.CourseID != 0 {
-		// Verify course exists if specified
-		_, err := s.courseRepo.GetByID(ctx, task.CourseID)
+		// Verify course exists if specified; it must belong to the same user
+		course, err := s.courseRepo.GetByID(ctx, existing.OwnerID, task.CourseID)
 		if err != nil {
 			return err
 		}
+		if course == nil {
+			return ErrCourseNotFound
+		}
 	}
 
+	// Neither the parent, the series nor the import origin of a task can be changed through an update
+	task.ParentID = existing.ParentID
+	task.RecurrenceID = existing.RecurrenceID
+	task.ExternalUID = existing.ExternalUID
+	task.OwnerID = existing.OwnerID
+	task.Version = existing.Version
 	task.CreatedAt = existing.CreatedAt
 	task.UpdatedAt = time.Now().UTC()
 
-	return s.taskRepo.Update(ctx, task)
+	// The update, its history, its events and the next occurrence of a completed recurring task are saved together
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		if err := s.taskRepo.Update(ctx, task); err != nil {
+			return translateStorageError(err)
+		}
+
+		if err := recordTaskChange(ctx, s.historyRepo, action, existing, task); err != nil {
+			return err
+		}
+
+		if err := s.publishTask(ctx, models.EventTaskUpdated, task, ""); err != nil {
+			return err
+		}
+		if task.Status != existing.Status {
+			if err := s.publishTask(ctx, models.EventTaskStatusChanged, task, existing.Status); err != nil {
+				return err
+			}
+		}
+
+		if task.RecurrenceID != 0 && existing.Status != models.TaskStatusCompleted && task.Status == models.TaskStatusCompleted {
+			return s.materializeNextOccurrence(ctx, task)
+		}
+		return nil
+	})
+}
+
+// validateTaskPatch rejects patches clearing a required field: only the description, the
+// estimate and the course of a task can be removed.
+func validateTaskPatch(patch models.TaskPatch) error {
+	var errs []error
+	if patch.Title != nil && strings.TrimSpace(*patch.Title) == "" {
+		errs = append(errs, ErrEmptyTitle)
+	}
+	if patch.DueDate != nil && patch.DueDate.IsZero() {
+		errs = append(errs, ErrDueDateRequired)
+	}
+	if patch.Status != nil && *patch.Status == "" {
+		errs = append(errs, ErrInvalidTaskStatus)
+	}
+	if patch.Priority != nil && (*patch.Priority < 1 || *patch.Priority > 5) {
+		errs = append(errs, ErrInvalidTaskPriority)
+	}
+	return errors.Join(errs...)
+}
+
+// mergeTaskPatch applies the non-nil fields of the patch onto the task.
+func mergeTaskPatch(task *models.Task, patch models.TaskPatch) {
+	if patch.Title != nil {
+		task.Title = *patch.Title
+	}
+	if patch.Description != nil {
+		task.Description = *patch.Description
+	}
+	if patch.DueDate != nil {
+		task.DueDate = *patch.DueDate
+	}
+	if patch.Priority != nil {
+		task.Priority = *patch.Priority
+	}
+	if patch.Status != nil {
+		task.Status = *patch.Status
+	}
+	if patch.EstimatedHours != nil {
+		task.EstimatedHours = *patch.EstimatedHours
+	}
+	if patch.CourseID != nil {
+		task.CourseID = *patch.CourseID
+	}
 }
 
 // GetTask implements input.TaskService.GetTask.
 // It retrieves a specific task by its ID.
 func (s *TaskService) GetTask(ctx context.Context, id int64) (*models.Task, error) {
-	task, err := s.taskRepo.GetByID(ctx, id)
+	ownerID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := s.taskRepo.GetByID(ctx, ownerID, id)
 	if err != nil {
 		return nil, err
 	}
@@ -111,34 +388,343 @@ func (s *TaskService) GetTask(ctx context.Context, id int64) (*models.Task, erro
 }
 
 // GetAllTasks implements input.TaskService.GetAllTasks.
-// It retrieves all tasks from the repository.
+// It retrieves all tasks of the current user from the repository.
 func (s *TaskService) GetAllTasks(ctx context.Context) ([]models.Task, error) {
-	return s.taskRepo.GetAll(ctx)
+	ownerID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.taskRepo.GetAll(ctx, ownerID)
+}
+
+// ListTasks implements input.TaskService.ListTasks.
+// It validates the query, decodes its cursor and fetches one extra task to detect further pages.
+func (s *TaskService) ListTasks(ctx context.Context, query models.TaskQuery) (*models.TaskPage, error) {
+	ownerID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.validateTaskQuery(&query); err != nil {
+		return nil, err
+	}
+
+	limit := query.Limit
+	query.Limit = limit + 1
+	tasks, err := s.taskRepo.List(ctx, ownerID, query)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &models.TaskPage{Tasks: tasks}
+	if len(tasks) > limit {
+		page.Tasks = tasks[:limit]
+		page.NextCursor = encodeTaskCursor(query.Sort, page.Tasks[limit-1])
+	}
+	return page, nil
+}
+
+// SearchTasks implements input.TaskService.SearchTasks.
+// It rejects queries without any letter or digit and bounds the number of results.
+func (s *TaskService) SearchTasks(ctx context.Context, query string, limit int) ([]models.TaskSearchResult, error) {
+	ownerID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.IndexFunc(query, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) < 0 {
+		return nil, ErrInvalidSearch
+	}
+
+	switch {
+	case limit <= 0:
+		limit = DefaultSearchLimit
+	case limit > MaxSearchLimit:
+		limit = MaxSearchLimit
+	}
+
+	return s.taskRepo.Search(ctx, ownerID, query, limit)
+}
+
+// GetTasksByCourse implements input.TaskService.GetTasksByCourse.
+// It ensures the course exists before retrieving its tasks.
+func (s *TaskService) GetTasksByCourse(ctx context.Context, courseID int64) ([]models.Task, error) {
+	ownerID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	course, err := s.courseRepo.GetByID(ctx, ownerID, courseID)
+	if err != nil {
+		return nil, err
+	}
+	if course == nil {
+		return nil, ErrCourseNotFound
+	}
+	return s.taskRepo.GetByCourseID(ctx, ownerID, courseID)
+}
+
+// CreateSubtask implements input.TaskService.CreateSubtask.
+// It attaches the task to the given parent and applies the regular creation rules.
+func (s *TaskService) CreateSubtask(ctx context.Context, parentID int64, task *models.Task) error {
+	task.ParentID = parentID
+	return s.CreateTask(ctx, task)
+}
+
+// GetSubtasks implements input.TaskService.GetSubtasks.
+// It ensures the parent task exists before retrieving its subtasks.
+func (s *TaskService) GetSubtasks(ctx context.Context, parentID int64) ([]models.Task, error) {
+	parent, err := s.GetTask(ctx, parentID)
+	if err != nil {
+		return nil, err
+	}
+	return s.taskRepo.GetSubtasks(ctx, parent.OwnerID, parentID)
+}
+
+// GetTaskProgress implements input.TaskService.GetTaskProgress.
+// It computes the completion percentage of a task from its subtasks.
+func (s *TaskService) GetTaskProgress(ctx context.Context, id int64) (*models.TaskProgress, error) {
+	task, err := s.GetTask(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	subtasks, err := s.taskRepo.GetSubtasks(ctx, task.OwnerID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	progress := models.TaskProgress{Total: len(subtasks)}
+	for _, subtask := range subtasks {
+		if subtask.Status == models.TaskStatusCompleted {
+			progress.Completed++
+		}
+	}
+	progress.Percent = completionPercent(task.Status, progress)
+
+	return &progress, nil
+}
+
+// GetAllTaskProgress implements input.TaskService.GetAllTaskProgress.
+// Only tasks that have subtasks are included in the result.
+func (s *TaskService) GetAllTaskProgress(ctx context.Context) (map[int64]models.TaskProgress, error) {
+	ownerID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := s.taskRepo.CountSubtasks(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	for parentID, progress := range counts {
+		progress.Percent = completionPercent(models.TaskStatusPending, progress)
+		counts[parentID] = progress
+	}
+	return counts, nil
 }
 
 // DeleteTask implements input.TaskService.DeleteTask.
-// It ensures the task exists before deletion.
-func (s *TaskService) DeleteTask(ctx context.Context, id int64) error {
-	existing, err := s.taskRepo.GetByID(ctx, id)
+// It ensures the task exists, and has the expected version if one is given, before deletion.
+// The task is read and deleted in one unit of work, and only deleted if it still has the version
+// read, so a concurrent update either happens before the check or makes the deletion fail.
+func (s *TaskService) DeleteTask(ctx context.Context, id, version int64) error {
+	// The subtasks are deleted with the task, so their deletions are recorded and published as well
+	return s.uow.Do(ctx, func(ctx context.Context) error {
+		existing, err := s.GetTask(ctx, id)
+		if err != nil {
+			return err
+		}
+		if version != 0 && version != existing.Version {
+			return ErrVersionConflict
+		}
+
+		subtasks, err := s.taskRepo.GetSubtasks(ctx, existing.OwnerID, id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.taskRepo.Delete(ctx, existing.OwnerID, id, existing.Version); err != nil {
+			return translateStorageError(err)
+		}
+
+		for _, task := range append(subtasks, *existing) {
+			if err := recordTaskChange(ctx, s.historyRepo, models.TaskChangeDeleted, &task, nil); err != nil {
+				return err
+			}
+			if err := s.publishTask(ctx, models.EventTaskDeleted, &task, ""); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// publishTask publishes an event about a task, carrying a snapshot of the task and, for
+// status changes, its previous status.
+func (s *TaskService) publishTask(ctx context.Context, eventType models.EventType, task *models.Task, previous models.TaskStatus) error {
+	event, err := newEvent(eventType, task.OwnerID)
 	if err != nil {
 		return err
 	}
-	if existing == nil {
+	snapshot := *task
+	event.Task = &snapshot
+	event.PreviousStatus = previous
+	return s.events.Publish(ctx, event)
+}
+
+// validateParent ensures the parent task exists and is not itself a subtask,
+// which keeps the task hierarchy one level deep.
+func (s *TaskService) validateParent(ctx context.Context, ownerID, parentID int64) error {
+	parent, err := s.taskRepo.GetByID(ctx, ownerID, parentID)
+	if err != nil {
+		return err
+	}
+	if parent == nil {
 		return ErrTaskNotFound
 	}
-	return s.taskRepo.Delete(ctx, id)
+	if parent.ParentID != 0 {
+		return ErrNestedSubtask
+	}
+	return nil
+}
+
+// translateStorageError maps the errors of the storage ports onto the domain errors of the services.
+func translateStorageError(err error) error {
+	if errors.Is(err, output.ErrVersionConflict) {
+		return ErrVersionConflict
+	}
+	return err
+}
+
+// completionPercent computes the completion percentage from subtask counts.
+// A task without subtasks is either fully done or not started, depending on its own status.
+func completionPercent(status models.TaskStatus, progress models.TaskProgress) int {
+	if progress.Total == 0 {
+		if status == models.TaskStatusCompleted {
+			return 100
+		}
+		return 0
+	}
+	return progress.Completed * 100 / progress.Total
+}
+
+// validateTaskQuery checks the listing criteria and fills in defaults for the sort field and limit.
+// It also decodes the opaque cursor into query.After.
+func (s *TaskService) validateTaskQuery(query *models.TaskQuery) error {
+	switch query.Status {
+	case "", models.TaskStatusPending, models.TaskStatusInProgress, models.TaskStatusCompleted:
+	default:
+		return fmt.Errorf("%w: unknown status %q", ErrInvalidTaskQuery, query.Status)
+	}
+
+	query.Tag = normalizeTagName(query.Tag)
+
+	if query.PriorityMin < 0 || query.PriorityMin > 5 {
+		return fmt.Errorf("%w: minimum priority must be between 1 and 5", ErrInvalidTaskQuery)
+	}
+
+	switch query.Sort {
+	case "":
+		query.Sort = models.TaskSortDueDate
+	case models.TaskSortDueDate, models.TaskSortPriority, models.TaskSortCreatedAt:
+	default:
+		return fmt.Errorf("%w: unknown sort field %q", ErrInvalidTaskQuery, query.Sort)
+	}
+
+	switch {
+	case query.Limit < 0:
+		return fmt.Errorf("%w: limit cannot be negative", ErrInvalidTaskQuery)
+	case query.Limit == 0:
+		query.Limit = DefaultTaskPageSize
+	case query.Limit > MaxTaskPageSize:
+		query.Limit = MaxTaskPageSize
+	}
+
+	if query.Cursor != "" {
+		after, err := decodeTaskCursor(query.Sort, query.Cursor)
+		if err != nil {
+			return err
+		}
+		query.After = after
+	}
+
+	return nil
+}
+
+// encodeTaskCursor builds the opaque cursor pointing right after the given task.
+// The sort field is embedded so a cursor cannot be replayed against a different ordering.
+func encodeTaskCursor(sort models.TaskSortField, task models.Task) string {
+	var value string
+	switch sort {
+	case models.TaskSortPriority:
+		value = strconv.Itoa(task.Priority)
+	case models.TaskSortCreatedAt:
+		value = task.CreatedAt.UTC().Format(time.RFC3339)
+	default:
+		value = task.DueDate.UTC().Format(time.RFC3339)
+	}
+
+	raw := fmt.Sprintf("%s|%s|%d", sort, value, task.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeTaskCursor parses a cursor produced by encodeTaskCursor for the given sort field.
+func decodeTaskCursor(sort models.TaskSortField, cursor string) (*models.TaskCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidTaskQuery)
+	}
+
+	parts := strings.Split(string(raw), "|")
+	if len(parts) != 3 || models.TaskSortField(parts[0]) != sort {
+		return nil, fmt.Errorf("%w: cursor does not match the requested ordering", ErrInvalidTaskQuery)
+	}
+
+	id, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidTaskQuery)
+	}
+
+	after := &models.TaskCursor{Value: parts[1], ID: id}
+	if sort == models.TaskSortPriority {
+		priority, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("%w: malformed cursor", ErrInvalidTaskQuery)
+		}
+		after.Value = priority
+	}
+
+	return after, nil
 }
 
 // validateTask performs validation of task data according to business rules.
-// It checks priority range and ensures the due date is in the future.
-func (s *TaskService) validateTask(task *models.Task) error {
+// It checks priority range and status, and ensures the due date is in the future.
+// On updates the stored task is passed as existing, and the due date is only checked when
+// it changes, so an overdue task can still be edited or completed.
+// Every violated rule is reported, joined into a single error.
+func (s *TaskService) validateTask(task, existing *models.Task) error {
+	var errs []error
+
 	if task.Priority < 1 || task.Priority > 5 {
-		return ErrInvalidTaskPriority
+		errs = append(errs, ErrInvalidTaskPriority)
 	}
 
-	if !task.DueDate.IsZero() && task.DueDate.Before(time.Now()) {
-		return ErrInvalidDueDate
+	switch task.Status {
+	case models.TaskStatusPending, models.TaskStatusInProgress, models.TaskStatusCompleted:
+	default:
+		errs = append(errs, ErrInvalidTaskStatus)
 	}
 
-	return nil
+	if task.EstimatedHours < 0 || task.EstimatedHours > MaxEstimatedHours {
+		errs = append(errs, ErrInvalidEstimate)
+	}
+
+	dueDateChanged := existing == nil || !task.DueDate.Equal(existing.DueDate)
+	if dueDateChanged && !task.DueDate.IsZero() && task.DueDate.Before(time.Now()) {
+		errs = append(errs, ErrInvalidDueDate)
+	}
+
+	return errors.Join(errs...)
 }
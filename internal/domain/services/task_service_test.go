@@ -0,0 +1,171 @@
+package services
+
+import (
+	"encoding/base64"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"uni-task-manager/internal/domain/models"
+)
+
+func TestTaskCursorRoundTrip(t *testing.T) {
+	task := models.Task{
+		ID:        42,
+		Priority:  4,
+		DueDate:   time.Date(2030, 1, 1, 11, 0, 0, 0, time.FixedZone("CET", 3600)),
+		CreatedAt: time.Date(2029, 12, 1, 8, 30, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		sort models.TaskSortField
+		want interface{}
+	}{
+		{models.TaskSortDueDate, "2030-01-01T10:00:00Z"},
+		{models.TaskSortPriority, 4},
+		{models.TaskSortCreatedAt, "2029-12-01T08:30:00Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.sort), func(t *testing.T) {
+			cursor, err := decodeTaskCursor(tt.sort, encodeTaskCursor(tt.sort, task))
+			if err != nil {
+				t.Fatalf("decodeTaskCursor() error = %v", err)
+			}
+			if cursor.Value != tt.want || cursor.ID != task.ID {
+				t.Errorf("decodeTaskCursor() = {%v %d}, want {%v %d}", cursor.Value, cursor.ID, tt.want, task.ID)
+			}
+		})
+	}
+}
+
+func TestDecodeTaskCursorRejectsInvalidCursors(t *testing.T) {
+	encode := func(raw string) string { return base64.RawURLEncoding.EncodeToString([]byte(raw)) }
+
+	tests := []struct {
+		name   string
+		sort   models.TaskSortField
+		cursor string
+	}{
+		{"not base64", models.TaskSortDueDate, "not a cursor!"},
+		{"other ordering", models.TaskSortDueDate, encode("priority|3|42")},
+		{"missing id", models.TaskSortDueDate, encode("due_date|2030-01-01T10:00:00Z")},
+		{"extra part", models.TaskSortDueDate, encode("due_date|2030-01-01T10:00:00Z|42|1")},
+		{"invalid id", models.TaskSortDueDate, encode("due_date|2030-01-01T10:00:00Z|x")},
+		{"invalid priority", models.TaskSortPriority, encode("priority|high|42")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := decodeTaskCursor(tt.sort, tt.cursor); !errors.Is(err, ErrInvalidTaskQuery) {
+				t.Errorf("decodeTaskCursor() error = %v, want %v", err, ErrInvalidTaskQuery)
+			}
+		})
+	}
+}
+
+func TestMergeTaskPatch(t *testing.T) {
+	stored := models.Task{
+		ID:             7,
+		Title:          "Essay",
+		Description:    "Draft",
+		DueDate:        time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC),
+		Priority:       3,
+		Status:         models.TaskStatusPending,
+		EstimatedHours: 4,
+		CourseID:       2,
+		Version:        5,
+	}
+	title := "Final essay"
+	empty := ""
+	dueDate := time.Date(2030, 2, 1, 9, 0, 0, 0, time.UTC)
+	priority := 5
+	status := models.TaskStatusInProgress
+	noHours := 0.0
+	noCourse := int64(0)
+
+	tests := []struct {
+		name  string
+		patch models.TaskPatch
+		want  func(task *models.Task)
+	}{
+		{
+			name:  "empty patch keeps everything",
+			patch: models.TaskPatch{},
+			want:  func(task *models.Task) {},
+		},
+		{
+			name:  "replaces the given fields",
+			patch: models.TaskPatch{Title: &title, DueDate: &dueDate, Priority: &priority, Status: &status},
+			want: func(task *models.Task) {
+				task.Title, task.DueDate, task.Priority, task.Status = title, dueDate, priority, status
+			},
+		},
+		{
+			name:  "clears the optional fields",
+			patch: models.TaskPatch{Description: &empty, EstimatedHours: &noHours, CourseID: &noCourse},
+			want: func(task *models.Task) {
+				task.Description, task.EstimatedHours, task.CourseID = "", 0, 0
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := stored
+			mergeTaskPatch(&got, tt.patch)
+
+			want := stored
+			tt.want(&want)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("mergeTaskPatch() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestValidateTaskPatch(t *testing.T) {
+	blank := "  "
+	title := "Essay"
+	empty := ""
+	var noDueDate time.Time
+	noStatus := models.TaskStatus("")
+	noPriority := 0
+	priority := 2
+	noCourse := int64(0)
+
+	tests := []struct {
+		name  string
+		patch models.TaskPatch
+		want  []error
+	}{
+		{"empty patch", models.TaskPatch{}, nil},
+		{"valid fields", models.TaskPatch{Title: &title, Priority: &priority}, nil},
+		{"cleared optional fields", models.TaskPatch{Description: &empty, CourseID: &noCourse}, nil},
+		{"cleared title", models.TaskPatch{Title: &empty}, []error{ErrEmptyTitle}},
+		{"blank title", models.TaskPatch{Title: &blank}, []error{ErrEmptyTitle}},
+		{"cleared due date", models.TaskPatch{DueDate: &noDueDate}, []error{ErrDueDateRequired}},
+		{"cleared status", models.TaskPatch{Status: &noStatus}, []error{ErrInvalidTaskStatus}},
+		{"cleared priority", models.TaskPatch{Priority: &noPriority}, []error{ErrInvalidTaskPriority}},
+		{
+			"several cleared fields",
+			models.TaskPatch{Title: &empty, DueDate: &noDueDate},
+			[]error{ErrEmptyTitle, ErrDueDateRequired},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateTaskPatch(tt.patch)
+			if len(tt.want) == 0 && err != nil {
+				t.Fatalf("validateTaskPatch() = %v, want nil", err)
+			}
+			for _, want := range tt.want {
+				if !errors.Is(err, want) {
+					t.Errorf("validateTaskPatch() = %v, want %v", err, want)
+				}
+			}
+		})
+	}
+}
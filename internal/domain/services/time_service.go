@@ -0,0 +1,233 @@
+// Package services implements the core business logic for time tracking
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+	"unicode/utf8"
+
+	"uni-task-manager/internal/domain/models"
+	"uni-task-manager/internal/ports/input"
+	"uni-task-manager/internal/ports/output"
+)
+
+// Domain-specific errors that can be returned by the TimeService
+var (
+	// ErrTimeEntryNotFound indicates that the requested time entry does not exist
+	ErrTimeEntryNotFound = errors.New("time entry not found")
+
+	// ErrInvalidTimeEntry indicates a time entry that doesn't end after it starts, ends in the future
+	// or has an overly long note
+	ErrInvalidTimeEntry = errors.New("time entry must end after it starts, not in the future, with a note of at most 500 characters")
+
+	// ErrTimerRunning indicates an attempt to start the timer of a task that is already being timed
+	ErrTimerRunning = errors.New("a timer is already running for this task")
+
+	// ErrTimerNotRunning indicates an attempt to stop the timer of a task that isn't being timed
+	ErrTimerNotRunning = errors.New("no timer is running for this task")
+)
+
+// MaxTimeEntryNoteLength caps the number of characters of a time entry note
+const MaxTimeEntryNoteLength = 500
+
+// Verify TimeService implements input.TimeService interface at compile time
+var _ input.TimeService = (*TimeService)(nil)
+
+// TimeService implements time tracking: timers and manual entries logged on tasks,
+// and the totals of time spent per task and per course.
+type TimeService struct {
+	timeRepo output.TimeEntryRepository
+	taskRepo output.TaskRepository
+}
+
+// NewTimeService creates a new instance of TimeService with the required dependencies.
+func NewTimeService(timeRepo output.TimeEntryRepository, taskRepo output.TaskRepository) *TimeService {
+	return &TimeService{
+		timeRepo: timeRepo,
+		taskRepo: taskRepo,
+	}
+}
+
+// StartTimer implements input.TimeService.StartTimer.
+// A user times one task at a time, so the timer running for another task is stopped first.
+func (s *TimeService) StartTimer(ctx context.Context, taskID int64, note string) (*models.TimeEntry, error) {
+	task, err := s.getTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if utf8.RuneCountInString(note) > MaxTimeEntryNoteLength {
+		return nil, ErrInvalidTimeEntry
+	}
+
+	now := time.Now().UTC()
+	running, err := s.timeRepo.GetRunning(ctx, task.OwnerID)
+	if err != nil {
+		return nil, err
+	}
+	if running != nil {
+		if running.TaskID == taskID {
+			return nil, ErrTimerRunning
+		}
+		running.EndedAt = now
+		if err := s.timeRepo.Update(ctx, running); err != nil {
+			return nil, err
+		}
+	}
+
+	entry := &models.TimeEntry{
+		OwnerID:   task.OwnerID,
+		TaskID:    taskID,
+		StartedAt: now,
+		Note:      note,
+		CreatedAt: now,
+	}
+	if err := s.timeRepo.Create(ctx, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// StopTimer implements input.TimeService.StopTimer.
+// A non-empty note replaces the note given when the timer was started.
+func (s *TimeService) StopTimer(ctx context.Context, taskID int64, note string) (*models.TimeEntry, error) {
+	task, err := s.getTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if utf8.RuneCountInString(note) > MaxTimeEntryNoteLength {
+		return nil, ErrInvalidTimeEntry
+	}
+
+	running, err := s.timeRepo.GetRunning(ctx, task.OwnerID)
+	if err != nil {
+		return nil, err
+	}
+	if running == nil || running.TaskID != taskID {
+		return nil, ErrTimerNotRunning
+	}
+
+	running.EndedAt = time.Now().UTC()
+	if note != "" {
+		running.Note = note
+	}
+	if err := s.timeRepo.Update(ctx, running); err != nil {
+		return nil, err
+	}
+
+	running.Duration = running.EndedAt.Sub(running.StartedAt)
+	return running, nil
+}
+
+// LogTime implements input.TimeService.LogTime.
+// Manual entries must be finished sessions: they end after they start and not in the future.
+func (s *TimeService) LogTime(ctx context.Context, entry *models.TimeEntry) error {
+	task, err := s.getTask(ctx, entry.TaskID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	if entry.StartedAt.IsZero() || !entry.EndedAt.After(entry.StartedAt) || entry.EndedAt.After(now) ||
+		utf8.RuneCountInString(entry.Note) > MaxTimeEntryNoteLength {
+		return ErrInvalidTimeEntry
+	}
+
+	entry.OwnerID = task.OwnerID
+	entry.StartedAt = entry.StartedAt.UTC()
+	entry.EndedAt = entry.EndedAt.UTC()
+	entry.Duration = entry.EndedAt.Sub(entry.StartedAt)
+	entry.CreatedAt = now
+
+	return s.timeRepo.Create(ctx, entry)
+}
+
+// GetTimeEntries implements input.TimeService.GetTimeEntries.
+// It ensures the task exists before retrieving its entries; running timers last until now.
+func (s *TimeService) GetTimeEntries(ctx context.Context, taskID int64) ([]models.TimeEntry, error) {
+	task, err := s.getTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.timeRepo.GetByTaskID(ctx, task.OwnerID, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	for i := range entries {
+		entries[i].Duration = entryDuration(&entries[i], now)
+	}
+	return entries, nil
+}
+
+// DeleteTimeEntry implements input.TimeService.DeleteTimeEntry.
+// It ensures the entry exists and was logged on the given task before deleting it.
+func (s *TimeService) DeleteTimeEntry(ctx context.Context, taskID, id int64) error {
+	task, err := s.getTask(ctx, taskID)
+	if err != nil {
+		return err
+	}
+
+	entry, err := s.timeRepo.GetByID(ctx, task.OwnerID, id)
+	if err != nil {
+		return err
+	}
+	if entry == nil || entry.TaskID != taskID {
+		return ErrTimeEntryNotFound
+	}
+
+	return s.timeRepo.Delete(ctx, task.OwnerID, id)
+}
+
+// GetTimeTotals implements input.TimeService.GetTimeTotals.
+// Running timers count until now.
+func (s *TimeService) GetTimeTotals(ctx context.Context) (*models.TimeTotals, error) {
+	ownerID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	byTask, err := s.timeRepo.SumByTask(ctx, ownerID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	byCourse, err := s.timeRepo.SumByCourse(ctx, ownerID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	totals := &models.TimeTotals{ByTask: byTask, ByCourse: byCourse}
+	for _, spent := range byTask {
+		totals.Total += spent
+	}
+	return totals, nil
+}
+
+// getTask retrieves a task of the current user, returning ErrTaskNotFound if it doesn't exist.
+func (s *TimeService) getTask(ctx context.Context, id int64) (*models.Task, error) {
+	ownerID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := s.taskRepo.GetByID(ctx, ownerID, id)
+	if err != nil {
+		return nil, err
+	}
+	if task == nil {
+		return nil, ErrTaskNotFound
+	}
+	return task, nil
+}
+
+// entryDuration computes the length of a time entry, counting a running timer until now.
+func entryDuration(entry *models.TimeEntry, now time.Time) time.Duration {
+	if entry.Running() {
+		return now.Sub(entry.StartedAt)
+	}
+	return entry.EndedAt.Sub(entry.StartedAt)
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"uni-task-manager/internal/domain/models"
+	"uni-task-manager/internal/ports/input"
+	"uni-task-manager/internal/ports/output"
+)
+
+// Domain-specific errors that can be returned by the TokenService
+var (
+	// ErrTokenNotFound indicates that the requested API token does not exist
+	ErrTokenNotFound = errors.New("API token not found")
+
+	// ErrInvalidToken indicates that an API token is unknown or expired
+	ErrInvalidToken = errors.New("invalid or expired API token")
+
+	// ErrInvalidTokenRequest indicates that the name, scopes or expiry of a new token are invalid
+	ErrInvalidTokenRequest = errors.New("invalid API token request")
+)
+
+// API token parameters
+const (
+	// apiTokenPrefix starts every token secret, so leaked tokens are easy to recognize
+	apiTokenPrefix = "utm_"
+
+	// apiTokenBytes is the amount of randomness in a token secret
+	apiTokenBytes = 32
+
+	// lastUsedResolution limits how often the last-used timestamp of a token is written
+	lastUsedResolution = time.Minute
+)
+
+// Verify TokenService implements input.TokenService interface at compile time
+var _ input.TokenService = (*TokenService)(nil)
+
+// TokenService implements the management and verification of personal API tokens.
+type TokenService struct {
+	tokenRepo output.APITokenRepository
+}
+
+// NewTokenService creates a new instance of TokenService with the required dependencies.
+func NewTokenService(tokenRepo output.APITokenRepository) *TokenService {
+	return &TokenService{
+		tokenRepo: tokenRepo,
+	}
+}
+
+// CreateToken implements input.TokenService.CreateToken.
+// Only the hash of the generated secret is stored; the secret is returned once in the Token field.
+func (s *TokenService) CreateToken(ctx context.Context, name string, scopes []models.TokenScope, expiresAt time.Time) (*models.APIToken, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name cannot be empty", ErrInvalidTokenRequest)
+	}
+	if len(scopes) == 0 {
+		return nil, fmt.Errorf("%w: at least one scope is required", ErrInvalidTokenRequest)
+	}
+
+	seen := make(map[models.TokenScope]bool)
+	unique := make([]models.TokenScope, 0, len(scopes))
+	for _, scope := range scopes {
+		switch scope {
+		case models.ScopeTasksRead, models.ScopeTasksWrite:
+		default:
+			return nil, fmt.Errorf("%w: unknown scope %q", ErrInvalidTokenRequest, scope)
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			unique = append(unique, scope)
+		}
+	}
+
+	now := time.Now().UTC()
+	if !expiresAt.IsZero() && !expiresAt.After(now) {
+		return nil, fmt.Errorf("%w: expiry must be in the future", ErrInvalidTokenRequest)
+	}
+
+	secret, err := generateToken(apiTokenBytes)
+	if err != nil {
+		return nil, err
+	}
+	secret = apiTokenPrefix + secret
+
+	token := &models.APIToken{
+		UserID:    userID,
+		Name:      name,
+		Token:     secret,
+		TokenHash: hashToken(secret),
+		Scopes:    unique,
+		ExpiresAt: expiresAt,
+		CreatedAt: now,
+	}
+	if err := s.tokenRepo.Create(ctx, token); err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// ListTokens implements input.TokenService.ListTokens.
+func (s *TokenService) ListTokens(ctx context.Context) ([]models.APIToken, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.tokenRepo.GetByUserID(ctx, userID)
+}
+
+// RevokeToken implements input.TokenService.RevokeToken.
+// It ensures the token belongs to the current user before deletion.
+func (s *TokenService) RevokeToken(ctx context.Context, id int64) error {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	existing, err := s.tokenRepo.GetByID(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return ErrTokenNotFound
+	}
+	return s.tokenRepo.Delete(ctx, userID, id)
+}
+
+// AuthenticateToken implements input.TokenService.AuthenticateToken.
+// The last-used timestamp is refreshed at most once per minute to keep reads cheap.
+func (s *TokenService) AuthenticateToken(ctx context.Context, secret string) (*models.APIToken, error) {
+	if !strings.HasPrefix(secret, apiTokenPrefix) {
+		return nil, ErrInvalidToken
+	}
+
+	token, err := s.tokenRepo.GetByTokenHash(ctx, hashToken(secret))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	if token == nil || token.Expired(now) {
+		return nil, ErrInvalidToken
+	}
+
+	if now.Sub(token.LastUsedAt) >= lastUsedResolution {
+		if err := s.tokenRepo.UpdateLastUsed(ctx, token.ID, now); err != nil {
+			return nil, err
+		}
+		token.LastUsedAt = now
+	}
+
+	return token, nil
+}
@@ -0,0 +1,241 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"uni-task-manager/internal/domain/models"
+	"uni-task-manager/internal/ports/input"
+	"uni-task-manager/internal/ports/output"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Domain-specific errors that can be returned by the UserService
+var (
+	// ErrUnauthenticated indicates that an operation requires a logged-in user
+	ErrUnauthenticated = errors.New("authentication required")
+
+	// ErrInvalidCredentials indicates a wrong email/password combination or an invalid session
+	ErrInvalidCredentials = errors.New("invalid email or password")
+
+	// ErrInvalidEmail indicates that the email address is malformed
+	ErrInvalidEmail = errors.New("invalid email address")
+
+	// ErrEmailTaken indicates that another account already uses the email address
+	ErrEmailTaken = errors.New("email address is already registered")
+
+	// ErrWeakPassword indicates that the password is too short
+	ErrWeakPassword = errors.New("password must be at least 8 characters long")
+)
+
+// Account security parameters
+const (
+	// MinPasswordLength is the minimum number of characters of a password
+	MinPasswordLength = 8
+
+	// SessionDuration is how long a browser session stays valid after login
+	SessionDuration = 30 * 24 * time.Hour
+
+	// sessionTokenBytes is the amount of randomness in a session token
+	sessionTokenBytes = 32
+)
+
+// contextKey is the type of the keys this package stores in request contexts
+type contextKey int
+
+// userIDKey is the context key of the authenticated user's ID
+const userIDKey contextKey = iota
+
+// ContextWithUserID returns a copy of ctx carrying the ID of the authenticated user.
+// Every task and course operation is scoped to this user.
+func ContextWithUserID(ctx context.Context, userID int64) context.Context {
+	return context.WithValue(ctx, userIDKey, userID)
+}
+
+// UserIDFromContext returns the ID of the authenticated user carried by ctx, if any.
+func UserIDFromContext(ctx context.Context) (int64, bool) {
+	userID, ok := ctx.Value(userIDKey).(int64)
+	return userID, ok && userID != 0
+}
+
+// currentUserID returns the ID of the authenticated user or ErrUnauthenticated.
+func currentUserID(ctx context.Context) (int64, error) {
+	userID, ok := UserIDFromContext(ctx)
+	if !ok {
+		return 0, ErrUnauthenticated
+	}
+	return userID, nil
+}
+
+// Verify UserService implements input.UserService interface at compile time
+var _ input.UserService = (*UserService)(nil)
+
+// UserService implements account registration, password authentication and browser sessions.
+type UserService struct {
+	userRepo    output.UserRepository
+	sessionRepo output.SessionRepository
+	uow         output.UnitOfWork
+}
+
+// NewUserService creates a new instance of UserService with the required dependencies.
+func NewUserService(userRepo output.UserRepository, sessionRepo output.SessionRepository, uow output.UnitOfWork) *UserService {
+	return &UserService{
+		userRepo:    userRepo,
+		sessionRepo: sessionRepo,
+		uow:         uow,
+	}
+}
+
+// Register implements input.UserService.Register.
+// The first account to register takes ownership of data created before accounts existed.
+func (s *UserService) Register(ctx context.Context, email, name, password string) (*models.User, error) {
+	email = strings.TrimSpace(email)
+	if !strings.Contains(email, "@") || strings.ContainsAny(email, " \t\r\n") {
+		return nil, ErrInvalidEmail
+	}
+	if len([]rune(password)) < MinPasswordLength {
+		return nil, ErrWeakPassword
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(name) == "" {
+		name, _, _ = strings.Cut(email, "@")
+	}
+
+	now := time.Now().UTC()
+	user := &models.User{
+		Email:        email,
+		Name:         strings.TrimSpace(name),
+		PasswordHash: string(hash),
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+
+	// Registrations are serialised, so only one of two concurrent first accounts claims the data
+	err = s.uow.Do(ctx, func(ctx context.Context) error {
+		existing, err := s.userRepo.GetByEmail(ctx, email)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return ErrEmailTaken
+		}
+
+		count, err := s.userRepo.Count(ctx)
+		if err != nil {
+			return err
+		}
+
+		if err := s.userRepo.Create(ctx, user); err != nil {
+			return err
+		}
+		if count == 0 {
+			return s.userRepo.ClaimUnowned(ctx, user.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// Login implements input.UserService.Login.
+// It verifies the password and opens a new session whose raw token is returned only once.
+func (s *UserService) Login(ctx context.Context, email, password string) (*models.Session, error) {
+	user, err := s.userRepo.GetByEmail(ctx, strings.TrimSpace(email))
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrInvalidCredentials
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	token, err := generateToken(sessionTokenBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	session := &models.Session{
+		Token:     token,
+		TokenHash: hashToken(token),
+		UserID:    user.ID,
+		ExpiresAt: now.Add(SessionDuration),
+		CreatedAt: now,
+	}
+	if err := s.sessionRepo.Create(ctx, session); err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// Logout implements input.UserService.Logout.
+func (s *UserService) Logout(ctx context.Context, token string) error {
+	return s.sessionRepo.Delete(ctx, hashToken(token))
+}
+
+// Authenticate implements input.UserService.Authenticate.
+// It resolves a session token to its user, rejecting unknown and expired sessions.
+func (s *UserService) Authenticate(ctx context.Context, token string) (*models.User, error) {
+	if token == "" {
+		return nil, ErrInvalidCredentials
+	}
+
+	session, err := s.sessionRepo.GetByTokenHash(ctx, hashToken(token))
+	if err != nil {
+		return nil, err
+	}
+	if session == nil {
+		return nil, ErrInvalidCredentials
+	}
+	if time.Now().After(session.ExpiresAt) {
+		s.sessionRepo.Delete(ctx, session.TokenHash)
+		return nil, ErrInvalidCredentials
+	}
+
+	return s.GetUser(ctx, session.UserID)
+}
+
+// GetUser implements input.UserService.GetUser.
+func (s *UserService) GetUser(ctx context.Context, id int64) (*models.User, error) {
+	user, err := s.userRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrInvalidCredentials
+	}
+	return user, nil
+}
+
+// generateToken returns a random URL-safe token with the given number of random bytes.
+func generateToken(size int) (string, error) {
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// hashToken returns the hex SHA-256 of a token, the only form in which tokens are stored.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
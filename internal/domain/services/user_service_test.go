@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"uni-task-manager/internal/domain/models"
+	"uni-task-manager/internal/ports/output"
+)
+
+// accountRepository keeps users in memory and remembers who claimed the unowned data;
+// counting, creating and claiming are only allowed inside a unit of work
+type accountRepository struct {
+	output.UserRepository
+	users     []models.User
+	claimedBy int64
+}
+
+func (r *accountRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	for _, user := range r.users {
+		if user.Email == email {
+			return &user, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *accountRepository) Count(ctx context.Context) (int, error) {
+	if ctx.Value(inWorkKey{}) == nil {
+		return 0, errOutsideWork
+	}
+	return len(r.users), nil
+}
+
+func (r *accountRepository) Create(ctx context.Context, user *models.User) error {
+	if ctx.Value(inWorkKey{}) == nil {
+		return errOutsideWork
+	}
+	user.ID = int64(len(r.users) + 1)
+	r.users = append(r.users, *user)
+	return nil
+}
+
+func (r *accountRepository) ClaimUnowned(ctx context.Context, userID int64) error {
+	if ctx.Value(inWorkKey{}) == nil {
+		return errOutsideWork
+	}
+	r.claimedBy = userID
+	return nil
+}
+
+func TestRegister(t *testing.T) {
+	ctx := context.Background()
+	users := &accountRepository{}
+	service := NewUserService(users, nil, trackedUnitOfWork{})
+
+	first, err := service.Register(ctx, "ada@example.com", "Ada", "correct horse")
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if users.claimedBy != first.ID {
+		t.Errorf("unowned data claimed by %d, want the first account %d", users.claimedBy, first.ID)
+	}
+
+	second, err := service.Register(ctx, "alan@example.com", "", "correct horse")
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if users.claimedBy != first.ID {
+		t.Errorf("unowned data claimed by %d after a second account, want %d", users.claimedBy, first.ID)
+	}
+	if second.Name != "alan" {
+		t.Errorf("Register() without a name = %q, want the local part of the email", second.Name)
+	}
+
+	if _, err := service.Register(ctx, " ada@example.com ", "Ada", "correct horse"); !errors.Is(err, ErrEmailTaken) {
+		t.Errorf("Register() with a taken email error = %v, want %v", err, ErrEmailTaken)
+	}
+	if len(users.users) != 2 {
+		t.Errorf("%d accounts registered, want 2", len(users.users))
+	}
+}
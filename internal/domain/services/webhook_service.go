@@ -0,0 +1,378 @@
+// Package services implements the core business logic for outgoing webhooks
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"uni-task-manager/internal/domain/models"
+	"uni-task-manager/internal/ports/input"
+	"uni-task-manager/internal/ports/output"
+)
+
+// Domain-specific errors that can be returned by the WebhookService
+var (
+	// ErrWebhookNotFound indicates that the requested webhook does not exist
+	ErrWebhookNotFound = errors.New("webhook not found")
+
+	// ErrInvalidWebhookURL indicates a webhook URL that isn't an absolute http or https URL, or that points to a private network
+	ErrInvalidWebhookURL = errors.New("webhook URL must be a public absolute http or https URL of at most 2000 characters")
+
+	// ErrInvalidWebhookEvents indicates a webhook without events or subscribed to an unknown event
+	ErrInvalidWebhookEvents = errors.New("webhook events must list event types such as task.created, task.*, course.* or *")
+)
+
+const (
+	// MaxWebhookAttempts caps the number of times the delivery of an event to a webhook is attempted
+	MaxWebhookAttempts = 8
+
+	// WebhookRetryDelay is the delay before the first retry; it doubles after every further failed attempt
+	WebhookRetryDelay = 30 * time.Second
+
+	// WebhookDeliveryLogSize is the number of latest deliveries listed per webhook
+	WebhookDeliveryLogSize = 50
+
+	// webhookBatchSize caps the number of deliveries attempted by a single dispatcher pass
+	webhookBatchSize = 100
+
+	// maxWebhookURLLength caps the length of a webhook URL
+	maxWebhookURLLength = 2000
+)
+
+// Verify WebhookService implements the input.WebhookService and output.EventSubscriber interfaces at compile time
+var (
+	_ input.WebhookService   = (*WebhookService)(nil)
+	_ output.EventSubscriber = (*WebhookService)(nil)
+)
+
+// WebhookService implements outgoing webhooks: it queues every dispatched event for the webhooks
+// subscribed to it and delivers the queue as HMAC-signed POST requests, retrying failed attempts
+// with exponential backoff. Deliveries are at least once, so subscribers should ignore event IDs
+// they have already seen.
+type WebhookService struct {
+	webhookRepo output.WebhookRepository
+	client      output.WebhookClient
+}
+
+// NewWebhookService creates a new instance of WebhookService with the required dependencies.
+func NewWebhookService(webhookRepo output.WebhookRepository, client output.WebhookClient) *WebhookService {
+	return &WebhookService{
+		webhookRepo: webhookRepo,
+		client:      client,
+	}
+}
+
+// CreateWebhook implements input.WebhookService.CreateWebhook.
+// The returned webhook carries its secret, which is not returned by the other operations.
+func (s *WebhookService) CreateWebhook(ctx context.Context, webhook *models.Webhook) error {
+	ownerID, err := currentUserID(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := s.validateWebhook(ctx, webhook); err != nil {
+		return err
+	}
+
+	secret, err := generateToken(32)
+	if err != nil {
+		return err
+	}
+
+	webhook.OwnerID = ownerID
+	webhook.Secret = "whsec_" + secret
+	now := time.Now().UTC()
+	webhook.CreatedAt = now
+	webhook.UpdatedAt = now
+
+	return s.webhookRepo.Create(ctx, webhook)
+}
+
+// ListWebhooks implements input.WebhookService.ListWebhooks.
+func (s *WebhookService) ListWebhooks(ctx context.Context) ([]models.Webhook, error) {
+	ownerID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks, err := s.webhookRepo.GetAll(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range webhooks {
+		webhooks[i].Secret = ""
+	}
+	return webhooks, nil
+}
+
+// GetWebhook implements input.WebhookService.GetWebhook.
+func (s *WebhookService) GetWebhook(ctx context.Context, id int64) (*models.Webhook, error) {
+	webhook, err := s.getWebhook(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	webhook.Secret = ""
+	return webhook, nil
+}
+
+// UpdateWebhook implements input.WebhookService.UpdateWebhook.
+// Deliveries queued before the webhook was changed are sent to its new URL.
+func (s *WebhookService) UpdateWebhook(ctx context.Context, webhook *models.Webhook) error {
+	existing, err := s.getWebhook(ctx, webhook.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.validateWebhook(ctx, webhook); err != nil {
+		return err
+	}
+
+	webhook.OwnerID = existing.OwnerID
+	webhook.CreatedAt = existing.CreatedAt
+	webhook.UpdatedAt = time.Now().UTC()
+
+	return s.webhookRepo.Update(ctx, webhook)
+}
+
+// DeleteWebhook implements input.WebhookService.DeleteWebhook.
+func (s *WebhookService) DeleteWebhook(ctx context.Context, id int64) error {
+	webhook, err := s.getWebhook(ctx, id)
+	if err != nil {
+		return err
+	}
+	return s.webhookRepo.Delete(ctx, webhook.OwnerID, id)
+}
+
+// GetDeliveries implements input.WebhookService.GetDeliveries.
+// It lists the latest WebhookDeliveryLogSize deliveries.
+func (s *WebhookService) GetDeliveries(ctx context.Context, webhookID int64) ([]models.WebhookDelivery, error) {
+	webhook, err := s.getWebhook(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	return s.webhookRepo.GetDeliveries(ctx, webhook.OwnerID, webhookID, WebhookDeliveryLogSize)
+}
+
+// HandleEvent implements output.EventSubscriber.HandleEvent.
+// It queues a delivery of the event for every active webhook of its owner subscribed to it;
+// the deliveries are attempted by the next DeliverPending pass.
+func (s *WebhookService) HandleEvent(ctx context.Context, message models.OutboxMessage) error {
+	webhooks, err := s.webhookRepo.GetAll(ctx, message.OwnerID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	for _, webhook := range webhooks {
+		if !webhook.Active || !subscribesTo(webhook.Events, message.EventType) {
+			continue
+		}
+
+		delivery := &models.WebhookDelivery{
+			WebhookID:     webhook.ID,
+			OwnerID:       webhook.OwnerID,
+			EventID:       message.EventID,
+			EventType:     message.EventType,
+			Payload:       message.Payload,
+			Status:        models.WebhookDeliveryPending,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		}
+		if err := s.webhookRepo.CreateDelivery(ctx, delivery); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeliverPending implements input.WebhookService.DeliverPending.
+// Any 2xx response acknowledges a delivery. After a failed attempt the delivery is retried after
+// WebhookRetryDelay, doubled after every further failure, until MaxWebhookAttempts are made.
+// Deliveries to webhooks deactivated since the event was queued are dropped as failed.
+func (s *WebhookService) DeliverPending(ctx context.Context, now time.Time) (*models.WebhookRun, error) {
+	run := &models.WebhookRun{}
+
+	deliveries, err := s.webhookRepo.GetDueDeliveries(ctx, now, webhookBatchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks := make(map[int64]*models.Webhook)
+	for i := range deliveries {
+		delivery := &deliveries[i]
+
+		webhook, ok := webhooks[delivery.WebhookID]
+		if !ok {
+			if webhook, err = s.webhookRepo.GetByID(ctx, delivery.OwnerID, delivery.WebhookID); err != nil {
+				return nil, err
+			}
+			webhooks[delivery.WebhookID] = webhook
+		}
+
+		if webhook == nil || !webhook.Active {
+			delivery.Status = models.WebhookDeliveryFailed
+			delivery.NextAttemptAt = time.Time{}
+			delivery.LastError = "webhook is inactive"
+			delivery.UpdatedAt = now
+		} else {
+			s.attempt(ctx, webhook, delivery, now)
+		}
+
+		if err := s.webhookRepo.UpdateDelivery(ctx, delivery); err != nil {
+			return nil, err
+		}
+
+		switch {
+		case delivery.Status == models.WebhookDeliverySucceeded:
+			run.Succeeded++
+		case delivery.Status == models.WebhookDeliveryFailed:
+			run.Failed++
+		default:
+			run.Retrying++
+		}
+	}
+
+	return run, nil
+}
+
+// attempt posts a delivery to its webhook and records the outcome on the delivery.
+// The payload is signed with the webhook secret over "<timestamp>.<payload>", so receivers
+// can authenticate it and reject replays of old requests.
+func (s *WebhookService) attempt(ctx context.Context, webhook *models.Webhook, delivery *models.WebhookDelivery, now time.Time) {
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	headers := map[string]string{
+		"Content-Type":        "application/json",
+		"X-Webhook-Event":     string(delivery.EventType),
+		"X-Webhook-Delivery":  strconv.FormatInt(delivery.ID, 10),
+		"X-Webhook-Timestamp": timestamp,
+		"X-Webhook-Signature": "sha256=" + signPayload(webhook.Secret, timestamp, delivery.Payload),
+	}
+
+	status, err := s.client.Post(ctx, webhook.URL, headers, []byte(delivery.Payload))
+	delivery.Attempts++
+	delivery.ResponseStatus = status
+	delivery.UpdatedAt = now
+
+	switch {
+	case err != nil:
+		delivery.LastError = err.Error()
+	case status < 200 || status > 299:
+		delivery.LastError = fmt.Sprintf("webhook responded with status %d", status)
+	default:
+		delivery.Status = models.WebhookDeliverySucceeded
+		delivery.NextAttemptAt = time.Time{}
+		delivery.LastError = ""
+		return
+	}
+
+	if delivery.Attempts >= MaxWebhookAttempts {
+		delivery.Status = models.WebhookDeliveryFailed
+		delivery.NextAttemptAt = time.Time{}
+		return
+	}
+	delivery.NextAttemptAt = now.Add(WebhookRetryDelay << (delivery.Attempts - 1))
+}
+
+// getWebhook retrieves a webhook of the current user, returning ErrWebhookNotFound if it doesn't exist.
+func (s *WebhookService) getWebhook(ctx context.Context, id int64) (*models.Webhook, error) {
+	ownerID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook, err := s.webhookRepo.GetByID(ctx, ownerID, id)
+	if err != nil {
+		return nil, err
+	}
+	if webhook == nil {
+		return nil, ErrWebhookNotFound
+	}
+	return webhook, nil
+}
+
+// validateWebhook checks the webhook and asks the client whether it may deliver to its URL.
+func (s *WebhookService) validateWebhook(ctx context.Context, webhook *models.Webhook) error {
+	if err := validateWebhook(webhook); err != nil {
+		return err
+	}
+	if err := s.client.CheckURL(ctx, webhook.URL); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidWebhookURL, err)
+	}
+	return nil
+}
+
+// validateWebhook checks the URL and events of a webhook, dropping duplicate events.
+// All violations are reported together, joined with errors.Join.
+func validateWebhook(webhook *models.Webhook) error {
+	var errs []error
+
+	webhook.URL = strings.TrimSpace(webhook.URL)
+	parsed, err := url.Parse(webhook.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" ||
+		len(webhook.URL) > maxWebhookURLLength {
+		errs = append(errs, ErrInvalidWebhookURL)
+	}
+
+	var events []string
+	seen := make(map[string]bool)
+	for _, pattern := range webhook.Events {
+		pattern = strings.TrimSpace(pattern)
+		if !validEventPattern(pattern) {
+			errs = append(errs, fmt.Errorf("%w: unknown event %q", ErrInvalidWebhookEvents, pattern))
+			break
+		}
+		if !seen[pattern] {
+			seen[pattern] = true
+			events = append(events, pattern)
+		}
+	}
+	if len(webhook.Events) == 0 {
+		errs = append(errs, ErrInvalidWebhookEvents)
+	}
+	webhook.Events = events
+
+	return errors.Join(errs...)
+}
+
+// validEventPattern reports whether a webhook can subscribe to the given event type or pattern.
+func validEventPattern(pattern string) bool {
+	switch pattern {
+	case "*", "task.*", "course.*":
+		return true
+	}
+	for _, eventType := range models.EventTypes {
+		if pattern == string(eventType) {
+			return true
+		}
+	}
+	return false
+}
+
+// subscribesTo reports whether any of the subscribed events matches the event type.
+func subscribesTo(patterns []string, eventType models.EventType) bool {
+	for _, pattern := range patterns {
+		if pattern == "*" || pattern == string(eventType) {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok && strings.HasPrefix(string(eventType), prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// signPayload computes the hex HMAC-SHA256 of "<timestamp>.<payload>" keyed with the webhook secret.
+func signPayload(secret, timestamp, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "." + payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,66 @@
+package services
+
+import "testing"
+
+func TestSignPayload(t *testing.T) {
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp string
+		payload   string
+		want      string
+	}{
+		{
+			name:      "known signature",
+			secret:    "whsec_test",
+			timestamp: "1700000000",
+			payload:   `{"id":"evt_1","type":"task.created"}`,
+			want:      "4badb79155d60aa98f9150e842d811ce1c1e1af9ade570ba375195e45189a61a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := signPayload(tt.secret, tt.timestamp, tt.payload); got != tt.want {
+				t.Errorf("signPayload() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	// The signature covers the secret, the timestamp and the payload
+	base := signPayload("whsec_test", "1700000000", "{}")
+	for _, other := range []string{
+		signPayload("whsec_other", "1700000000", "{}"),
+		signPayload("whsec_test", "1700000001", "{}"),
+		signPayload("whsec_test", "1700000000", "{ }"),
+	} {
+		if other == base {
+			t.Errorf("signature %s does not depend on every input", other)
+		}
+	}
+}
+
+func TestValidEventPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    bool
+	}{
+		{"*", true},
+		{"task.*", true},
+		{"course.*", true},
+		{"task.created", true},
+		{"task.status_changed", true},
+		{"course.deleted", true},
+		{"task.unknown", false},
+		{"user.*", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			if got := validEventPattern(tt.pattern); got != tt.want {
+				t.Errorf("validEventPattern(%q) = %v, want %v", tt.pattern, got, tt.want)
+			}
+		})
+	}
+}
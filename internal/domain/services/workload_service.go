@@ -0,0 +1,181 @@
+// Package services implements the core business logic for workload forecasting
+package services
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"uni-task-manager/internal/domain/models"
+	"uni-task-manager/internal/ports/input"
+	"uni-task-manager/internal/ports/output"
+)
+
+// Domain-specific errors that can be returned by the WorkloadService
+var (
+	// ErrInvalidWorkloadRange indicates a forecast that ends before it starts or spans more than a year
+	ErrInvalidWorkloadRange = errors.New("workload range must end on or after its start and span at most 366 days")
+
+	// ErrInvalidCapacity indicates a weekly capacity that is negative or longer than a week
+	ErrInvalidCapacity = errors.New("weekly capacity must be between 0 and 168 hours")
+)
+
+const (
+	// DefaultWeeklyCapacity is the number of hours a week a user can work when no capacity is given
+	DefaultWeeklyCapacity = 20
+
+	// DefaultWorkloadWeeks is the number of weeks forecast when no end is given
+	DefaultWorkloadWeeks = 8
+
+	// MaxWorkloadDays caps the number of days of a single forecast
+	MaxWorkloadDays = 366
+)
+
+// dayLength is the length of a UTC calendar day
+const dayLength = 24 * time.Hour
+
+// Verify WorkloadService implements input.WorkloadService interface at compile time
+var _ input.WorkloadService = (*WorkloadService)(nil)
+
+// WorkloadService implements workload forecasting: it spreads the remaining effort of the
+// estimated tasks over the days until they are due and flags the weeks that exceed the capacity.
+type WorkloadService struct {
+	taskRepo output.TaskRepository
+	timeRepo output.TimeEntryRepository
+}
+
+// NewWorkloadService creates a new instance of WorkloadService with the required dependencies.
+func NewWorkloadService(taskRepo output.TaskRepository, timeRepo output.TimeEntryRepository) *WorkloadService {
+	return &WorkloadService{
+		taskRepo: taskRepo,
+		timeRepo: timeRepo,
+	}
+}
+
+// GetWorkload implements input.WorkloadService.GetWorkload.
+// The remaining effort of a task is its estimate minus the time already logged on it; it is spread
+// evenly over the days from today to its due date, and overdue tasks are planned entirely for today.
+// Tasks without a due date are left out.
+func (s *WorkloadService) GetWorkload(ctx context.Context, from, to time.Time, capacity float64) (*models.Workload, error) {
+	ownerID, err := currentUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	today := startOfDay(now)
+	if from.IsZero() {
+		from = today
+	}
+	from = startOfDay(from)
+	if to.IsZero() {
+		to = from.AddDate(0, 0, 7*DefaultWorkloadWeeks-1)
+	}
+	to = startOfDay(to)
+	if to.Before(from) || int(to.Sub(from)/dayLength) >= MaxWorkloadDays {
+		return nil, ErrInvalidWorkloadRange
+	}
+
+	if capacity == 0 {
+		capacity = DefaultWeeklyCapacity
+	}
+	if capacity < 0 || capacity > 168 {
+		return nil, ErrInvalidCapacity
+	}
+
+	tasks, err := s.taskRepo.GetAll(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	spent, err := s.timeRepo.SumByTask(ctx, ownerID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	workload := &models.Workload{From: from, To: to, WeeklyCapacity: capacity}
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		workload.Days = append(workload.Days, models.WorkloadDay{Date: d})
+	}
+	firstWeek := startOfWeek(from)
+	for w := firstWeek; !w.After(to); w = w.AddDate(0, 0, 7) {
+		workload.Weeks = append(workload.Weeks, models.WorkloadWeek{Start: w})
+	}
+
+	shares := make([]map[int64]float64, len(workload.Weeks))
+	titles := make(map[int64]string)
+	for _, task := range tasks {
+		// A task without a due date has no days to spread its effort over
+		if task.Status == models.TaskStatusCompleted || task.EstimatedHours <= 0 || task.DueDate.IsZero() {
+			continue
+		}
+		remaining := task.EstimatedHours - spent[task.ID].Hours()
+		if remaining <= 0 {
+			continue
+		}
+
+		due := startOfDay(task.DueDate)
+		if due.Before(today) {
+			due = today
+		}
+		perDay := remaining / float64(due.Sub(today)/dayLength+1)
+
+		first, last := latest(today, from), earliest(due, to)
+		for d := first; !d.After(last); d = d.AddDate(0, 0, 1) {
+			workload.Days[d.Sub(from)/dayLength].Hours += perDay
+			week := int(startOfWeek(d).Sub(firstWeek) / (7 * dayLength))
+			workload.Weeks[week].Hours += perDay
+			if shares[week] == nil {
+				shares[week] = make(map[int64]float64)
+			}
+			shares[week][task.ID] += perDay
+			titles[task.ID] = task.Title
+		}
+	}
+
+	for i := range workload.Weeks {
+		week := &workload.Weeks[i]
+		week.Overloaded = week.Hours > capacity
+		for taskID, hours := range shares[i] {
+			week.Tasks = append(week.Tasks, models.WorkloadTask{TaskID: taskID, Title: titles[taskID], Hours: hours})
+		}
+		sort.Slice(week.Tasks, func(a, b int) bool {
+			if week.Tasks[a].Hours != week.Tasks[b].Hours {
+				return week.Tasks[a].Hours > week.Tasks[b].Hours
+			}
+			return week.Tasks[a].TaskID < week.Tasks[b].TaskID
+		})
+		workload.Total += week.Hours
+	}
+
+	return workload, nil
+}
+
+// startOfDay returns midnight UTC of the day of t.
+func startOfDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// startOfWeek returns midnight UTC of the Monday of the week of t.
+func startOfWeek(t time.Time) time.Time {
+	t = startOfDay(t)
+	return t.AddDate(0, 0, -mondayOffset(t.Weekday()))
+}
+
+// earliest returns the earlier of two times.
+func earliest(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+// latest returns the later of two times.
+func latest(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
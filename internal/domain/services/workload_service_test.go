@@ -0,0 +1,44 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"uni-task-manager/internal/domain/models"
+)
+
+func TestGetWorkloadLeavesOutTasksWithoutDueDate(t *testing.T) {
+	ctx := ContextWithUserID(context.Background(), 1)
+	today := startOfDay(time.Now())
+	tasks := &listedTaskRepository{tasks: []models.Task{
+		{ID: 1, Title: "Reading", Priority: 3, Status: models.TaskStatusPending, EstimatedHours: 30},
+		{ID: 2, Title: "Essay", Priority: 3, Status: models.TaskStatusPending, EstimatedHours: 6,
+			DueDate: today.AddDate(0, 0, 2).Add(12 * time.Hour)},
+	}}
+	service := NewWorkloadService(tasks, untrackedTimeRepository{})
+
+	workload, err := service.GetWorkload(ctx, today, today.AddDate(0, 0, 13), 20)
+	if err != nil {
+		t.Fatalf("GetWorkload() error = %v", err)
+	}
+
+	for i, want := range []float64{2, 2, 2, 0} {
+		if got := workload.Days[i].Hours; got != want {
+			t.Errorf("day %d hours = %v, want %v", i, got, want)
+		}
+	}
+	if workload.Total != 6 {
+		t.Errorf("GetWorkload() total = %v, want 6", workload.Total)
+	}
+	for _, week := range workload.Weeks {
+		if week.Overloaded {
+			t.Errorf("week of %s is overloaded, want no week over capacity", week.Start.Format(time.DateOnly))
+		}
+		for _, task := range week.Tasks {
+			if task.TaskID != 2 {
+				t.Errorf("week of %s lists task %d, want only the dated task 2", week.Start.Format(time.DateOnly), task.TaskID)
+			}
+		}
+	}
+}
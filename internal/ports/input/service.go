@@ -4,6 +4,7 @@ package input
 
 import (
 	"context"
+	"time"
 
 	"uni-task-manager/internal/domain/models"
 )
@@ -16,9 +17,30 @@ type TaskService interface {
 	CreateTask(ctx context.Context, task *models.Task) error
 
 	// UpdateTask modifies an existing task with input validation and business rules
-	// Returns an error if the task doesn't exist or if the updated data is invalid
+	// A non-zero task.Version is the version the update is based on
+	// Returns an error if the task doesn't exist or if the updated data is invalid,
+	// ErrVersionConflict if the task was changed since that version
+	// or ErrTaskBlocked if it is completed while its prerequisites are open
 	UpdateTask(ctx context.Context, task *models.Task) error
 
+	// CreateTaskWithTags creates a new task, starting a series if a rule is given, and replaces
+	// its tags by name, creating the tags that don't exist yet
+	// Returns ErrInvalidTagName if a name is rejected, before anything is created
+	CreateTaskWithTags(ctx context.Context, task *models.Task, rule *models.Recurrence, tags []string) error
+
+	// UpdateTaskWithTags modifies an existing task like UpdateTask and replaces its tags by name
+	// Returns ErrInvalidTagName if a name is rejected, before anything is updated
+	UpdateTaskWithTags(ctx context.Context, task *models.Task, tags []string) error
+
+	// PatchTask merges a partial update onto the stored task and returns the result
+	// Returns ErrTaskNotFound if the task doesn't exist, an error if the merged task is invalid
+	// or ErrVersionConflict if patch.Version is set and no longer current
+	PatchTask(ctx context.Context, id int64, patch models.TaskPatch) (*models.Task, error)
+
+	// SetTaskStatus moves a task to the given status and returns the updated task
+	// Returns ErrTaskNotFound if the task doesn't exist or ErrInvalidTaskStatus if the status is unknown
+	SetTaskStatus(ctx context.Context, id int64, status models.TaskStatus) (*models.Task, error)
+
 	// GetTask retrieves a specific task by its ID
 	// Returns ErrTaskNotFound if the task doesn't exist
 	GetTask(ctx context.Context, id int64) (*models.Task, error)
@@ -26,9 +48,81 @@ type TaskService interface {
 	// GetAllTasks retrieves all tasks in the system
 	GetAllTasks(ctx context.Context) ([]models.Task, error)
 
-	// DeleteTask removes a task from the system
+	// ListTasks retrieves a filtered, sorted page of tasks
+	// Returns ErrInvalidTaskQuery if the query criteria or cursor are invalid
+	ListTasks(ctx context.Context, query models.TaskQuery) (*models.TaskPage, error)
+
+	// SearchTasks retrieves the tasks matching a full-text query, most relevant first
+	// Returns ErrInvalidSearch if the query has no searchable terms
+	SearchTasks(ctx context.Context, query string, limit int) ([]models.TaskSearchResult, error)
+
+	// GetTasksByCourse retrieves all tasks associated with a specific course
+	// Returns ErrCourseNotFound if the course doesn't exist
+	GetTasksByCourse(ctx context.Context, courseID int64) ([]models.Task, error)
+
+	// CreateSubtask creates a new task as a subtask of the given parent task
+	// Returns ErrTaskNotFound if the parent doesn't exist or ErrNestedSubtask if it is itself a subtask
+	CreateSubtask(ctx context.Context, parentID int64, task *models.Task) error
+
+	// GetSubtasks retrieves all subtasks of a specific task
+	// Returns ErrTaskNotFound if the parent task doesn't exist
+	GetSubtasks(ctx context.Context, parentID int64) ([]models.Task, error)
+
+	// GetTaskProgress computes the completion percentage of a task from its subtasks
 	// Returns ErrTaskNotFound if the task doesn't exist
-	DeleteTask(ctx context.Context, id int64) error
+	GetTaskProgress(ctx context.Context, id int64) (*models.TaskProgress, error)
+
+	// GetAllTaskProgress computes the progress of every task that has subtasks, keyed by task ID
+	GetAllTaskProgress(ctx context.Context) (map[int64]models.TaskProgress, error)
+
+	// CreateRecurringTask creates a new task starting a series with the given rule, both or neither
+	// Returns ErrInvalidRecurrence if the rule is malformed, before anything is created
+	CreateRecurringTask(ctx context.Context, task *models.Task, rule *models.Recurrence) error
+
+	// SetRecurrence makes the task recurring, or replaces the rule of its series
+	// Returns ErrInvalidRecurrence if the rule is malformed or ErrTaskNotFound if the task doesn't exist
+	SetRecurrence(ctx context.Context, taskID int64, rule *models.Recurrence) error
+
+	// RemoveRecurrence stops the series of the task; existing occurrences are kept
+	// Returns ErrTaskNotRecurring if the task doesn't belong to a series
+	RemoveRecurrence(ctx context.Context, taskID int64) error
+
+	// GetSeries retrieves the recurrence rule and all occurrences of the task's series
+	// Returns ErrTaskNotRecurring if the task doesn't belong to a series
+	GetSeries(ctx context.Context, taskID int64) (*models.TaskSeries, error)
+
+	// AddDependency makes a task wait for the completion of another task
+	// Returns ErrTaskNotFound if either task doesn't exist or ErrDependencyCycle if the
+	// other task already depends on the task, directly or indirectly
+	AddDependency(ctx context.Context, taskID, dependsOnID int64) error
+
+	// RemoveDependency stops a task from waiting for another task
+	// Returns ErrTaskNotFound if the task doesn't exist or ErrDependencyNotFound if it doesn't depend on the other task
+	RemoveDependency(ctx context.Context, taskID, dependsOnID int64) error
+
+	// GetDependencies retrieves the prerequisites and dependents of a task and whether it is blocked
+	// Returns ErrTaskNotFound if the task doesn't exist
+	GetDependencies(ctx context.Context, taskID int64) (*models.TaskDependencies, error)
+
+	// GetBlockedTasks reports which of the given tasks are unfinished and waiting for an unfinished
+	// prerequisite, keyed by task ID
+	GetBlockedTasks(ctx context.Context, taskIDs []int64) (map[int64]bool, error)
+
+	// DeleteTask removes a task and its subtasks from the system
+	// A non-zero version must match the stored version of the task
+	// Returns ErrTaskNotFound if the task doesn't exist or ErrVersionConflict if the version doesn't match
+	DeleteTask(ctx context.Context, id, version int64) error
+
+	// GetTaskHistory retrieves the recorded changes of a task, newest first; the history of
+	// a deleted task remains available
+	// Returns ErrTaskNotFound if the task doesn't exist and has no history
+	GetTaskHistory(ctx context.Context, id int64) ([]models.TaskHistoryEntry, error)
+
+	// RestoreTaskVersion brings the task back to its state before the change recorded by a history entry
+	// A non-zero version must match the stored version of the task
+	// Returns ErrHistoryEntryNotFound if the entry isn't part of the task's history, ErrVersionConflict
+	// if the version doesn't match, or the validation errors of an update
+	RestoreTaskVersion(ctx context.Context, id, entryID, version int64) (*models.Task, error)
 }
 
 // CourseService defines the primary port for course-related business operations.
@@ -39,7 +133,9 @@ type CourseService interface {
 	CreateCourse(ctx context.Context, course *models.Course) error
 
 	// UpdateCourse modifies an existing course with input validation and business rules
-	// Returns an error if the course doesn't exist or if the updated data is invalid
+	// A non-zero course.Version is the version the update is based on
+	// Returns an error if the course doesn't exist or if the updated data is invalid,
+	// or ErrVersionConflict if the course was changed since that version
 	UpdateCourse(ctx context.Context, course *models.Course) error
 
 	// GetCourse retrieves a specific course by its ID
@@ -49,7 +145,204 @@ type CourseService interface {
 	// GetAllCourses retrieves all courses in the system
 	GetAllCourses(ctx context.Context) ([]models.Course, error)
 
-	// DeleteCourse removes a course from the system
+	// GetCourseStats summarizes the status of the tasks of a specific course
 	// Returns ErrCourseNotFound if the course doesn't exist
-	DeleteCourse(ctx context.Context, id int64) error
+	GetCourseStats(ctx context.Context, id int64) (*models.CourseStats, error)
+
+	// DeleteCourse removes a course from the system, handling its tasks according to the policy
+	// A non-zero version must match the stored version of the course
+	// Returns ErrCourseNotFound if the course doesn't exist, ErrVersionConflict if the version doesn't match,
+	// ErrCourseHasTasks if the policy restricts and the course has tasks or ErrInvalidDeletePolicy
+	DeleteCourse(ctx context.Context, id, version int64, policy models.CourseDeletePolicy) error
+}
+
+// TagService defines the primary port for tagging tasks.
+// This interface represents the API through which the application core can be used.
+type TagService interface {
+	// CreateTag creates a new tag with a normalized name
+	// Returns ErrInvalidTagName if the name is rejected or ErrTagExists if the name is taken
+	CreateTag(ctx context.Context, tag *models.Tag) error
+
+	// UpdateTag renames an existing tag
+	// Returns ErrTagNotFound if the tag doesn't exist, ErrInvalidTagName or ErrTagExists
+	UpdateTag(ctx context.Context, tag *models.Tag) error
+
+	// GetTag retrieves a specific tag by its ID
+	// Returns ErrTagNotFound if the tag doesn't exist
+	GetTag(ctx context.Context, id int64) (*models.Tag, error)
+
+	// GetAllTags retrieves all tags in the system
+	GetAllTags(ctx context.Context) ([]models.Tag, error)
+
+	// DeleteTag removes a tag from the system and from every task carrying it
+	// Returns ErrTagNotFound if the tag doesn't exist
+	DeleteTag(ctx context.Context, id int64) error
+
+	// GetTaskTags retrieves the tags of a specific task
+	// Returns ErrTaskNotFound if the task doesn't exist
+	GetTaskTags(ctx context.Context, taskID int64) ([]models.Tag, error)
+
+	// GetAllTaskTags retrieves the tags of every tagged task, keyed by task ID
+	GetAllTaskTags(ctx context.Context) (map[int64][]models.Tag, error)
+
+	// GetTagsOfTasks retrieves the tags of the given tasks, keyed by task ID
+	GetTagsOfTasks(ctx context.Context, taskIDs []int64) (map[int64][]models.Tag, error)
+
+	// SetTaskTags replaces the tags of a task by name, creating the tags that don't exist yet,
+	// and returns the resulting tags
+	// Returns ErrTaskNotFound if the task doesn't exist or ErrInvalidTagName if a name is rejected
+	SetTaskTags(ctx context.Context, taskID int64, names []string) ([]models.Tag, error)
+}
+
+// TimeService defines the primary port for tracking the time spent on tasks.
+// This interface represents the API through which the application core can be used.
+type TimeService interface {
+	// StartTimer starts timing a task, stopping the timer running for another task if any
+	// Returns ErrTaskNotFound if the task doesn't exist or ErrTimerRunning if it is already timed
+	StartTimer(ctx context.Context, taskID int64, note string) (*models.TimeEntry, error)
+
+	// StopTimer stops timing a task and returns the finished entry; a non-empty note replaces its note
+	// Returns ErrTaskNotFound if the task doesn't exist or ErrTimerNotRunning if it isn't timed
+	StopTimer(ctx context.Context, taskID int64, note string) (*models.TimeEntry, error)
+
+	// LogTime records a finished session on entry.TaskID, entered manually
+	// Returns ErrTaskNotFound if the task doesn't exist or ErrInvalidTimeEntry if the session is invalid
+	LogTime(ctx context.Context, entry *models.TimeEntry) error
+
+	// GetTimeEntries retrieves the time entries of a specific task, latest first
+	// Returns ErrTaskNotFound if the task doesn't exist
+	GetTimeEntries(ctx context.Context, taskID int64) ([]models.TimeEntry, error)
+
+	// DeleteTimeEntry removes a time entry of a specific task
+	// Returns ErrTaskNotFound if the task doesn't exist or ErrTimeEntryNotFound if the entry doesn't
+	DeleteTimeEntry(ctx context.Context, taskID, id int64) error
+
+	// GetTimeTotals totals the time logged per task and per course
+	GetTimeTotals(ctx context.Context) (*models.TimeTotals, error)
+}
+
+// WorkloadService defines the primary port for forecasting the workload of the estimated tasks.
+// This interface represents the API through which the application core can be used.
+type WorkloadService interface {
+	// GetWorkload forecasts the hours required per day and per week between two days, flagging
+	// the weeks above the weekly capacity; zero values select today, eight weeks and 20 hours
+	// Returns ErrInvalidWorkloadRange if the range is invalid or ErrInvalidCapacity if the capacity is
+	GetWorkload(ctx context.Context, from, to time.Time, capacity float64) (*models.Workload, error)
+}
+
+// PlannerService defines the primary port for planning study sessions.
+// This interface represents the API through which the application core can be used.
+type PlannerService interface {
+	// GetAvailability retrieves the weekly availability windows of the current user
+	GetAvailability(ctx context.Context) ([]models.AvailabilityWindow, error)
+
+	// AddAvailability adds a weekly availability window
+	// Returns ErrInvalidAvailability if the window is malformed or ErrAvailabilityOverlap if it overlaps another
+	AddAvailability(ctx context.Context, window *models.AvailabilityWindow) error
+
+	// DeleteAvailability removes an availability window
+	// Returns ErrAvailabilityNotFound if the window doesn't exist
+	DeleteAvailability(ctx context.Context, id int64) error
+
+	// GetPlan schedules study sessions on the open estimated tasks for the next days, planning at most
+	// maxHours a day; zero values select a week and 4 hours
+	// Returns ErrInvalidPlanQuery if the number of days or hours is out of range
+	GetPlan(ctx context.Context, days int, maxHours float64) (*models.StudyPlan, error)
+}
+
+// ReminderService defines the primary port for reminding users of their deadlines.
+// It is driven by a background scheduler rather than by user requests.
+type ReminderService interface {
+	// SendDueReminders delivers the reminders of every user that are due at the given time
+	// and haven't been delivered yet, and summarizes the deliveries
+	SendDueReminders(ctx context.Context, now time.Time) (*models.ReminderRun, error)
+}
+
+// ImportService defines the primary port for importing deadlines from external calendars.
+// This interface represents the API through which the application core can be used.
+type ImportService interface {
+	// ImportCalendar creates or updates tasks from calendar items, matching or creating their courses
+	// Items already imported (same UID) are updated instead of duplicated
+	// Returns ErrInvalidImportOptions if the options are invalid
+	ImportCalendar(ctx context.Context, items []models.CalendarItem, opts models.ImportOptions) (*models.ImportResult, error)
+}
+
+// WebhookService defines the primary port for outgoing webhooks: subscriptions of the current user
+// to task and course events, and the delivery of those events.
+type WebhookService interface {
+	// CreateWebhook subscribes a URL to events and generates the secret its payloads are signed with
+	// Returns ErrInvalidWebhookURL or ErrInvalidWebhookEvents if the subscription is rejected
+	CreateWebhook(ctx context.Context, webhook *models.Webhook) error
+
+	// ListWebhooks retrieves the webhooks of the current user, without their secrets
+	ListWebhooks(ctx context.Context) ([]models.Webhook, error)
+
+	// GetWebhook retrieves a webhook by its ID, without its secret
+	// Returns ErrWebhookNotFound if the webhook doesn't exist
+	GetWebhook(ctx context.Context, id int64) (*models.Webhook, error)
+
+	// UpdateWebhook replaces the URL, events and active flag of a webhook; its secret is kept
+	// Returns ErrWebhookNotFound if the webhook doesn't exist
+	UpdateWebhook(ctx context.Context, webhook *models.Webhook) error
+
+	// DeleteWebhook removes a webhook together with its delivery log
+	// Returns ErrWebhookNotFound if the webhook doesn't exist
+	DeleteWebhook(ctx context.Context, id int64) error
+
+	// GetDeliveries retrieves the latest deliveries to a webhook, newest first
+	// Returns ErrWebhookNotFound if the webhook doesn't exist
+	GetDeliveries(ctx context.Context, webhookID int64) ([]models.WebhookDelivery, error)
+
+	// DeliverPending attempts the deliveries of every user that are due at the given time and
+	// summarizes the attempts; it is driven by a background dispatcher rather than by user requests
+	DeliverPending(ctx context.Context, now time.Time) (*models.WebhookRun, error)
+}
+
+// OutboxService defines the primary port for dispatching the events recorded in the outbox.
+// It is driven by a background dispatcher rather than by user requests.
+type OutboxService interface {
+	// DispatchPending hands the events due at the given time to every subscriber, removes the
+	// dispatched events from the outbox and summarizes the pass
+	DispatchPending(ctx context.Context, now time.Time) (*models.OutboxRun, error)
+}
+
+// UserService defines the primary port for user accounts and authentication.
+// This interface represents the API through which the application core can be used.
+type UserService interface {
+	// Register creates a new user account with a hashed password
+	// Returns ErrInvalidEmail, ErrWeakPassword or ErrEmailTaken if the account data is rejected
+	Register(ctx context.Context, email, name, password string) (*models.User, error)
+
+	// Login verifies the credentials and opens a new session
+	// Returns ErrInvalidCredentials if the email or password is wrong
+	Login(ctx context.Context, email, password string) (*models.Session, error)
+
+	// Logout closes the session identified by the token
+	Logout(ctx context.Context, token string) error
+
+	// Authenticate resolves a session token to its user
+	// Returns ErrInvalidCredentials if the session is unknown or expired
+	Authenticate(ctx context.Context, token string) (*models.User, error)
+
+	// GetUser retrieves a specific user by its ID
+	GetUser(ctx context.Context, id int64) (*models.User, error)
+}
+
+// TokenService defines the primary port for personal API tokens.
+// This interface represents the API through which the application core can be used.
+type TokenService interface {
+	// CreateToken issues a new token for the current user with the given scopes and optional expiry
+	// The returned token carries its secret, which cannot be retrieved again
+	CreateToken(ctx context.Context, name string, scopes []models.TokenScope, expiresAt time.Time) (*models.APIToken, error)
+
+	// ListTokens retrieves the tokens of the current user, without their secrets
+	ListTokens(ctx context.Context) ([]models.APIToken, error)
+
+	// RevokeToken deletes a token of the current user
+	// Returns ErrTokenNotFound if the user has no such token
+	RevokeToken(ctx context.Context, id int64) error
+
+	// AuthenticateToken resolves a token secret to the token, recording its use
+	// Returns ErrInvalidToken if the token is unknown or expired
+	AuthenticateToken(ctx context.Context, secret string) (*models.APIToken, error)
 }
@@ -4,55 +4,427 @@ package output
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"uni-task-manager/internal/domain/models"
 )
 
+// ErrVersionConflict is returned by repositories when a versioned record no longer has the
+// version the caller read, because it was modified in the meantime
+var ErrVersionConflict = errors.New("stored version does not match")
+
 // TaskRepository defines the interface for task storage operations.
 // This is an output port that must be implemented by storage adapters (e.g., SQLite, PostgreSQL).
+// Every operation is scoped to the tasks of a single owner.
 type TaskRepository interface {
-	// GetAll retrieves all tasks from the storage, ordered by due date
-	GetAll(ctx context.Context) ([]models.Task, error)
+	// GetAll retrieves all tasks of the owner from the storage, ordered by due date
+	GetAll(ctx context.Context, ownerID int64) ([]models.Task, error)
+
+	// List retrieves the owner's tasks matching the query's filters, in the requested order.
+	// At most query.Limit tasks positioned after query.After are returned
+	List(ctx context.Context, ownerID int64, query models.TaskQuery) ([]models.Task, error)
 
-	// GetByID retrieves a specific task by its unique identifier
+	// GetByID retrieves a specific task of the owner by its unique identifier
 	// Returns nil if the task is not found
-	GetByID(ctx context.Context, id int64) (*models.Task, error)
+	GetByID(ctx context.Context, ownerID, id int64) (*models.Task, error)
 
-	// Create persists a new task in the storage
+	// Create persists a new task in the storage, owned by task.OwnerID
 	// The ID field will be populated with the generated identifier
 	Create(ctx context.Context, task *models.Task) error
 
-	// Update modifies an existing task in the storage
-	// Returns an error if the task doesn't exist
+	// Update modifies an existing task of task.OwnerID in the storage if it still has task.Version,
+	// and increments the version of both the stored record and the task
+	// Returns ErrVersionConflict if the stored task has another version
 	Update(ctx context.Context, task *models.Task) error
 
-	// Delete removes a task from the storage
-	// Returns an error if the task doesn't exist
-	Delete(ctx context.Context, id int64) error
+	// Delete removes a task of the owner and its subtasks from the storage if it still has the given version
+	// Returns ErrVersionConflict if the stored task has another version
+	Delete(ctx context.Context, ownerID, id, version int64) error
+
+	// GetByCourseID retrieves all tasks of the owner associated with a specific course
+	GetByCourseID(ctx context.Context, ownerID, courseID int64) ([]models.Task, error)
+
+	// DeleteByCourseID removes the owner's tasks of a specific course, together with their subtasks
+	DeleteByCourseID(ctx context.Context, ownerID, courseID int64) error
+
+	// DetachCourse removes the course reference of every task of the owner associated with the course,
+	// recording updatedAt as their modification time
+	DetachCourse(ctx context.Context, ownerID, courseID int64, updatedAt time.Time) error
+
+	// GetSubtasks retrieves all subtasks of a specific parent task of the owner
+	GetSubtasks(ctx context.Context, ownerID, parentID int64) ([]models.Task, error)
+
+	// GetUnfinishedDueBefore retrieves the unfinished tasks of every owner with a due date before the given time,
+	// ordered by due date; unlike the other operations it isn't scoped to an owner, for background jobs
+	GetUnfinishedDueBefore(ctx context.Context, before time.Time) ([]models.Task, error)
 
-	// GetByCourseID retrieves all tasks associated with a specific course
-	GetByCourseID(ctx context.Context, courseID int64) ([]models.Task, error)
+	// GetByExternalUID retrieves a task of the owner by the calendar UID it was imported from
+	// Returns nil if no task has the given UID
+	GetByExternalUID(ctx context.Context, ownerID int64, uid string) (*models.Task, error)
+
+	// GetByRecurrenceID retrieves all tasks of the series generated by a recurrence rule of the owner
+	GetByRecurrenceID(ctx context.Context, ownerID, recurrenceID int64) ([]models.Task, error)
+
+	// CountSubtasks counts the total and completed subtasks of every parent task of the owner, keyed by parent ID
+	CountSubtasks(ctx context.Context, ownerID int64) (map[int64]models.TaskProgress, error)
+
+	// Search retrieves the owner's tasks whose title, description, course name or tags contain
+	// every term of the query, most relevant first, up to limit results
+	Search(ctx context.Context, ownerID int64, query string, limit int) ([]models.TaskSearchResult, error)
 }
 
 // CourseRepository defines the interface for course storage operations.
 // This is an output port that must be implemented by storage adapters (e.g., SQLite, PostgreSQL).
+// Every operation is scoped to the courses of a single owner.
 type CourseRepository interface {
-	// GetAll retrieves all courses from the storage, ordered by name
-	GetAll(ctx context.Context) ([]models.Course, error)
+	// GetAll retrieves all courses of the owner from the storage, ordered by name
+	GetAll(ctx context.Context, ownerID int64) ([]models.Course, error)
+
+	// GetByID retrieves a specific course of the owner by its unique identifier
+	// Returns nil if the course is not found
+	GetByID(ctx context.Context, ownerID, id int64) (*models.Course, error)
 
-	// GetByID retrieves a specific course by its unique identifier
+	// GetByName retrieves a course of the owner by its name, compared case-insensitively
 	// Returns nil if the course is not found
-	GetByID(ctx context.Context, id int64) (*models.Course, error)
+	GetByName(ctx context.Context, ownerID int64, name string) (*models.Course, error)
 
-	// Create persists a new course in the storage
+	// Create persists a new course in the storage, owned by course.OwnerID
 	// The ID field will be populated with the generated identifier
 	Create(ctx context.Context, course *models.Course) error
 
-	// Update modifies an existing course in the storage
-	// Returns an error if the course doesn't exist
+	// Update modifies an existing course of course.OwnerID in the storage if it still has course.Version,
+	// and increments the version of both the stored record and the course
+	// Returns ErrVersionConflict if the stored course has another version
 	Update(ctx context.Context, course *models.Course) error
 
-	// Delete removes a course from the storage
-	// Returns an error if the course doesn't exist
+	// Delete removes a course of the owner from the storage if it still has the given version
+	// Returns ErrVersionConflict if the stored course has another version,
+	// or an error if the course is still referenced by tasks
+	Delete(ctx context.Context, ownerID, id, version int64) error
+}
+
+// TagRepository defines the interface for tag storage operations.
+// This is an output port that must be implemented by storage adapters (e.g., SQLite, PostgreSQL).
+// Every operation is scoped to the tags of a single owner.
+type TagRepository interface {
+	// GetAll retrieves all tags of the owner from the storage, ordered by name
+	GetAll(ctx context.Context, ownerID int64) ([]models.Tag, error)
+
+	// GetByID retrieves a specific tag of the owner by its unique identifier
+	// Returns nil if the tag is not found
+	GetByID(ctx context.Context, ownerID, id int64) (*models.Tag, error)
+
+	// GetByName retrieves a tag of the owner by its name, compared case-insensitively
+	// Returns nil if the tag is not found
+	GetByName(ctx context.Context, ownerID int64, name string) (*models.Tag, error)
+
+	// Create persists a new tag in the storage, owned by tag.OwnerID
+	// The ID field will be populated with the generated identifier
+	Create(ctx context.Context, tag *models.Tag) error
+
+	// Update renames an existing tag of tag.OwnerID in the storage
+	Update(ctx context.Context, tag *models.Tag) error
+
+	// Delete removes a tag of the owner from the storage, untagging the tasks that carry it
+	Delete(ctx context.Context, ownerID, id int64) error
+
+	// GetByTaskID retrieves the tags of a specific task of the owner, ordered by name
+	GetByTaskID(ctx context.Context, ownerID, taskID int64) ([]models.Tag, error)
+
+	// GetAllByTask retrieves the tags of every tagged task of the owner, keyed by task ID
+	GetAllByTask(ctx context.Context, ownerID int64) (map[int64][]models.Tag, error)
+
+	// GetByTaskIDs retrieves the tags of the given tasks of the owner, keyed by task ID
+	GetByTaskIDs(ctx context.Context, ownerID int64, taskIDs []int64) (map[int64][]models.Tag, error)
+
+	// SetTaskTags replaces the tags of a task with the given tags of the same owner
+	SetTaskTags(ctx context.Context, taskID int64, tagIDs []int64) error
+}
+
+// DependencyRepository defines the interface for storing the dependencies between tasks.
+// This is an output port that must be implemented by storage adapters (e.g., SQLite, PostgreSQL).
+// Every operation is scoped to the tasks of a single owner.
+type DependencyRepository interface {
+	// GetAll retrieves the prerequisite IDs of every task of the owner that has any, keyed by task ID
+	GetAll(ctx context.Context, ownerID int64) (map[int64][]int64, error)
+
+	// GetPrerequisites retrieves the tasks a specific task of the owner depends on, ordered by due date
+	GetPrerequisites(ctx context.Context, ownerID, taskID int64) ([]models.Task, error)
+
+	// GetDependents retrieves the tasks of the owner that depend on a specific task, ordered by due date
+	GetDependents(ctx context.Context, ownerID, taskID int64) ([]models.Task, error)
+
+	// CountOpenPrerequisites counts the unfinished prerequisites of the given unfinished tasks of the
+	// owner that have any, keyed by task ID
+	CountOpenPrerequisites(ctx context.Context, ownerID int64, taskIDs []int64) (map[int64]int, error)
+
+	// Add records that a task depends on another task of the same owner; adding an existing
+	// dependency again has no effect
+	Add(ctx context.Context, taskID, dependsOnID int64, createdAt time.Time) error
+
+	// Remove deletes the dependency of a task of the owner on another task
+	Remove(ctx context.Context, ownerID, taskID, dependsOnID int64) error
+}
+
+// TimeEntryRepository defines the interface for time entry storage operations.
+// This is an output port that must be implemented by storage adapters (e.g., SQLite, PostgreSQL).
+// Every operation is scoped to the time entries of a single owner.
+type TimeEntryRepository interface {
+	// GetByTaskID retrieves the time entries of a specific task of the owner, latest first
+	GetByTaskID(ctx context.Context, ownerID, taskID int64) ([]models.TimeEntry, error)
+
+	// GetByID retrieves a specific time entry of the owner by its unique identifier
+	// Returns nil if the time entry is not found
+	GetByID(ctx context.Context, ownerID, id int64) (*models.TimeEntry, error)
+
+	// GetRunning retrieves the running timer of the owner
+	// Returns nil if no timer is running
+	GetRunning(ctx context.Context, ownerID int64) (*models.TimeEntry, error)
+
+	// Create persists a new time entry in the storage, owned by entry.OwnerID
+	// The ID field will be populated with the generated identifier
+	Create(ctx context.Context, entry *models.TimeEntry) error
+
+	// Update modifies the end and note of an existing time entry of entry.OwnerID in the storage
+	Update(ctx context.Context, entry *models.TimeEntry) error
+
+	// Delete removes a time entry of the owner from the storage
+	Delete(ctx context.Context, ownerID, id int64) error
+
+	// SumByTask totals the time logged on every task of the owner that has any, keyed by task ID;
+	// running timers count until now
+	SumByTask(ctx context.Context, ownerID int64, now time.Time) (map[int64]time.Duration, error)
+
+	// SumByCourse totals the time logged on the tasks of every course of the owner that has any,
+	// keyed by course ID; running timers count until now
+	SumByCourse(ctx context.Context, ownerID int64, now time.Time) (map[int64]time.Duration, error)
+}
+
+// AvailabilityRepository defines the interface for availability window storage operations.
+// This is an output port that must be implemented by storage adapters (e.g., SQLite, PostgreSQL).
+// Every operation is scoped to the windows of a single owner.
+type AvailabilityRepository interface {
+	// GetAll retrieves all availability windows of the owner, ordered by weekday and start
+	GetAll(ctx context.Context, ownerID int64) ([]models.AvailabilityWindow, error)
+
+	// GetByID retrieves a specific availability window of the owner by its unique identifier
+	// Returns nil if the window is not found
+	GetByID(ctx context.Context, ownerID, id int64) (*models.AvailabilityWindow, error)
+
+	// Create persists a new availability window in the storage, owned by window.OwnerID
+	// The ID field will be populated with the generated identifier
+	Create(ctx context.Context, window *models.AvailabilityWindow) error
+
+	// Delete removes an availability window of the owner from the storage
+	Delete(ctx context.Context, ownerID, id int64) error
+}
+
+// ReminderRepository defines the interface for storing the delivery state of reminders.
+// This is an output port that must be implemented by storage adapters (e.g., SQLite, PostgreSQL).
+type ReminderRepository interface {
+	// GetByTask retrieves the deliveries of the reminders about a task
+	GetByTask(ctx context.Context, taskID int64) ([]models.ReminderDelivery, error)
+
+	// Save creates or updates the delivery of a reminder on a channel,
+	// identified by its task, rule, scheduled time and channel
+	Save(ctx context.Context, delivery *models.ReminderDelivery) error
+}
+
+// Notifier defines the interface for delivering notifications to users through a channel,
+// such as email or a webhook. This is an output port implemented by notification adapters.
+type Notifier interface {
+	// Channel names the channel; the delivery state of reminders is kept per channel
+	Channel() string
+
+	// Notify delivers the notification; an error means it wasn't delivered and may be retried
+	Notify(ctx context.Context, notification models.Notification) error
+}
+
+// TaskHistoryRepository defines the interface for task history storage operations.
+// This is an output port that must be implemented by storage adapters (e.g., SQLite, PostgreSQL).
+// Every operation is scoped to the tasks of a single owner.
+type TaskHistoryRepository interface {
+	// GetByTaskID retrieves the history of a task of the owner, newest first, including the
+	// history of a deleted task
+	GetByTaskID(ctx context.Context, ownerID, taskID int64) ([]models.TaskHistoryEntry, error)
+
+	// GetByID retrieves a specific history entry of the owner by its unique identifier
+	// Returns nil if the entry is not found
+	GetByID(ctx context.Context, ownerID, id int64) (*models.TaskHistoryEntry, error)
+
+	// Add records a change of a task of entry.OwnerID
+	// The ID field will be populated with the generated identifier
+	Add(ctx context.Context, entry *models.TaskHistoryEntry) error
+}
+
+// UnitOfWork defines the interface for running several storage operations atomically.
+// This is an output port implemented by storage adapters with their transactions.
+type UnitOfWork interface {
+	// Do runs fn atomically: the repository operations called with the context handed to fn
+	// are committed together if fn returns nil, and rolled back otherwise
+	// A unit of work started inside another one joins it
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// EventPublisher defines the interface for publishing domain events to subscribers outside the domain.
+// This is an output port implemented, for instance, by the event outbox.
+type EventPublisher interface {
+	// Publish hands an event over for delivery to its subscribers; called in a unit of work,
+	// the event is only delivered if the unit of work commits
+	Publish(ctx context.Context, event models.Event) error
+}
+
+// EventSubscriber defines the interface for consumers of the events dispatched from the outbox.
+// This is an output port implemented, for instance, by the webhook registry.
+type EventSubscriber interface {
+	// HandleEvent processes an event; it runs in the unit of work that removes the event from
+	// the outbox, so an error leaves the event to be dispatched again
+	HandleEvent(ctx context.Context, message models.OutboxMessage) error
+}
+
+// OutboxRepository defines the interface for event outbox storage operations.
+// This is an output port that must be implemented by storage adapters (e.g., SQLite, PostgreSQL).
+// Unlike the other repositories it isn't scoped to an owner, for the dispatcher.
+type OutboxRepository interface {
+	// Add records an event in the outbox
+	// The ID field will be populated with the generated identifier
+	Add(ctx context.Context, message *models.OutboxMessage) error
+
+	// GetDue retrieves the messages to dispatch by the given time, in the order they were added
+	GetDue(ctx context.Context, now time.Time, limit int) ([]models.OutboxMessage, error)
+
+	// Update records a failed attempt to dispatch a message
+	Update(ctx context.Context, message *models.OutboxMessage) error
+
+	// Delete removes a dispatched message from the outbox
 	Delete(ctx context.Context, id int64) error
 }
+
+// WebhookRepository defines the interface for webhook and webhook delivery storage operations.
+// This is an output port that must be implemented by storage adapters (e.g., SQLite, PostgreSQL).
+// Every operation is scoped to the webhooks of a single owner, except those used by the delivery job.
+type WebhookRepository interface {
+	// GetAll retrieves all webhooks of the owner, in creation order
+	GetAll(ctx context.Context, ownerID int64) ([]models.Webhook, error)
+
+	// GetByID retrieves a specific webhook of the owner by its unique identifier
+	// Returns nil if the webhook is not found
+	GetByID(ctx context.Context, ownerID, id int64) (*models.Webhook, error)
+
+	// Create persists a new webhook in the storage, owned by webhook.OwnerID
+	// The ID field will be populated with the generated identifier
+	Create(ctx context.Context, webhook *models.Webhook) error
+
+	// Update modifies the URL, events and active flag of an existing webhook of webhook.OwnerID
+	Update(ctx context.Context, webhook *models.Webhook) error
+
+	// Delete removes a webhook of the owner and its deliveries from the storage
+	Delete(ctx context.Context, ownerID, id int64) error
+
+	// CreateDelivery queues a new delivery of an event to a webhook
+	// The ID field will be populated with the generated identifier
+	CreateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error
+
+	// UpdateDelivery records the outcome of an attempt to deliver an event
+	UpdateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error
+
+	// GetDeliveries retrieves the latest deliveries to a webhook of the owner, newest first
+	GetDeliveries(ctx context.Context, ownerID, webhookID int64, limit int) ([]models.WebhookDelivery, error)
+
+	// GetDueDeliveries retrieves the pending deliveries of every owner to attempt by the given time,
+	// oldest first; unlike the other operations it isn't scoped to an owner, for the delivery job
+	GetDueDeliveries(ctx context.Context, now time.Time, limit int) ([]models.WebhookDelivery, error)
+}
+
+// WebhookClient defines the interface for posting webhook payloads over HTTP.
+// This is an output port implemented by an HTTP client adapter.
+type WebhookClient interface {
+	// CheckURL reports why the client won't deliver to the URL, such as a host resolving to a private address
+	CheckURL(ctx context.Context, url string) error
+
+	// Post sends the body with the given headers to the URL and returns the response status
+	// An error means that no response was received
+	Post(ctx context.Context, url string, headers map[string]string, body []byte) (int, error)
+}
+
+// RecurrenceRepository defines the interface for recurrence rule storage operations.
+// This is an output port that must be implemented by storage adapters (e.g., SQLite, PostgreSQL).
+// Every operation is scoped to the rules of a single owner.
+type RecurrenceRepository interface {
+	// GetByID retrieves a specific recurrence rule of the owner by its unique identifier
+	// Returns nil if the rule is not found
+	GetByID(ctx context.Context, ownerID, id int64) (*models.Recurrence, error)
+
+	// Create persists a new recurrence rule in the storage, owned by rule.OwnerID
+	// The ID field will be populated with the generated identifier
+	Create(ctx context.Context, rule *models.Recurrence) error
+
+	// Update modifies an existing recurrence rule of rule.OwnerID in the storage
+	Update(ctx context.Context, rule *models.Recurrence) error
+
+	// Delete removes a recurrence rule of the owner from the storage
+	// The tasks of its series are kept but no longer reference the rule
+	Delete(ctx context.Context, ownerID, id int64) error
+}
+
+// UserRepository defines the interface for user account storage operations.
+// This is an output port that must be implemented by storage adapters (e.g., SQLite, PostgreSQL).
+type UserRepository interface {
+	// GetByID retrieves a specific user by its unique identifier
+	// Returns nil if the user is not found
+	GetByID(ctx context.Context, id int64) (*models.User, error)
+
+	// GetByEmail retrieves a user by email address, compared case-insensitively
+	// Returns nil if the user is not found
+	GetByEmail(ctx context.Context, email string) (*models.User, error)
+
+	// Count returns the number of registered users
+	Count(ctx context.Context) (int, error)
+
+	// Create persists a new user in the storage
+	// The ID field will be populated with the generated identifier
+	Create(ctx context.Context, user *models.User) error
+
+	// ClaimUnowned assigns every course, task and recurrence rule without an owner to the user
+	ClaimUnowned(ctx context.Context, userID int64) error
+}
+
+// SessionRepository defines the interface for browser session storage operations.
+// This is an output port that must be implemented by storage adapters (e.g., SQLite, PostgreSQL).
+type SessionRepository interface {
+	// GetByTokenHash retrieves a session by the hash of its token
+	// Returns nil if the session is not found
+	GetByTokenHash(ctx context.Context, tokenHash string) (*models.Session, error)
+
+	// Create persists a new session in the storage
+	Create(ctx context.Context, session *models.Session) error
+
+	// Delete removes a session from the storage
+	Delete(ctx context.Context, tokenHash string) error
+}
+
+// APITokenRepository defines the interface for API token storage operations.
+// This is an output port that must be implemented by storage adapters (e.g., SQLite, PostgreSQL).
+type APITokenRepository interface {
+	// GetByID retrieves a specific token of the user by its unique identifier
+	// Returns nil if the user has no token with the given ID
+	GetByID(ctx context.Context, userID, id int64) (*models.APIToken, error)
+
+	// GetByTokenHash retrieves a token by the hash of its secret
+	// Returns nil if the token is not found
+	GetByTokenHash(ctx context.Context, tokenHash string) (*models.APIToken, error)
+
+	// GetByUserID retrieves all tokens of a user, newest first
+	GetByUserID(ctx context.Context, userID int64) ([]models.APIToken, error)
+
+	// Create persists a new token in the storage
+	// The ID field will be populated with the generated identifier
+	Create(ctx context.Context, token *models.APIToken) error
+
+	// Delete removes a token of the user from the storage
+	Delete(ctx context.Context, userID, id int64) error
+
+	// UpdateLastUsed records the moment the token last authenticated a request
+	UpdateLastUsed(ctx context.Context, id int64, usedAt time.Time) error
+}